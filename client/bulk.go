@@ -0,0 +1,166 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// DefaultBulkParallelism is the number of operations a Bulk runs at once,
+// unless overridden.
+const DefaultBulkParallelism = 4
+
+// Bulk runs many operations against the API, such as creating hundreds of
+// columns, with bounded concurrency.
+//
+// Operations made with a Client share its rate limiter and retries, so a
+// Bulk with a high Parallelism does not exceed the API's rate limit: the
+// excess operations wait their turn, and rate limited requests back off as
+// they would if they had been made one at a time.
+//
+// The zero value is ready to use, running DefaultBulkParallelism operations
+// at once and attempting every one regardless of failures.
+type Bulk[T any] struct {
+	// Parallelism is the maximum number of operations run at once.
+	// Defaults to DefaultBulkParallelism.
+	Parallelism int
+	// StopOnError stops the Bulk starting any further operations once one
+	// has failed, and cancels the context of those still running.
+	// Otherwise, every operation is attempted.
+	StopOnError bool
+}
+
+// Run calls op for each index from 0 to n-1, returning the results in the
+// order of their indexes.
+//
+// If any operation fails, a *BulkError is returned describing which, and
+// the results of those which failed or were not attempted are nil.
+func (b Bulk[T]) Run(ctx context.Context, n int, op func(ctx context.Context, i int) (*T, error)) ([]*T, error) {
+	parallelism := b.Parallelism
+	if parallelism <= 0 {
+		parallelism = DefaultBulkParallelism
+	}
+
+	parent := ctx
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+
+	results := make([]*T, n)
+	errs := make([]error, n)
+	attempted := make([]bool, n)
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, parallelism)
+	for i := range n {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			break
+		}
+		attempted[i] = true
+
+		wg.Add(1)
+		go func() {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			results[i], errs[i] = op(ctx, i)
+			if errs[i] != nil {
+				results[i] = nil
+				if b.StopOnError {
+					cancel(errBulkStopped)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	bulkErr := &BulkError{Total: n, ctxErr: parent.Err()}
+	stopped := errors.Is(context.Cause(ctx), errBulkStopped)
+	for i := range n {
+		switch {
+		case !attempted[i]:
+			bulkErr.Skipped = append(bulkErr.Skipped, i)
+		case errs[i] == nil:
+		case stopped && errors.Is(errs[i], context.Canceled):
+			// cancelled by another operation failing, rather than failing
+			// in its own right
+			bulkErr.Skipped = append(bulkErr.Skipped, i)
+		default:
+			bulkErr.Errors = append(bulkErr.Errors, BulkItemError{Index: i, Err: errs[i]})
+		}
+	}
+	if len(bulkErr.Errors) == 0 && len(bulkErr.Skipped) == 0 {
+		return results, nil
+	}
+	return results, bulkErr
+}
+
+// errBulkStopped is the cause of the cancellation of a Bulk's context when
+// it stops on an error.
+var errBulkStopped = errors.New("bulk operation stopped after an error")
+
+// BulkItemError is the failure of a single operation of a Bulk.
+type BulkItemError struct {
+	// Index of the operation.
+	Index int
+	Err   error
+}
+
+func (e BulkItemError) Error() string {
+	return fmt.Sprintf("item %d: %s", e.Index, e.Err)
+}
+
+func (e BulkItemError) Unwrap() error { return e.Err }
+
+// BulkError is returned by Bulk.Run when any of its operations failed or
+// were not attempted.
+type BulkError struct {
+	// Total is the number of operations the Bulk was asked to run.
+	Total int
+	// Errors are the failed operations, in the order of their indexes.
+	Errors []BulkItemError
+	// Skipped are the indexes of the operations which were not attempted,
+	// or were cancelled, either because the Bulk stopped on an error or
+	// because its context was done.
+	Skipped []int
+
+	// ctxErr is the error of the context passed to Run, if it was done
+	ctxErr error
+}
+
+func (e *BulkError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d of %d items failed", len(e.Errors), e.Total)
+	if len(e.Skipped) > 0 {
+		fmt.Fprintf(&b, ", %d skipped", len(e.Skipped))
+	}
+	for i, err := range e.Errors {
+		if i == 0 {
+			b.WriteString(": ")
+		} else {
+			b.WriteString("; ")
+		}
+		b.WriteString(err.Error())
+	}
+	return b.String()
+}
+
+// Unwrap returns the errors of the failed operations, so that errors.Is and
+// errors.As can be used to look for a particular failure, along with the
+// context's error if it ended the Bulk early.
+func (e *BulkError) Unwrap() []error {
+	errs := make([]error, 0, len(e.Errors)+1)
+	for _, err := range e.Errors {
+		errs = append(errs, err)
+	}
+	if e.ctxErr != nil {
+		errs = append(errs, e.ctxErr)
+	}
+	return errs
+}
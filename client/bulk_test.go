@@ -0,0 +1,134 @@
+package client_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/honeycombio/terraform-provider-honeycombio/client"
+)
+
+func TestBulk(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	t.Run("creates columns", func(t *testing.T) {
+		s, c := newFakeTestClient(t)
+		ds, err := c.Datasets.Create(ctx, &client.Dataset{Name: "bulk"})
+		require.NoError(t, err)
+
+		keys := make([]string, 50)
+		for i := range keys {
+			keys[i] = fmt.Sprintf("column_%d", i)
+		}
+		cols, err := client.Bulk[client.Column]{Parallelism: 8}.Run(ctx, len(keys),
+			func(ctx context.Context, i int) (*client.Column, error) {
+				return c.Columns.Create(ctx, ds.Slug, &client.Column{KeyName: keys[i]})
+			},
+		)
+		require.NoError(t, err)
+		require.Len(t, cols, len(keys))
+		for i, col := range cols {
+			assert.Equal(t, keys[i], col.KeyName)
+		}
+		assert.Equal(t, len(keys), s.RequestCount("POST /1/columns/{dataset}"))
+	})
+
+	t.Run("collects failures", func(t *testing.T) {
+		_, c := newFakeTestClient(t)
+		ds, err := c.Datasets.Create(ctx, &client.Dataset{Name: "bulk"})
+		require.NoError(t, err)
+
+		// every other key is a duplicate of the one before it
+		keys := []string{"a", "a", "b", "b", "c"}
+		cols, err := client.Bulk[client.Column]{Parallelism: 1}.Run(ctx, len(keys),
+			func(ctx context.Context, i int) (*client.Column, error) {
+				return c.Columns.Create(ctx, ds.Slug, &client.Column{KeyName: keys[i]})
+			},
+		)
+
+		var bulkErr *client.BulkError
+		require.ErrorAs(t, err, &bulkErr)
+		assert.Equal(t, 5, bulkErr.Total)
+		assert.Empty(t, bulkErr.Skipped)
+		require.Len(t, bulkErr.Errors, 2)
+		assert.Equal(t, 1, bulkErr.Errors[0].Index)
+		assert.Equal(t, 3, bulkErr.Errors[1].Index)
+		assert.ErrorIs(t, err, client.ErrConflict)
+		assert.EqualError(t, err, "2 of 5 items failed: item 1: "+bulkErr.Errors[0].Err.Error()+"; item 3: "+bulkErr.Errors[1].Err.Error())
+
+		assert.NotNil(t, cols[0])
+		assert.Nil(t, cols[1])
+		assert.NotNil(t, cols[4])
+	})
+
+	t.Run("bounds parallelism", func(t *testing.T) {
+		var running, peak atomic.Int32
+		_, err := client.Bulk[int]{Parallelism: 3}.Run(ctx, 20,
+			func(_ context.Context, i int) (*int, error) {
+				n := running.Add(1)
+				defer running.Add(-1)
+				for {
+					p := peak.Load()
+					if n <= p || peak.CompareAndSwap(p, n) {
+						break
+					}
+				}
+				time.Sleep(5 * time.Millisecond)
+				return &i, nil
+			},
+		)
+		require.NoError(t, err)
+		assert.Equal(t, int32(3), peak.Load())
+	})
+
+	t.Run("stops on first error", func(t *testing.T) {
+		boom := errors.New("boom")
+		var calls atomic.Int32
+		results, err := client.Bulk[int]{Parallelism: 2, StopOnError: true}.Run(ctx, 10,
+			func(ctx context.Context, i int) (*int, error) {
+				calls.Add(1)
+				switch i {
+				case 0:
+					return nil, boom
+				case 1:
+					// still running when item 0 fails
+					<-ctx.Done()
+					return nil, ctx.Err()
+				}
+				return &i, nil
+			},
+		)
+
+		var bulkErr *client.BulkError
+		require.ErrorAs(t, err, &bulkErr)
+		require.Len(t, bulkErr.Errors, 1)
+		assert.Equal(t, 0, bulkErr.Errors[0].Index)
+		require.ErrorIs(t, err, boom)
+		assert.NotErrorIs(t, err, context.Canceled)
+		assert.Contains(t, bulkErr.Skipped, 1)
+		assert.Len(t, bulkErr.Skipped, 10-1-(int(calls.Load())-2))
+		assert.Nil(t, results[0])
+		assert.Nil(t, results[1])
+	})
+
+	t.Run("stops when the context is done", func(t *testing.T) {
+		cctx, cancel := context.WithCancel(ctx)
+		cancel()
+
+		_, err := client.Bulk[int]{}.Run(cctx, 5, func(_ context.Context, i int) (*int, error) {
+			return &i, nil
+		})
+		var bulkErr *client.BulkError
+		require.ErrorAs(t, err, &bulkErr)
+		assert.Len(t, bulkErr.Skipped, 5)
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+}
@@ -10,7 +10,6 @@ import (
 	"errors"
 	"fmt"
 	"io"
-	"log"
 	"net/http"
 	"net/url"
 	"os"
@@ -21,6 +20,8 @@ import (
 	retryablehttp "github.com/hashicorp/go-retryablehttp"
 
 	"github.com/honeycombio/terraform-provider-honeycombio/client/internal/limits"
+	"github.com/honeycombio/terraform-provider-honeycombio/client/internal/logging"
+	"github.com/honeycombio/terraform-provider-honeycombio/client/internal/tracing"
 )
 
 const (
@@ -34,18 +35,59 @@ const (
 	EnvironmentWideSlug = "__all__"
 )
 
+// Defaults for retrying failed requests, used by both the v1 and v2 clients.
+const (
+	DefaultRetryMax     = 30
+	DefaultRetryWaitMin = 200 * time.Millisecond
+	DefaultRetryWaitMax = time.Minute
+)
+
 // Config holds all configuration options for the client.
 type Config struct {
 	// Required - the API key to use when sending request to Honeycomb.
 	APIKey string
 	// URL of the Honeycomb API, defaults to "https://api.honeycomb.io".
 	APIUrl string
-	// With debug enabled the client will log all requests and responses.
+	// With debug enabled the client will log all requests and responses,
+	// with credentials redacted, via tflog.
 	Debug bool
 	// Optionally override the HTTP client with a custom client.
 	HTTPClient *http.Client
 	// Optionally set the user agent to send with all requests, defaults to "go-honeycombio".
 	UserAgent string
+	// Optionally limit the number of requests made per second.
+	//
+	// Regardless of this setting, requests are slowed down as the rate limit
+	// budget reported by the API runs low.
+	MaxRequestsPerSecond float64
+	// Optionally share a RateLimiter with other clients, such as the v2
+	// client, so that all of their requests draw from the same budget.
+	// When set, MaxRequestsPerSecond is ignored in favour of the
+	// RateLimiter's own ceiling.
+	RateLimiter *RateLimiter
+	// Optionally override the number of times a failed request is retried,
+	// and the bounds of the wait between attempts. Default to
+	// DefaultRetryMax, DefaultRetryWaitMin and DefaultRetryWaitMax. A
+	// RetryMax of zero disables retries.
+	//
+	// Creates are only retried after a server error if repeating them is
+	// harmless, such as creating a query, or once the client has checked
+	// the failed attempt did not create anything, such as creating a
+	// trigger.
+	RetryMax     *int
+	RetryWaitMin time.Duration
+	RetryWaitMax time.Duration
+}
+
+// RateLimiter paces the requests made by the clients sharing it, both to a
+// fixed requests-per-second ceiling and to the rate limit budget reported
+// by the API.
+type RateLimiter = limits.Limiter
+
+// NewRateLimiter returns a RateLimiter allowing at most maxRequestsPerSecond
+// requests per second. A value of zero or less imposes no fixed limit.
+func NewRateLimiter(maxRequestsPerSecond float64) *RateLimiter {
+	return limits.NewLimiter(maxRequestsPerSecond)
 }
 
 // Client to interact with Honeycomb.
@@ -76,11 +118,14 @@ type Client struct {
 // DefaultConfig returns a Config initilized with default values.
 func DefaultConfig() *Config {
 	c := &Config{
-		APIKey:     os.Getenv(DefaultAPIKeyEnv),
-		APIUrl:     os.Getenv(DefaultAPIEndpointEnv),
-		Debug:      false,
-		HTTPClient: cleanhttp.DefaultPooledClient(),
-		UserAgent:  defaultUserAgent,
+		APIKey:       os.Getenv(DefaultAPIKeyEnv),
+		APIUrl:       os.Getenv(DefaultAPIEndpointEnv),
+		Debug:        false,
+		HTTPClient:   cleanhttp.DefaultPooledClient(),
+		UserAgent:    defaultUserAgent,
+		RetryMax:     ToPtr(DefaultRetryMax),
+		RetryWaitMin: DefaultRetryWaitMin,
+		RetryWaitMax: DefaultRetryWaitMax,
 	}
 
 	// if API Key is still unset, try using the legacy environment variable
@@ -117,6 +162,24 @@ func NewClientWithConfig(config *Config) (*Client, error) {
 	if config.HTTPClient != nil {
 		cfg.HTTPClient = config.HTTPClient
 	}
+	if config.MaxRequestsPerSecond > 0 {
+		cfg.MaxRequestsPerSecond = config.MaxRequestsPerSecond
+	}
+	if config.RetryMax != nil {
+		cfg.RetryMax = config.RetryMax
+	}
+	if config.RetryWaitMin > 0 {
+		cfg.RetryWaitMin = config.RetryWaitMin
+	}
+	if config.RetryWaitMax > 0 {
+		cfg.RetryWaitMax = config.RetryWaitMax
+	}
+	if *cfg.RetryMax < 0 {
+		return nil, errors.New("the number of retries must not be negative")
+	}
+	if cfg.RetryWaitMin > cfg.RetryWaitMax {
+		return nil, errors.New("the minimum wait between retries must not be greater than the maximum")
+	}
 
 	if cfg.APIKey == "" {
 		return nil, errors.New("APIKey must be configured")
@@ -132,22 +195,25 @@ func NewClientWithConfig(config *Config) (*Client, error) {
 		headers: make(http.Header),
 	}
 
+	limiter := config.RateLimiter
+	if limiter == nil {
+		limiter = NewRateLimiter(cfg.MaxRequestsPerSecond)
+	}
+	httpClient := limits.NewHTTPClient(cfg.HTTPClient, limiter)
+	// every attempt is traced, including the time spent held back by the limiter
+	httpClient = tracing.NewHTTPClient(httpClient)
+	if config.Debug {
+		// if enabled we log all requests and responses, with credentials redacted
+		httpClient = logging.NewHTTPClient(httpClient)
+	}
 	client.httpClient = &retryablehttp.Client{
 		Backoff:      limits.RetryHTTPBackoff,
 		CheckRetry:   limits.RetryHTTPCheck,
 		ErrorHandler: retryablehttp.PassthroughErrorHandler,
-		HTTPClient:   cfg.HTTPClient,
-		RetryWaitMin: 200 * time.Millisecond,
-		RetryWaitMax: time.Minute,
-		RetryMax:     30,
-	}
-
-	if config.Debug {
-		// if enabled we log all requests and responses to sterr
-		client.httpClient.Logger = log.New(os.Stderr, "", log.LstdFlags)
-		client.httpClient.ResponseLogHook = func(l retryablehttp.Logger, resp *http.Response) {
-			l.Printf("[DEBUG] Response: %s %s", resp.Request.Method, resp.Request.URL.String())
-		}
+		HTTPClient:   httpClient,
+		RetryWaitMin: cfg.RetryWaitMin,
+		RetryWaitMax: cfg.RetryWaitMax,
+		RetryMax:     *cfg.RetryMax,
 	}
 
 	client.headers.Add("Content-Type", "application/json")
@@ -233,6 +299,10 @@ func (c *Client) newRequest(ctx context.Context, method, path string, body any)
 		return nil, err
 	}
 
+	// each request is a new operation, however many times it is retried
+	ctx = logging.WithOperation(ctx)
+	ctx = tracing.WithOperation(ctx)
+	ctx = limits.WithRetryPolicy(ctx, limits.RetryPolicyFor(method, url.Path))
 	req, err := retryablehttp.NewRequestWithContext(ctx, method, url.String(), bodyReader)
 	if err != nil {
 		return nil, err
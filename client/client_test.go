@@ -4,12 +4,14 @@ import (
 	"context"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/joho/godotenv"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/honeycombio/terraform-provider-honeycombio/client"
+	"github.com/honeycombio/terraform-provider-honeycombio/client/fakeserver"
 )
 
 const testUserAgent = "go-honeycombio/test"
@@ -36,6 +38,24 @@ func newTestClient(t *testing.T) *client.Client {
 	return c
 }
 
+// newFakeTestClient returns a Client configured to use a new fake server,
+// which is closed when the test completes.
+func newFakeTestClient(t *testing.T, opts ...fakeserver.Option) (*fakeserver.Server, *client.Client) {
+	t.Helper()
+
+	s := fakeserver.New(opts...)
+	t.Cleanup(s.Close)
+
+	c, err := client.NewClientWithConfig(&client.Config{
+		APIKey:    s.APIKey(),
+		APIUrl:    s.URL,
+		UserAgent: testUserAgent,
+	})
+	require.NoError(t, err, "failed to create test client")
+
+	return s, c
+}
+
 func testDataset(t *testing.T) string {
 	t.Helper()
 
@@ -90,3 +110,33 @@ func TestClient_EndpointURL(t *testing.T) {
 
 	assert.Equal(t, endpointUrl, c.EndpointURL().String())
 }
+
+func TestClient_SharedRateLimiter(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	s := fakeserver.New()
+	t.Cleanup(s.Close)
+
+	// two requests per second, with a burst of two
+	limiter := client.NewRateLimiter(2)
+	clients := make([]*client.Client, 2)
+	for i := range clients {
+		c, err := client.NewClientWithConfig(&client.Config{
+			APIKey:      s.APIKey(),
+			APIUrl:      s.URL,
+			UserAgent:   testUserAgent,
+			RateLimiter: limiter,
+		})
+		require.NoError(t, err)
+		clients[i] = c
+	}
+
+	// the burst is used up across both clients, so the third request waits
+	start := time.Now()
+	for _, c := range []*client.Client{clients[0], clients[1], clients[0]} {
+		_, err := c.Datasets.List(ctx)
+		require.NoError(t, err)
+	}
+	assert.GreaterOrEqual(t, time.Since(start), 400*time.Millisecond)
+}
@@ -0,0 +1,851 @@
+// Package clone copies the configuration of one Honeycomb environment into
+// another, such as when promoting the configuration of a staging
+// environment to production.
+//
+// The datasets, columns, derived columns, dataset definitions, queries,
+// query annotations, SLOs, burn alerts, triggers, recipients and flexible
+// boards of the source environment are recreated in the destination, with
+// the IDs they reference remapped to those of their copies.
+//
+// Configuration which already exists in the destination is left as it is,
+// and its ID used in place of a copy's: datasets, boards, triggers, SLOs and
+// query annotations are matched by name, columns by key name, derived
+// columns by alias, and recipients by type and target. The burn alerts of
+// an SLO which already existed are not cloned, as they cannot be matched.
+// Dataset definitions are always overwritten, and queries always created.
+package clone
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/honeycombio/terraform-provider-honeycombio/client"
+)
+
+// Options configure a clone.
+type Options struct {
+	// DryRun reports the changes a clone would make without making any.
+	// The destination is still read, to determine what already exists.
+	DryRun bool
+	// Datasets limits the clone to the datasets with these slugs in the
+	// source environment. Defaults to every dataset.
+	//
+	// Environment-wide configuration is always cloned, though anything
+	// referencing a dataset which is not cloned is skipped.
+	Datasets []string
+}
+
+// Clone recreates the configuration of the source environment in the
+// destination environment, returning a report of the changes made.
+//
+// The clone stops at the first failure, in which case the report lists the
+// changes made until then.
+func Clone(ctx context.Context, src, dst *client.Client, opts Options) (*Report, error) {
+	c := &cloner{
+		src:         src,
+		dst:         dst,
+		opts:        opts,
+		report:      &Report{DryRun: opts.DryRun},
+		datasets:    make(map[string]string),
+		newDatasets: make(map[string]bool),
+		recipients:  make(map[string]string),
+		queries:     make(map[string]string),
+		querySpecs:  make(map[string]string),
+		annotations: make(map[string]string),
+		slos:        make(map[string]string),
+	}
+	err := c.clone(ctx)
+	return c.report, err
+}
+
+type cloner struct {
+	src, dst *client.Client
+	opts     Options
+	report   *Report
+
+	// the following map the IDs of the source environment to those of the
+	// destination environment
+
+	// datasets maps slugs, including the environment-wide slug
+	datasets map[string]string
+	// newDatasets are the destination slugs of created datasets, which
+	// can't be read during a dry run
+	newDatasets map[string]bool
+	recipients  map[string]string
+	queries     map[string]string
+	annotations map[string]string
+	slos        map[string]string
+
+	// querySpecs maps the destination slug and Hash of each cloned query to
+	// the ID of its copy, so that equivalent queries are only cloned once
+	querySpecs map[string]string
+
+	// plannedSequence numbers the placeholder IDs of a dry run
+	plannedSequence int
+}
+
+func (c *cloner) clone(ctx context.Context) error {
+	if err := c.cloneRecipients(ctx); err != nil {
+		return err
+	}
+	slugs, err := c.cloneDatasets(ctx)
+	if err != nil {
+		return err
+	}
+
+	scopes := append(slices.Clone(slugs), client.EnvironmentWideSlug)
+
+	for _, slug := range slugs {
+		if err := c.cloneColumns(ctx, slug); err != nil {
+			return err
+		}
+	}
+	for _, slug := range scopes {
+		if err := c.cloneDerivedColumns(ctx, slug); err != nil {
+			return err
+		}
+	}
+	// dataset definitions may name derived columns, including
+	// environment-wide ones, so are cloned after all of them
+	for _, slug := range slugs {
+		if err := c.cloneDatasetDefinition(ctx, slug); err != nil {
+			return err
+		}
+	}
+
+	for _, slug := range scopes {
+		if err := c.cloneQueryAnnotations(ctx, slug); err != nil {
+			return err
+		}
+		if err := c.cloneSLOs(ctx, slug); err != nil {
+			return err
+		}
+		if err := c.cloneTriggers(ctx, slug); err != nil {
+			return err
+		}
+	}
+
+	return c.cloneBoards(ctx)
+}
+
+// create records the creation of a piece of configuration, calling create
+// to make it unless this is a dry run. The ID of the created configuration,
+// or a placeholder during a dry run, is returned.
+func (c *cloner) create(change Change, create func() (string, error)) (string, error) {
+	change.Action = ActionCreate
+	if c.opts.DryRun {
+		c.plannedSequence++
+		c.report.add(change)
+		return fmt.Sprintf("planned-%d", c.plannedSequence), nil
+	}
+
+	id, err := create()
+	if err != nil {
+		return "", fmt.Errorf("creating %s %q: %w", change.Kind, change.Name, err)
+	}
+	change.DestinationID = id
+	c.report.add(change)
+	return id, nil
+}
+
+func (c *cloner) exists(change Change, id string) {
+	change.Action = ActionSkip
+	change.DestinationID = id
+	change.Reason = "already exists"
+	c.report.add(change)
+}
+
+func (c *cloner) skip(change Change, reason string) {
+	change.Action = ActionSkip
+	change.Reason = reason
+	c.report.add(change)
+}
+
+// destinationExists returns whether the destination dataset can be read,
+// which it can't during a dry run if the clone would have created it.
+func (c *cloner) destinationExists(dstSlug string) bool {
+	return !c.newDatasets[dstSlug]
+}
+
+func (c *cloner) cloneRecipients(ctx context.Context) error {
+	srcRecipients, err := c.src.Recipients.List(ctx)
+	if err != nil {
+		return fmt.Errorf("listing source recipients: %w", err)
+	}
+	dstRecipients, err := c.dst.Recipients.List(ctx)
+	if err != nil {
+		return fmt.Errorf("listing destination recipients: %w", err)
+	}
+	existing := make(map[string]string, len(dstRecipients))
+	for _, r := range dstRecipients {
+		existing[recipientKey(r)] = r.ID
+	}
+
+	for _, r := range srcRecipients {
+		change := Change{
+			Kind:     KindRecipient,
+			Name:     string(r.Type) + ":" + recipientTarget(r),
+			SourceID: r.ID,
+		}
+		if id, ok := existing[recipientKey(r)]; ok {
+			c.exists(change, id)
+			c.recipients[r.ID] = id
+			continue
+		}
+
+		id, err := c.create(change, func() (string, error) {
+			created, err := c.dst.Recipients.Create(ctx, &client.Recipient{
+				Type:    r.Type,
+				Details: r.Details,
+			})
+			if err != nil {
+				return "", err
+			}
+			return created.ID, nil
+		})
+		if err != nil {
+			return err
+		}
+		c.recipients[r.ID] = id
+	}
+	return nil
+}
+
+// cloneDatasets clones the source datasets selected by the options,
+// returning their slugs.
+func (c *cloner) cloneDatasets(ctx context.Context) ([]string, error) {
+	c.datasets[client.EnvironmentWideSlug] = client.EnvironmentWideSlug
+
+	srcDatasets, err := c.src.Datasets.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("listing source datasets: %w", err)
+	}
+	dstDatasets, err := c.dst.Datasets.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("listing destination datasets: %w", err)
+	}
+	existing := make(map[string]string, len(dstDatasets))
+	for _, d := range dstDatasets {
+		existing[d.Name] = d.Slug
+	}
+
+	var slugs []string
+	for _, d := range srcDatasets {
+		if len(c.opts.Datasets) > 0 && !slices.Contains(c.opts.Datasets, d.Slug) {
+			continue
+		}
+		slugs = append(slugs, d.Slug)
+
+		change := Change{
+			Kind:     KindDataset,
+			Dataset:  d.Slug,
+			Name:     d.Name,
+			SourceID: d.Slug,
+		}
+		if slug, ok := existing[d.Name]; ok {
+			c.exists(change, slug)
+			c.datasets[d.Slug] = slug
+			continue
+		}
+
+		slug, err := c.create(change, func() (string, error) {
+			created, err := c.dst.Datasets.Create(ctx, &client.Dataset{
+				Name:            d.Name,
+				Description:     d.Description,
+				ExpandJSONDepth: d.ExpandJSONDepth,
+			})
+			if err != nil {
+				return "", err
+			}
+			return created.Slug, nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		if c.opts.DryRun {
+			// the placeholder would be of no use to a reader of the
+			// report, and datasets are usually slugged the same
+			slug = d.Slug
+			c.newDatasets[slug] = true
+		}
+		c.datasets[d.Slug] = slug
+	}
+	return slugs, nil
+}
+
+func (c *cloner) cloneColumns(ctx context.Context, slug string) error {
+	dstSlug := c.datasets[slug]
+
+	srcColumns, err := c.src.Columns.List(ctx, slug)
+	if err != nil {
+		return fmt.Errorf("listing columns of source dataset %q: %w", slug, err)
+	}
+	existing := make(map[string]string)
+	if c.destinationExists(dstSlug) {
+		dstColumns, err := c.dst.Columns.List(ctx, dstSlug)
+		if err != nil {
+			return fmt.Errorf("listing columns of destination dataset %q: %w", dstSlug, err)
+		}
+		for _, col := range dstColumns {
+			existing[col.KeyName] = col.ID
+		}
+	}
+
+	var missing []client.Column
+	for _, col := range srcColumns {
+		change := Change{
+			Kind:     KindColumn,
+			Dataset:  slug,
+			Name:     col.KeyName,
+			SourceID: col.ID,
+		}
+		if id, ok := existing[col.KeyName]; ok {
+			c.exists(change, id)
+			continue
+		}
+		if c.opts.DryRun {
+			_, _ = c.create(change, nil)
+			continue
+		}
+		missing = append(missing, col)
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+
+	// datasets can have many hundreds of columns
+	created, err := client.Bulk[client.Column]{StopOnError: true}.Run(ctx, len(missing),
+		func(ctx context.Context, i int) (*client.Column, error) {
+			return c.dst.Columns.Create(ctx, dstSlug, &client.Column{
+				KeyName:     missing[i].KeyName,
+				Hidden:      missing[i].Hidden,
+				Description: missing[i].Description,
+				Type:        missing[i].Type,
+			})
+		},
+	)
+	for i, col := range created {
+		if col == nil {
+			continue
+		}
+		c.report.add(Change{
+			Kind:          KindColumn,
+			Action:        ActionCreate,
+			Dataset:       slug,
+			Name:          missing[i].KeyName,
+			SourceID:      missing[i].ID,
+			DestinationID: col.ID,
+		})
+	}
+	if err != nil {
+		return fmt.Errorf("creating columns in dataset %q: %w", dstSlug, err)
+	}
+	return nil
+}
+
+func (c *cloner) cloneDerivedColumns(ctx context.Context, slug string) error {
+	dstSlug := c.datasets[slug]
+
+	srcColumns, err := c.src.DerivedColumns.List(ctx, slug)
+	if err != nil {
+		return fmt.Errorf("listing derived columns of source dataset %q: %w", slug, err)
+	}
+	existing := make(map[string]string)
+	if c.destinationExists(dstSlug) {
+		dstColumns, err := c.dst.DerivedColumns.List(ctx, dstSlug)
+		if err != nil {
+			return fmt.Errorf("listing derived columns of destination dataset %q: %w", dstSlug, err)
+		}
+		for _, dc := range dstColumns {
+			existing[dc.Alias] = dc.ID
+		}
+	}
+
+	for _, dc := range srcColumns {
+		change := Change{
+			Kind:     KindDerivedColumn,
+			Dataset:  slug,
+			Name:     dc.Alias,
+			SourceID: dc.ID,
+		}
+		if id, ok := existing[dc.Alias]; ok {
+			c.exists(change, id)
+			continue
+		}
+
+		_, err := c.create(change, func() (string, error) {
+			created, err := c.dst.DerivedColumns.Create(ctx, dstSlug, &client.DerivedColumn{
+				Alias:       dc.Alias,
+				Expression:  dc.Expression,
+				Description: dc.Description,
+			})
+			if err != nil {
+				return "", err
+			}
+			return created.ID, nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *cloner) cloneDatasetDefinition(ctx context.Context, slug string) error {
+	dstSlug := c.datasets[slug]
+
+	def, err := c.src.DatasetDefinitions.Get(ctx, slug)
+	if err != nil {
+		return fmt.Errorf("getting definitions of source dataset %q: %w", slug, err)
+	}
+
+	change := Change{
+		Kind:          KindDatasetDefinition,
+		Action:        ActionUpdate,
+		Dataset:       slug,
+		DestinationID: dstSlug,
+	}
+	if !c.opts.DryRun {
+		if _, err := c.dst.DatasetDefinitions.Update(ctx, dstSlug, def); err != nil {
+			return fmt.Errorf("updating definitions of dataset %q: %w", dstSlug, err)
+		}
+	}
+	c.report.add(change)
+	return nil
+}
+
+// cloneQuery clones a query of the source dataset, returning the ID of its
+// copy. Each query is only cloned once, however many times it is
+// referenced, and equivalent queries share a copy.
+func (c *cloner) cloneQuery(ctx context.Context, slug, id string) (string, error) {
+	if dstID, ok := c.queries[id]; ok {
+		return dstID, nil
+	}
+
+	q, err := c.src.Queries.Get(ctx, slug, id)
+	if err != nil {
+		return "", fmt.Errorf("getting query %q of source dataset %q: %w", id, slug, err)
+	}
+	q.ID = nil
+
+	dstSlug := c.datasets[slug]
+	hash, err := q.Hash()
+	if err != nil {
+		return "", fmt.Errorf("hashing query %q of source dataset %q: %w", id, slug, err)
+	}
+	specKey := dstSlug + "/" + hash
+	if dstID, ok := c.querySpecs[specKey]; ok {
+		// queries can't be changed, so an equivalent copy can be shared
+		c.queries[id] = dstID
+		return dstID, nil
+	}
+
+	dstID, err := c.create(Change{
+		Kind:     KindQuery,
+		Dataset:  slug,
+		SourceID: id,
+	}, func() (string, error) {
+		created, err := c.dst.Queries.Create(ctx, dstSlug, q)
+		if err != nil {
+			return "", err
+		}
+		return *created.ID, nil
+	})
+	if err != nil {
+		return "", err
+	}
+	c.queries[id] = dstID
+	c.querySpecs[specKey] = dstID
+	return dstID, nil
+}
+
+func (c *cloner) cloneQueryAnnotations(ctx context.Context, slug string) error {
+	dstSlug := c.datasets[slug]
+
+	srcAnnotations, err := c.src.QueryAnnotations.List(ctx, slug)
+	if err != nil {
+		return fmt.Errorf("listing query annotations of source dataset %q: %w", slug, err)
+	}
+	existing := make(map[string]string)
+	if c.destinationExists(dstSlug) {
+		dstAnnotations, err := c.dst.QueryAnnotations.List(ctx, dstSlug)
+		if err != nil {
+			return fmt.Errorf("listing query annotations of destination dataset %q: %w", dstSlug, err)
+		}
+		for _, qa := range dstAnnotations {
+			existing[qa.Name] = qa.ID
+		}
+	}
+
+	for _, qa := range srcAnnotations {
+		change := Change{
+			Kind:     KindQueryAnnotation,
+			Dataset:  slug,
+			Name:     qa.Name,
+			SourceID: qa.ID,
+		}
+		if id, ok := existing[qa.Name]; ok {
+			c.exists(change, id)
+			c.annotations[qa.ID] = id
+			continue
+		}
+
+		queryID, err := c.cloneQuery(ctx, slug, qa.QueryID)
+		if err != nil {
+			return err
+		}
+		id, err := c.create(change, func() (string, error) {
+			created, err := c.dst.QueryAnnotations.Create(ctx, dstSlug, &client.QueryAnnotation{
+				Name:        qa.Name,
+				Description: qa.Description,
+				QueryID:     queryID,
+				Source:      qa.Source,
+			})
+			if err != nil {
+				return "", err
+			}
+			return created.ID, nil
+		})
+		if err != nil {
+			return err
+		}
+		c.annotations[qa.ID] = id
+	}
+	return nil
+}
+
+func (c *cloner) cloneSLOs(ctx context.Context, slug string) error {
+	dstSlug := c.datasets[slug]
+
+	srcSLOs, err := c.src.SLOs.List(ctx, slug)
+	if err != nil {
+		return fmt.Errorf("listing SLOs of source dataset %q: %w", slug, err)
+	}
+	existing := make(map[string]string)
+	if c.destinationExists(dstSlug) {
+		dstSLOs, err := c.dst.SLOs.List(ctx, dstSlug)
+		if err != nil {
+			return fmt.Errorf("listing SLOs of destination dataset %q: %w", dstSlug, err)
+		}
+		for _, slo := range dstSLOs {
+			existing[slo.Name] = slo.ID
+		}
+	}
+
+	for _, slo := range srcSLOs {
+		change := Change{
+			Kind:     KindSLO,
+			Dataset:  slug,
+			Name:     slo.Name,
+			SourceID: slo.ID,
+		}
+		if id, ok := existing[slo.Name]; ok {
+			c.exists(change, id)
+			c.slos[slo.ID] = id
+			continue
+		}
+
+		datasetSlugs, err := c.remapDatasets(slo.DatasetSlugs)
+		if err != nil {
+			c.skip(change, err.Error())
+			continue
+		}
+		id, err := c.create(change, func() (string, error) {
+			created, err := c.dst.SLOs.Create(ctx, dstSlug, &client.SLO{
+				Name:             slo.Name,
+				Description:      slo.Description,
+				TimePeriodDays:   slo.TimePeriodDays,
+				TargetPerMillion: slo.TargetPerMillion,
+				DatasetSlugs:     datasetSlugs,
+				Tags:             slo.Tags,
+				SLI:              slo.SLI,
+			})
+			if err != nil {
+				return "", err
+			}
+			return created.ID, nil
+		})
+		if err != nil {
+			return err
+		}
+		c.slos[slo.ID] = id
+
+		if err := c.cloneBurnAlerts(ctx, slug, slo); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *cloner) cloneBurnAlerts(ctx context.Context, slug string, slo client.SLO) error {
+	dstSlug := c.datasets[slug]
+
+	alerts, err := c.src.BurnAlerts.ListForSLO(ctx, slug, slo.ID)
+	if err != nil {
+		return fmt.Errorf("listing burn alerts of source SLO %q: %w", slo.Name, err)
+	}
+	for _, ba := range alerts {
+		change := Change{
+			Kind:     KindBurnAlert,
+			Dataset:  slug,
+			Name:     burnAlertName(slo, ba),
+			SourceID: ba.ID,
+		}
+		recipients, err := c.remapRecipients(ba.Recipients)
+		if err != nil {
+			c.skip(change, err.Error())
+			continue
+		}
+
+		ba.ID = ""
+		ba.SLO = client.SLORef{ID: c.slos[slo.ID]}
+		ba.Recipients = recipients
+		_, err = c.create(change, func() (string, error) {
+			created, err := c.dst.BurnAlerts.Create(ctx, dstSlug, &ba)
+			if err != nil {
+				return "", err
+			}
+			return created.ID, nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *cloner) cloneTriggers(ctx context.Context, slug string) error {
+	dstSlug := c.datasets[slug]
+
+	srcTriggers, err := c.src.Triggers.List(ctx, slug)
+	if err != nil {
+		return fmt.Errorf("listing triggers of source dataset %q: %w", slug, err)
+	}
+	existing := make(map[string]string)
+	if c.destinationExists(dstSlug) {
+		dstTriggers, err := c.dst.Triggers.List(ctx, dstSlug)
+		if err != nil {
+			return fmt.Errorf("listing triggers of destination dataset %q: %w", dstSlug, err)
+		}
+		for _, t := range dstTriggers {
+			existing[t.Name] = t.ID
+		}
+	}
+
+	for _, t := range srcTriggers {
+		change := Change{
+			Kind:     KindTrigger,
+			Dataset:  slug,
+			Name:     t.Name,
+			SourceID: t.ID,
+		}
+		if id, ok := existing[t.Name]; ok {
+			c.exists(change, id)
+			continue
+		}
+		recipients, err := c.remapRecipients(t.Recipients)
+		if err != nil {
+			c.skip(change, err.Error())
+			continue
+		}
+
+		t.ID = ""
+		t.DatasetSlug = ""
+		t.CreatedAt = nil
+		t.Recipients = recipients
+		if t.QueryID != "" {
+			// the inline query, if any, is the same query
+			t.Query = nil
+			if t.QueryID, err = c.cloneQuery(ctx, slug, t.QueryID); err != nil {
+				return err
+			}
+		} else if t.Query != nil {
+			t.Query.ID = nil
+		}
+		_, err = c.create(change, func() (string, error) {
+			created, err := c.dst.Triggers.Create(ctx, dstSlug, &t)
+			if err != nil {
+				return "", err
+			}
+			return created.ID, nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *cloner) cloneBoards(ctx context.Context) error {
+	srcBoards, err := c.src.Boards.List(ctx)
+	if err != nil {
+		return fmt.Errorf("listing source boards: %w", err)
+	}
+	dstBoards, err := c.dst.Boards.List(ctx)
+	if err != nil {
+		return fmt.Errorf("listing destination boards: %w", err)
+	}
+	existing := make(map[string]string, len(dstBoards))
+	for _, b := range dstBoards {
+		existing[b.Name] = b.ID
+	}
+
+	for _, b := range srcBoards {
+		change := Change{
+			Kind:     KindBoard,
+			Name:     b.Name,
+			SourceID: b.ID,
+		}
+		if b.BoardType != client.BoardTypeFlexible {
+			c.skip(change, "only flexible boards are cloned")
+			continue
+		}
+		if id, ok := existing[b.Name]; ok {
+			c.exists(change, id)
+			continue
+		}
+
+		panels, err := c.remapPanels(ctx, b.Panels)
+		if errors.Is(err, errUnresolved) {
+			c.skip(change, err.Error())
+			continue
+		} else if err != nil {
+			return err
+		}
+
+		_, err = c.create(change, func() (string, error) {
+			created, err := c.dst.Boards.Create(ctx, &client.Board{
+				BoardType:        b.BoardType,
+				LayoutGeneration: b.LayoutGeneration,
+				Panels:           panels,
+				Name:             b.Name,
+				Description:      b.Description,
+				Tags:             b.Tags,
+				PresetFilters:    b.PresetFilters,
+			})
+			if err != nil {
+				return "", err
+			}
+			return created.ID, nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// errUnresolved is returned when configuration references something which
+// has not been cloned, such as an SLO of a dataset excluded by the options.
+var errUnresolved = errors.New("references configuration which was not cloned")
+
+// remapPanels returns a copy of the panels referencing the clones of their
+// queries, query annotations and SLOs.
+func (c *cloner) remapPanels(ctx context.Context, panels []client.BoardPanel) ([]client.BoardPanel, error) {
+	remapped := make([]client.BoardPanel, len(panels))
+	for i, p := range panels {
+		switch {
+		case p.QueryPanel != nil:
+			qp := *p.QueryPanel
+			slug := qp.Dataset
+			if slug == "" {
+				slug = client.EnvironmentWideSlug
+			}
+			dstSlug, ok := c.datasets[slug]
+			if !ok {
+				return nil, fmt.Errorf("%w: dataset %q", errUnresolved, slug)
+			}
+
+			queryID, err := c.cloneQuery(ctx, slug, qp.QueryID)
+			if err != nil {
+				return nil, err
+			}
+			qp.QueryID = queryID
+			if qp.Dataset != "" {
+				qp.Dataset = dstSlug
+			}
+			if qp.QueryAnnotationID != "" {
+				if qp.QueryAnnotationID, ok = c.annotations[qp.QueryAnnotationID]; !ok {
+					return nil, fmt.Errorf("%w: query annotation %q", errUnresolved, p.QueryPanel.QueryAnnotationID)
+				}
+			}
+			p.QueryPanel = &qp
+		case p.SLOPanel != nil:
+			sloID, ok := c.slos[p.SLOPanel.SLOID]
+			if !ok {
+				return nil, fmt.Errorf("%w: SLO %q", errUnresolved, p.SLOPanel.SLOID)
+			}
+			p.SLOPanel = &client.BoardSLOPanel{SLOID: sloID}
+		}
+		remapped[i] = p
+	}
+	return remapped, nil
+}
+
+// remapRecipients returns a copy of the notification recipients referencing
+// the clones of their recipients.
+func (c *cloner) remapRecipients(recipients []client.NotificationRecipient) ([]client.NotificationRecipient, error) {
+	if recipients == nil {
+		return nil, nil
+	}
+	remapped := make([]client.NotificationRecipient, len(recipients))
+	for i, r := range recipients {
+		if r.ID != "" {
+			id, ok := c.recipients[r.ID]
+			if !ok {
+				return nil, fmt.Errorf("%w: recipient %q", errUnresolved, r.ID)
+			}
+			r.ID = id
+		}
+		remapped[i] = r
+	}
+	return remapped, nil
+}
+
+// remapDatasets returns the destination slugs of the source datasets.
+func (c *cloner) remapDatasets(slugs []string) ([]string, error) {
+	if slugs == nil {
+		return nil, nil
+	}
+	remapped := make([]string, len(slugs))
+	for i, slug := range slugs {
+		dstSlug, ok := c.datasets[slug]
+		if !ok {
+			return nil, fmt.Errorf("%w: dataset %q", errUnresolved, slug)
+		}
+		remapped[i] = dstSlug
+	}
+	return remapped, nil
+}
+
+// recipientKey identifies a recipient across environments by its type and
+// target, as the API does when recipients are specified without an ID.
+func recipientKey(r client.Recipient) string {
+	return string(r.Type) + "\x00" + recipientTarget(r)
+}
+
+func recipientTarget(r client.Recipient) string {
+	switch r.Type {
+	case client.RecipientTypeEmail:
+		return r.Details.EmailAddress
+	case client.RecipientTypePagerDuty:
+		return r.Details.PDIntegrationName
+	case client.RecipientTypeSlack:
+		return r.Details.SlackChannel
+	case client.RecipientTypeMarker:
+		return r.Details.MarkerID
+	default:
+		return r.Details.WebhookName
+	}
+}
+
+func burnAlertName(slo client.SLO, ba client.BurnAlert) string {
+	switch ba.AlertType {
+	case client.BurnAlertAlertTypeBudgetRate:
+		return fmt.Sprintf("%s: budget rate over %d minutes", slo.Name, client.PtrValueOrDefault(ba.BudgetRateWindowMinutes, 0))
+	default:
+		return fmt.Sprintf("%s: exhaustion in %d minutes", slo.Name, client.PtrValueOrDefault(ba.ExhaustionMinutes, 0))
+	}
+}
@@ -0,0 +1,362 @@
+package clone_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/honeycombio/terraform-provider-honeycombio/client"
+	"github.com/honeycombio/terraform-provider-honeycombio/client/clone"
+	"github.com/honeycombio/terraform-provider-honeycombio/client/fakeserver"
+)
+
+func TestClone(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	t.Run("dry run makes no changes", func(t *testing.T) {
+		_, src := newFakeTestClient(t)
+		dstServer, dst := newFakeTestClient(t)
+		populateSource(t, src)
+		populateDestination(t, dst)
+
+		writes := []string{
+			"POST /1/recipients",
+			"POST /1/datasets",
+			"POST /1/columns/{dataset}",
+			"POST /1/derived_columns/{dataset}",
+			"PATCH /1/dataset_definitions/{dataset}",
+			"POST /1/queries/{dataset}",
+			"POST /1/query_annotations/{dataset}",
+			"POST /1/slos/{dataset}",
+			"POST /1/burn_alerts/{dataset}",
+			"POST /1/triggers/{dataset}",
+			"POST /1/boards",
+		}
+		before := make(map[string]int, len(writes))
+		for _, w := range writes {
+			before[w] = dstServer.RequestCount(w)
+		}
+
+		report, err := clone.Clone(ctx, src, dst, clone.Options{DryRun: true})
+		require.NoError(t, err)
+		assert.True(t, report.DryRun)
+		for _, w := range writes {
+			assert.Equal(t, before[w], dstServer.RequestCount(w), w)
+		}
+
+		assertPlanned(t, report)
+		assert.Contains(t, report.String(), "Dry run")
+	})
+
+	t.Run("clones the environment", func(t *testing.T) {
+		_, src := newFakeTestClient(t)
+		_, dst := newFakeTestClient(t)
+		srcIDs := populateSource(t, src)
+		populateDestination(t, dst)
+
+		report, err := clone.Clone(ctx, src, dst, clone.Options{})
+		require.NoError(t, err)
+		assertPlanned(t, report)
+		for _, c := range report.Changes {
+			if c.Action == clone.ActionCreate {
+				assert.NotEmpty(t, c.DestinationID, "%s %s", c.Kind, c.Name)
+			}
+		}
+
+		columns, err := dst.Columns.List(ctx, "api")
+		require.NoError(t, err)
+		assert.Len(t, columns, 3)
+		dc, err := dst.DerivedColumns.GetByAlias(ctx, "api", "is_error")
+		require.NoError(t, err)
+		assert.Equal(t, "EQUALS($status_code, 500)", dc.Expression)
+		_, err = dst.DerivedColumns.GetByAlias(ctx, client.EnvironmentWideSlug, "env_wide")
+		require.NoError(t, err)
+		def, err := dst.DatasetDefinitions.Get(ctx, "api")
+		require.NoError(t, err)
+		require.NotNil(t, def.Route)
+		assert.Equal(t, "http.route", def.Route.Name)
+
+		recipients, err := dst.Recipients.List(ctx)
+		require.NoError(t, err)
+		require.Len(t, recipients, 1)
+		recipientID := recipients[0].ID
+		assert.NotEqual(t, srcIDs.recipient, recipientID)
+
+		slos, err := dst.SLOs.List(ctx, "api")
+		require.NoError(t, err)
+		require.Len(t, slos, 1)
+		sloID := slos[0].ID
+		assert.NotEqual(t, srcIDs.slo, sloID)
+
+		alerts, err := dst.BurnAlerts.ListForSLO(ctx, "api", sloID)
+		require.NoError(t, err)
+		require.Len(t, alerts, 1)
+		assert.Equal(t, sloID, alerts[0].SLO.ID)
+		require.Len(t, alerts[0].Recipients, 1)
+		assert.Equal(t, recipientID, alerts[0].Recipients[0].ID)
+
+		triggers, err := dst.Triggers.List(ctx, "api")
+		require.NoError(t, err)
+		require.Len(t, triggers, 2)
+		for _, tr := range triggers {
+			if tr.Name != "Errors" {
+				continue
+			}
+			assert.NotEqual(t, srcIDs.query, tr.QueryID)
+			_, err := dst.Queries.Get(ctx, "api", tr.QueryID)
+			require.NoError(t, err)
+			require.Len(t, tr.Recipients, 1)
+			assert.Equal(t, recipientID, tr.Recipients[0].ID)
+		}
+
+		boards, err := dst.Boards.List(ctx)
+		require.NoError(t, err)
+		require.Len(t, boards, 1)
+		board := boards[0]
+		require.Len(t, board.Panels, 3)
+		qp := board.Panels[0].QueryPanel
+		require.NotNil(t, qp)
+		assert.NotEqual(t, srcIDs.query, qp.QueryID)
+		_, err = dst.Queries.Get(ctx, "api", qp.QueryID)
+		require.NoError(t, err)
+		annotation, err := dst.QueryAnnotations.Get(ctx, "api", qp.QueryAnnotationID)
+		require.NoError(t, err)
+		assert.Equal(t, qp.QueryID, annotation.QueryID)
+		require.NotNil(t, board.Panels[1].SLOPanel)
+		assert.Equal(t, sloID, board.Panels[1].SLOPanel.SLOID)
+	})
+
+	t.Run("cloning again changes nothing", func(t *testing.T) {
+		_, src := newFakeTestClient(t)
+		_, dst := newFakeTestClient(t)
+		populateSource(t, src)
+
+		_, err := clone.Clone(ctx, src, dst, clone.Options{})
+		require.NoError(t, err)
+		report, err := clone.Clone(ctx, src, dst, clone.Options{})
+		require.NoError(t, err)
+
+		for _, c := range report.Changes {
+			if c.Kind == clone.KindDatasetDefinition {
+				continue
+			}
+			assert.Equal(t, clone.ActionSkip, c.Action, "%s %s", c.Kind, c.Name)
+		}
+	})
+
+	t.Run("clones equivalent queries once", func(t *testing.T) {
+		_, src := newFakeTestClient(t)
+		_, dst := newFakeTestClient(t)
+		ds, err := src.Datasets.Create(ctx, &client.Dataset{Name: "api"})
+		require.NoError(t, err)
+		for i, spec := range []*client.QuerySpec{
+			{Calculations: []client.CalculationSpec{{Op: client.CalculationOpCount}}},
+			{
+				Calculations: []client.CalculationSpec{{Op: client.CalculationOpCount}},
+				TimeRange:    client.ToPtr(client.DefaultQueryTimeRange),
+			},
+		} {
+			q, err := src.Queries.Create(ctx, ds.Slug, spec)
+			require.NoError(t, err)
+			_, err = src.QueryAnnotations.Create(ctx, ds.Slug, &client.QueryAnnotation{
+				Name:    fmt.Sprintf("Annotation %d", i),
+				QueryID: *q.ID,
+			})
+			require.NoError(t, err)
+		}
+
+		report, err := clone.Clone(ctx, src, dst, clone.Options{})
+		require.NoError(t, err)
+		assert.Equal(t, 1, report.Count(clone.KindQuery, clone.ActionCreate))
+
+		annotations, err := dst.QueryAnnotations.List(ctx, "api")
+		require.NoError(t, err)
+		require.Len(t, annotations, 2)
+		assert.Equal(t, annotations[0].QueryID, annotations[1].QueryID)
+	})
+
+	t.Run("skips references to datasets not cloned", func(t *testing.T) {
+		_, src := newFakeTestClient(t)
+		_, dst := newFakeTestClient(t)
+		populateSource(t, src)
+		_, err := src.Datasets.Create(ctx, &client.Dataset{Name: "other"})
+		require.NoError(t, err)
+
+		report, err := clone.Clone(ctx, src, dst, clone.Options{Datasets: []string{"other"}})
+		require.NoError(t, err)
+
+		assert.Equal(t, 1, report.Count(clone.KindDataset, clone.ActionCreate))
+		assert.Zero(t, report.Count(clone.KindSLO, clone.ActionCreate))
+		assert.Zero(t, report.Count(clone.KindBoard, clone.ActionCreate))
+		assert.Equal(t, 1, report.Count(clone.KindBoard, clone.ActionSkip))
+		boards, err := dst.Boards.List(ctx)
+		require.NoError(t, err)
+		assert.Empty(t, boards)
+	})
+}
+
+// assertPlanned asserts the report has the changes expected of a clone of
+// populateSource into populateDestination.
+func assertPlanned(t *testing.T, report *clone.Report) {
+	t.Helper()
+
+	assert.Equal(t, 1, report.Count(clone.KindRecipient, clone.ActionCreate))
+	assert.Equal(t, 1, report.Count(clone.KindDataset, clone.ActionSkip))
+	assert.Equal(t, 1, report.Count(clone.KindColumn, clone.ActionSkip))
+	assert.Equal(t, 2, report.Count(clone.KindColumn, clone.ActionCreate))
+	assert.Equal(t, 2, report.Count(clone.KindDerivedColumn, clone.ActionCreate))
+	assert.Equal(t, 1, report.Count(clone.KindDatasetDefinition, clone.ActionUpdate))
+	assert.Equal(t, 1, report.Count(clone.KindQuery, clone.ActionCreate), "the query is only cloned once")
+	assert.Equal(t, 1, report.Count(clone.KindQueryAnnotation, clone.ActionCreate))
+	assert.Equal(t, 1, report.Count(clone.KindSLO, clone.ActionCreate))
+	assert.Equal(t, 1, report.Count(clone.KindBurnAlert, clone.ActionCreate))
+	assert.Equal(t, 1, report.Count(clone.KindTrigger, clone.ActionCreate))
+	assert.Equal(t, 1, report.Count(clone.KindTrigger, clone.ActionSkip))
+	assert.Equal(t, 1, report.Count(clone.KindBoard, clone.ActionCreate))
+
+	var sb strings.Builder
+	_, err := report.WriteTo(&sb)
+	require.NoError(t, err)
+	assert.Equal(t, len(report.Changes)+1, strings.Count(strings.TrimPrefix(sb.String(), "Dry run: no changes have been made.\n\n"), "\n"))
+}
+
+type sourceIDs struct {
+	recipient, query, slo string
+}
+
+// populateSource creates a dataset with one of every kind of configuration
+// which is cloned, referencing each other.
+func populateSource(t *testing.T, c *client.Client) sourceIDs {
+	t.Helper()
+	ctx := context.Background()
+
+	ds, err := c.Datasets.Create(ctx, &client.Dataset{Name: "api"})
+	require.NoError(t, err)
+	for _, key := range []string{"status_code", "http.route", "duration_ms"} {
+		_, err := c.Columns.Create(ctx, ds.Slug, &client.Column{KeyName: key})
+		require.NoError(t, err)
+	}
+	_, err = c.DerivedColumns.Create(ctx, ds.Slug, &client.DerivedColumn{
+		Alias:      "is_error",
+		Expression: "EQUALS($status_code, 500)",
+	})
+	require.NoError(t, err)
+	_, err = c.DerivedColumns.Create(ctx, client.EnvironmentWideSlug, &client.DerivedColumn{
+		Alias:      "env_wide",
+		Expression: "BOOL(1)",
+	})
+	require.NoError(t, err)
+	_, err = c.DatasetDefinitions.Update(ctx, ds.Slug, &client.DatasetDefinition{
+		Route: &client.DefinitionColumn{Name: "http.route"},
+	})
+	require.NoError(t, err)
+
+	recipient, err := c.Recipients.Create(ctx, &client.Recipient{
+		Type:    client.RecipientTypeEmail,
+		Details: client.RecipientDetails{EmailAddress: "oncall@example.com"},
+	})
+	require.NoError(t, err)
+	notify := []client.NotificationRecipient{{ID: recipient.ID}}
+
+	query, err := c.Queries.Create(ctx, ds.Slug, &client.QuerySpec{
+		Calculations: []client.CalculationSpec{{Op: client.CalculationOpCount}},
+	})
+	require.NoError(t, err)
+	annotation, err := c.QueryAnnotations.Create(ctx, ds.Slug, &client.QueryAnnotation{
+		Name:    "Errors",
+		QueryID: *query.ID,
+	})
+	require.NoError(t, err)
+
+	slo, err := c.SLOs.Create(ctx, ds.Slug, &client.SLO{
+		Name:             "Availability",
+		TimePeriodDays:   30,
+		TargetPerMillion: 999000,
+		SLI:              client.SLIRef{Alias: "is_error"},
+	})
+	require.NoError(t, err)
+	_, err = c.BurnAlerts.Create(ctx, ds.Slug, &client.BurnAlert{
+		AlertType:         client.BurnAlertAlertTypeExhaustionTime,
+		ExhaustionMinutes: client.ToPtr(60),
+		SLO:               client.SLORef{ID: slo.ID},
+		Recipients:        notify,
+	})
+	require.NoError(t, err)
+
+	for _, name := range []string{"Errors", "Already there"} {
+		_, err = c.Triggers.Create(ctx, ds.Slug, &client.Trigger{
+			Name:       name,
+			QueryID:    *query.ID,
+			Threshold:  &client.TriggerThreshold{Op: client.TriggerThresholdOpGreaterThan, Value: 10},
+			Recipients: notify,
+		})
+		require.NoError(t, err)
+	}
+
+	_, err = c.Boards.Create(ctx, &client.Board{
+		Name:      "Service",
+		BoardType: client.BoardTypeFlexible,
+		Panels: []client.BoardPanel{
+			{
+				PanelType: client.BoardPanelTypeQuery,
+				QueryPanel: &client.BoardQueryPanel{
+					Dataset:           ds.Slug,
+					QueryID:           *query.ID,
+					QueryAnnotationID: annotation.ID,
+				},
+			},
+			{
+				PanelType: client.BoardPanelTypeSLO,
+				SLOPanel:  &client.BoardSLOPanel{SLOID: slo.ID},
+			},
+			{
+				PanelType: client.BoardPanelTypeText,
+				TextPanel: &client.BoardTextPanel{Content: "Owned by the API team"},
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	return sourceIDs{recipient: recipient.ID, query: *query.ID, slo: slo.ID}
+}
+
+// populateDestination creates some of the configuration of populateSource,
+// which a clone should leave as it is.
+func populateDestination(t *testing.T, c *client.Client) {
+	t.Helper()
+	ctx := context.Background()
+
+	ds, err := c.Datasets.Create(ctx, &client.Dataset{Name: "api"})
+	require.NoError(t, err)
+	_, err = c.Columns.Create(ctx, ds.Slug, &client.Column{KeyName: "status_code"})
+	require.NoError(t, err)
+	_, err = c.Triggers.Create(ctx, ds.Slug, &client.Trigger{
+		Name: "Already there",
+		Query: &client.QuerySpec{
+			Calculations: []client.CalculationSpec{{Op: client.CalculationOpCount}},
+		},
+		Threshold: &client.TriggerThreshold{Op: client.TriggerThresholdOpGreaterThan, Value: 10},
+	})
+	require.NoError(t, err)
+}
+
+func newFakeTestClient(t *testing.T) (*fakeserver.Server, *client.Client) {
+	t.Helper()
+
+	s := fakeserver.New()
+	t.Cleanup(s.Close)
+
+	c, err := client.NewClientWithConfig(&client.Config{
+		APIKey: s.APIKey(),
+		APIUrl: s.URL,
+	})
+	require.NoError(t, err)
+
+	return s, c
+}
@@ -0,0 +1,118 @@
+package clone
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+)
+
+// Kind is the kind of configuration a Change applies to.
+type Kind string
+
+const (
+	KindRecipient         Kind = "recipient"
+	KindDataset           Kind = "dataset"
+	KindColumn            Kind = "column"
+	KindDerivedColumn     Kind = "derived_column"
+	KindDatasetDefinition Kind = "dataset_definition"
+	KindQuery             Kind = "query"
+	KindQueryAnnotation   Kind = "query_annotation"
+	KindSLO               Kind = "slo"
+	KindBurnAlert         Kind = "burn_alert"
+	KindTrigger           Kind = "trigger"
+	KindBoard             Kind = "board"
+)
+
+// Action is what a clone does, or would do in a dry run, with a piece of
+// configuration.
+type Action string
+
+const (
+	// ActionCreate creates the configuration in the destination.
+	ActionCreate Action = "create"
+	// ActionUpdate overwrites the existing configuration in the destination.
+	ActionUpdate Action = "update"
+	// ActionSkip leaves the destination as it is, either because it
+	// already has the configuration or because it could not be cloned.
+	ActionSkip Action = "skip"
+)
+
+// Change describes what a clone did, or would do in a dry run, with a single
+// piece of configuration.
+type Change struct {
+	Kind   Kind
+	Action Action
+	// Dataset is the slug of the source dataset the configuration belongs
+	// to, or the environment-wide slug. Empty for configuration which does
+	// not belong to a dataset, such as boards.
+	Dataset string
+	// Name identifies the configuration to a reader of the report, such as
+	// a trigger's name or a column's key name.
+	Name string
+	// SourceID is the ID of the configuration in the source environment.
+	SourceID string
+	// DestinationID is the ID of the configuration in the destination
+	// environment. Empty for configuration not created during a dry run.
+	DestinationID string
+	// Reason explains a skip.
+	Reason string
+}
+
+// Report lists the changes made by a clone, or which would be made by a dry
+// run, in the order they were made.
+type Report struct {
+	DryRun  bool
+	Changes []Change
+}
+
+// Count returns the number of changes of the kind with the action.
+func (r *Report) Count(kind Kind, action Action) int {
+	n := 0
+	for _, c := range r.Changes {
+		if c.Kind == kind && c.Action == action {
+			n++
+		}
+	}
+	return n
+}
+
+// WriteTo writes the report as a table, one change per line.
+func (r *Report) WriteTo(w io.Writer) (int64, error) {
+	var b strings.Builder
+	if r.DryRun {
+		b.WriteString("Dry run: no changes have been made.\n\n")
+	}
+
+	tw := tabwriter.NewWriter(&b, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "ACTION\tKIND\tDATASET\tNAME\tSOURCE ID\tDESTINATION ID\tREASON")
+	for _, c := range r.Changes {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			c.Action, c.Kind, orDash(c.Dataset), orDash(c.Name),
+			orDash(c.SourceID), orDash(c.DestinationID), c.Reason,
+		)
+	}
+	if err := tw.Flush(); err != nil {
+		return 0, err
+	}
+
+	n, err := io.WriteString(w, b.String())
+	return int64(n), err
+}
+
+func (r *Report) String() string {
+	var b strings.Builder
+	_, _ = r.WriteTo(&b)
+	return b.String()
+}
+
+func (r *Report) add(c Change) {
+	r.Changes = append(r.Changes, c)
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
@@ -2,9 +2,12 @@ package client
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/url"
 	"time"
+
+	"github.com/honeycombio/terraform-provider-honeycombio/client/internal/limits"
 )
 
 // Columns describe all the columns-related methods that the Honeycomb API
@@ -103,9 +106,28 @@ func (s *columns) GetByKeyName(ctx context.Context, dataset string, keyName stri
 }
 
 func (s *columns) Create(ctx context.Context, dataset string, data *Column) (*Column, error) {
-	var c Column
-	err := s.client.Do(ctx, "POST", "/1/columns/"+urlEncodeDataset(dataset), data, &c)
-	return &c, err
+	return limits.CreateOnce(ctx, s.client.httpClient,
+		func(ctx context.Context) (*Column, error) {
+			var c Column
+			err := s.client.Do(ctx, "POST", "/1/columns/"+urlEncodeDataset(dataset), data, &c)
+			return &c, err
+		},
+		func(ctx context.Context, since time.Time) (*Column, error) {
+			c, err := s.GetByKeyName(ctx, dataset, data.KeyName)
+			if errors.Is(err, ErrNotFound) {
+				return nil, nil
+			}
+			if err != nil {
+				return nil, err
+			}
+			// a column of the same name created before the attempt is
+			// someone else's, and the create would have conflicted with it
+			if !limits.CreatedSince(c.CreatedAt, since) {
+				return nil, nil
+			}
+			return c, nil
+		},
+	)
 }
 
 func (s *columns) Update(ctx context.Context, dataset string, data *Column) (*Column, error) {
@@ -0,0 +1,180 @@
+package client_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/honeycombio/terraform-provider-honeycombio/client"
+	"github.com/honeycombio/terraform-provider-honeycombio/client/fakeserver"
+)
+
+func TestClient_CreateAfterLostResponse(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	newDataset := func(t *testing.T, c *client.Client) string {
+		t.Helper()
+
+		ds, err := c.Datasets.Create(ctx, &client.Dataset{Name: "retries"})
+		require.NoError(t, err)
+		return ds.Slug
+	}
+
+	t.Run("trigger is not duplicated", func(t *testing.T) {
+		const create = "POST /1/triggers/{dataset}"
+		s, c := newFakeTestClient(t, fakeserver.WithLostResponses(create, 1))
+		dataset := newDataset(t, c)
+
+		tr, err := c.Triggers.Create(ctx, dataset, &client.Trigger{
+			Name:  "lost",
+			Query: &client.QuerySpec{Calculations: []client.CalculationSpec{{Op: client.CalculationOpCount}}},
+			Threshold: &client.TriggerThreshold{
+				Op:    client.TriggerThresholdOpGreaterThan,
+				Value: 100,
+			},
+		})
+		require.NoError(t, err)
+		assert.NotEmpty(t, tr.ID)
+		assert.Equal(t, 1, s.RequestCount(create))
+
+		triggers, err := c.Triggers.List(ctx, dataset)
+		require.NoError(t, err)
+		require.Len(t, triggers, 1)
+		assert.Equal(t, tr.ID, triggers[0].ID)
+	})
+
+	t.Run("existing identical trigger is not adopted", func(t *testing.T) {
+		const create = "POST /1/triggers/{dataset}"
+		s, c := newFakeTestClient(t)
+		dataset := newDataset(t, c)
+
+		data := &client.Trigger{
+			Name:  "lost",
+			Query: &client.QuerySpec{Calculations: []client.CalculationSpec{{Op: client.CalculationOpCount}}},
+			Threshold: &client.TriggerThreshold{
+				Op:    client.TriggerThresholdOpGreaterThan,
+				Value: 100,
+			},
+		}
+		existing, err := c.Triggers.Create(ctx, dataset, data)
+		require.NoError(t, err)
+		// creation times are to the second, so wait for the next one
+		time.Sleep(time.Until(existing.CreatedAt.Add(time.Second)))
+
+		s.LoseResponses(create, 1)
+		tr, err := c.Triggers.Create(ctx, dataset, data)
+		require.NoError(t, err)
+		assert.NotEqual(t, existing.ID, tr.ID)
+		assert.Equal(t, 2, s.RequestCount(create))
+
+		triggers, err := c.Triggers.List(ctx, dataset)
+		require.NoError(t, err)
+		assert.Len(t, triggers, 2)
+	})
+
+	t.Run("marker is not duplicated", func(t *testing.T) {
+		const create = "POST /1/markers/{dataset}"
+		s, c := newFakeTestClient(t, fakeserver.WithLostResponses(create, 1))
+		dataset := newDataset(t, c)
+
+		m, err := c.Markers.Create(ctx, dataset, &client.Marker{Message: "lost", Type: "deploy"})
+		require.NoError(t, err)
+		assert.NotEmpty(t, m.ID)
+		assert.Equal(t, 1, s.RequestCount(create))
+
+		markers, err := c.Markers.List(ctx, dataset)
+		require.NoError(t, err)
+		require.Len(t, markers, 1)
+		assert.Equal(t, m.ID, markers[0].ID)
+	})
+
+	t.Run("column is not duplicated", func(t *testing.T) {
+		const create = "POST /1/columns/{dataset}"
+		s, c := newFakeTestClient(t, fakeserver.WithLostResponses(create, 1))
+		dataset := newDataset(t, c)
+
+		col, err := c.Columns.Create(ctx, dataset, &client.Column{KeyName: "lost"})
+		require.NoError(t, err)
+		assert.NotEmpty(t, col.ID)
+		assert.Equal(t, 1, s.RequestCount(create))
+	})
+
+	t.Run("derived column is not duplicated", func(t *testing.T) {
+		const create = "POST /1/derived_columns/{dataset}"
+		s, c := newFakeTestClient(t, fakeserver.WithLostResponses(create, 1))
+		dataset := newDataset(t, c)
+
+		dc, err := c.DerivedColumns.Create(ctx, dataset, &client.DerivedColumn{
+			Alias:      "lost",
+			Expression: "BOOL(1)",
+		})
+		require.NoError(t, err)
+		assert.NotEmpty(t, dc.ID)
+		assert.Equal(t, 1, s.RequestCount(create))
+	})
+
+	t.Run("other creates are not retried", func(t *testing.T) {
+		const create = "POST /1/boards"
+		s, c := newFakeTestClient(t, fakeserver.WithLostResponses(create, 1))
+
+		_, err := c.Boards.Create(ctx, &client.Board{Name: "lost", BoardType: client.BoardTypeFlexible})
+		require.Error(t, err)
+		assert.Equal(t, 1, s.RequestCount(create))
+	})
+
+	t.Run("idempotent create is retried", func(t *testing.T) {
+		const create = "POST /1/queries/{dataset}"
+		s, c := newFakeTestClient(t, fakeserver.WithLostResponses(create, 1))
+		dataset := newDataset(t, c)
+
+		q, err := c.Queries.Create(ctx, dataset, &client.QuerySpec{})
+		require.NoError(t, err)
+		assert.NotNil(t, q.ID)
+		assert.Equal(t, 2, s.RequestCount(create))
+	})
+}
+
+func TestClient_RetriesDisabled(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	const create = "POST /1/queries/{dataset}"
+	s := fakeserver.New(fakeserver.WithLostResponses(create, 1))
+	t.Cleanup(s.Close)
+
+	c, err := client.NewClientWithConfig(&client.Config{
+		APIKey:   s.APIKey(),
+		APIUrl:   s.URL,
+		RetryMax: client.ToPtr(0),
+	})
+	require.NoError(t, err)
+
+	ds, err := c.Datasets.Create(ctx, &client.Dataset{Name: "retries"})
+	require.NoError(t, err)
+
+	_, err = c.Queries.Create(ctx, ds.Slug, &client.QuerySpec{})
+	require.Error(t, err)
+	assert.Equal(t, 1, s.RequestCount(create))
+}
+
+func TestClient_InvalidRetryConfig(t *testing.T) {
+	t.Parallel()
+
+	_, err := client.NewClientWithConfig(&client.Config{
+		APIKey:       "abcd123",
+		RetryWaitMin: time.Minute,
+		RetryWaitMax: time.Second,
+	})
+	require.Error(t, err)
+
+	_, err = client.NewClientWithConfig(&client.Config{
+		APIKey:   "abcd123",
+		RetryMax: client.ToPtr(-1),
+	})
+	require.Error(t, err)
+}
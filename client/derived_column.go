@@ -2,8 +2,12 @@ package client
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/url"
+	"time"
+
+	"github.com/honeycombio/terraform-provider-honeycombio/client/internal/limits"
 )
 
 // DerivedColumns describe all the derived columns-related methods that the
@@ -53,6 +57,9 @@ type DerivedColumn struct {
 	Expression string `json:"expression"`
 	// Optional.
 	Description string `json:"description,omitempty"`
+
+	// Read only
+	CreatedAt *time.Time `json:"created_at,omitempty"`
 }
 
 func (s *derivedColumns) List(ctx context.Context, dataset string) ([]DerivedColumn, error) {
@@ -74,9 +81,28 @@ func (s *derivedColumns) GetByAlias(ctx context.Context, dataset string, alias s
 }
 
 func (s *derivedColumns) Create(ctx context.Context, dataset string, data *DerivedColumn) (*DerivedColumn, error) {
-	var d DerivedColumn
-	err := s.client.Do(ctx, "POST", fmt.Sprintf("/1/derived_columns/%s", urlEncodeDataset(dataset)), data, &d)
-	return &d, err
+	return limits.CreateOnce(ctx, s.client.httpClient,
+		func(ctx context.Context) (*DerivedColumn, error) {
+			var d DerivedColumn
+			err := s.client.Do(ctx, "POST", fmt.Sprintf("/1/derived_columns/%s", urlEncodeDataset(dataset)), data, &d)
+			return &d, err
+		},
+		func(ctx context.Context, since time.Time) (*DerivedColumn, error) {
+			d, err := s.GetByAlias(ctx, dataset, data.Alias)
+			if errors.Is(err, ErrNotFound) {
+				return nil, nil
+			}
+			if err != nil {
+				return nil, err
+			}
+			// a derived column of the same alias created before the attempt
+			// is someone else's, and the create would have conflicted with it
+			if d.CreatedAt == nil || !limits.CreatedSince(*d.CreatedAt, since) {
+				return nil, nil
+			}
+			return d, nil
+		},
+	)
 }
 
 func (s *derivedColumns) Update(ctx context.Context, dataset string, data *DerivedColumn) (*DerivedColumn, error) {
@@ -4,10 +4,34 @@ import (
 	"encoding/json"
 	"errors"
 	"net/http"
+	"slices"
+	"strings"
 
 	"github.com/hashicorp/jsonapi"
 )
 
+// Sentinel errors classifying the errors returned by the API.
+//
+// A DetailedError matches one of these with errors.Is based on its HTTP
+// status, regardless of if it came from the v1 or v2 API.
+var (
+	// ErrNotFound is matched by HTTP 404 errors.
+	ErrNotFound = errors.New("not found")
+	// ErrConflict is matched by HTTP 409 errors.
+	ErrConflict = errors.New("conflict")
+	// ErrRateLimited is matched by HTTP 429 errors.
+	ErrRateLimited = errors.New("rate limited")
+	// ErrUnauthorized is matched by HTTP 401 errors, such as for a missing or
+	// invalid API key.
+	ErrUnauthorized = errors.New("unauthorized")
+	// ErrForbidden is matched by HTTP 403 errors, returned when the API key
+	// lacks the permission or scope required for the request.
+	ErrForbidden = errors.New("forbidden")
+	// ErrValidation is matched by HTTP 422 errors, and HTTP 400 errors with
+	// a 'validation-failed' problem type.
+	ErrValidation = errors.New("validation failed")
+)
+
 // DetailedError is an RFC7807 'Problem Detail' formatted error message.
 type DetailedError struct {
 	// The HTTP status code of the error.
@@ -69,6 +93,43 @@ func (e *DetailedError) IsConflict() bool {
 	return e.Status == http.StatusConflict
 }
 
+// HTTPStatus returns the HTTP status code of the response the error
+// describes.
+func (e DetailedError) HTTPStatus() int {
+	return e.Status
+}
+
+// Is reports whether the error matches the target sentinel error.
+func (e DetailedError) Is(target error) bool {
+	return target != nil && target == e.Unwrap()
+}
+
+// Unwrap returns the sentinel error classifying the error, or nil if the
+// error does not fall into any of the classes.
+func (e DetailedError) Unwrap() error {
+	switch e.Status {
+	case http.StatusNotFound:
+		return ErrNotFound
+	case http.StatusConflict:
+		return ErrConflict
+	case http.StatusTooManyRequests:
+		return ErrRateLimited
+	case http.StatusUnauthorized:
+		return ErrUnauthorized
+	case http.StatusForbidden:
+		return ErrForbidden
+	case http.StatusUnprocessableEntity:
+		return ErrValidation
+	case http.StatusBadRequest:
+		// v1 problem types are URIs ending in the problem name,
+		// while v2 error codes may be further namespaced by a slash
+		if slices.Contains(strings.Split(e.Type, "/"), "validation-failed") {
+			return ErrValidation
+		}
+	}
+	return nil
+}
+
 // Error returns a pretty-printed representation of the error
 func (e DetailedError) Error() string {
 	if len(e.Details) > 0 {
@@ -90,6 +151,38 @@ func (e DetailedError) Error() string {
 	return e.Message
 }
 
+// FieldError is a problem with the value of a single field of a request,
+// found by validating the request before it is sent to the API.
+type FieldError struct {
+	// Field is the name of the offending field in the dotted form used by
+	// the API's error details, such as "orders[1].op".
+	Field string
+	// Message describes the problem.
+	Message string
+}
+
+func (e FieldError) Error() string {
+	return e.Field + ": " + e.Message
+}
+
+// FieldErrors is the list of problems found by validating a request, which
+// matches ErrValidation with errors.Is.
+type FieldErrors []FieldError
+
+// Error returns each of the problems on its own line.
+func (e FieldErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i, fe := range e {
+		msgs[i] = fe.Error()
+	}
+	return strings.Join(msgs, "\n")
+}
+
+// Is reports whether the target is ErrValidation.
+func (e FieldErrors) Is(target error) bool {
+	return target == ErrValidation
+}
+
 func ErrorFromResponse(r *http.Response) error {
 	if r == nil {
 		return errors.New("invalid response")
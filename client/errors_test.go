@@ -291,3 +291,95 @@ func TestErrors_JSONAPI(t *testing.T) {
 		})
 	}
 }
+
+func TestErrors_DetailedError_Is(t *testing.T) {
+	t.Parallel()
+
+	sentinels := []error{
+		client.ErrNotFound,
+		client.ErrConflict,
+		client.ErrRateLimited,
+		client.ErrUnauthorized,
+		client.ErrForbidden,
+		client.ErrValidation,
+	}
+
+	testCases := []struct {
+		name     string
+		input    client.DetailedError
+		expected error
+	}{
+		{
+			name:     "404 is not found",
+			input:    client.DetailedError{Status: http.StatusNotFound},
+			expected: client.ErrNotFound,
+		},
+		{
+			name:     "409 is a conflict",
+			input:    client.DetailedError{Status: http.StatusConflict},
+			expected: client.ErrConflict,
+		},
+		{
+			name:     "429 is rate limited",
+			input:    client.DetailedError{Status: http.StatusTooManyRequests},
+			expected: client.ErrRateLimited,
+		},
+		{
+			name:     "401 is unauthorized",
+			input:    client.DetailedError{Status: http.StatusUnauthorized},
+			expected: client.ErrUnauthorized,
+		},
+		{
+			name:     "403 is forbidden",
+			input:    client.DetailedError{Status: http.StatusForbidden},
+			expected: client.ErrForbidden,
+		},
+		{
+			name:     "422 is a validation error",
+			input:    client.DetailedError{Status: http.StatusUnprocessableEntity},
+			expected: client.ErrValidation,
+		},
+		{
+			name: "400 with a v1 validation problem type is a validation error",
+			input: client.DetailedError{
+				Status: http.StatusBadRequest,
+				Type:   "https://api.honeycomb.io/problems/validation-failed",
+			},
+			expected: client.ErrValidation,
+		},
+		{
+			name: "400 with a v2 validation error code is a validation error",
+			input: client.DetailedError{
+				Status: http.StatusBadRequest,
+				Type:   "validation-failed/invalid",
+			},
+			expected: client.ErrValidation,
+		},
+		{
+			name: "other 400s are unclassified",
+			input: client.DetailedError{
+				Status: http.StatusBadRequest,
+				Type:   "https://api.honeycomb.io/problems/unparseable",
+			},
+		},
+		{
+			name:  "500 is unclassified",
+			input: client.DetailedError{Status: http.StatusInternalServerError},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			// wrapping must not interfere with classification
+			err := fmt.Errorf("wrapped: %w", testCase.input)
+
+			for _, sentinel := range sentinels {
+				if sentinel == testCase.expected {
+					require.ErrorIs(t, err, sentinel)
+				} else {
+					require.NotErrorIs(t, err, sentinel)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,116 @@
+package fakeserver
+
+import (
+	"crypto/rand"
+	"net/http"
+	"slices"
+	"strconv"
+
+	"github.com/honeycombio/terraform-provider-honeycombio/client"
+)
+
+var apiKeyTypes = []string{"configuration", "ingest"}
+
+// apiKeyAttributes are those API keys can be filtered and sorted by.
+var apiKeyAttributes = listAttributes[apiKey]{
+	"name":        func(k *apiKey) string { return *k.Name },
+	"type":        func(k *apiKey) string { return k.KeyType },
+	"environment": func(k *apiKey) string { return k.Environment.ID },
+	"disabled":    func(k *apiKey) string { return strconv.FormatBool(*k.Disabled) },
+}
+
+func (s *Server) listAPIKeys(w http.ResponseWriter, r *http.Request) {
+	keys, ok := filterAndSort(s, w, r, s.apiKeys.list(""), apiKeyAttributes)
+	if !ok {
+		return
+	}
+	writeJSONAPIPage(s, w, r, keys, func(k *apiKey) string { return k.ID })
+}
+
+func (s *Server) createAPIKey(w http.ResponseWriter, r *http.Request) {
+	var k apiKey
+	if !s.decodeJSONAPI(w, r, &k) {
+		return
+	}
+	var v validator
+	v.required("/data/attributes/key_type", k.KeyType == "")
+	if k.KeyType != "" && !slices.Contains(apiKeyTypes, k.KeyType) {
+		v.invalid("/data/attributes/key_type", "must be one of configuration, ingest")
+	}
+	v.required("/data/relationships/environment", k.Environment == nil || k.Environment.ID == "")
+	if k.Environment != nil && k.Environment.ID != "" {
+		if _, ok := s.environments.get("", k.Environment.ID); !ok {
+			v.invalid("/data/relationships/environment", "environment not found")
+		}
+	}
+	if s.jsonapiValidationFailed(w, v) {
+		return
+	}
+
+	ts := now()
+	k.ID = newID()
+	k.Secret = rand.Text()
+	if k.Name == nil {
+		k.Name = client.ToPtr("")
+	}
+	if k.Disabled == nil {
+		k.Disabled = client.ToPtr(false)
+	}
+	if k.Permissions == nil {
+		k.Permissions = &apiKeyPermissions{}
+	}
+	k.Timestamps = &timestamps{CreatedAt: ts, UpdatedAt: ts}
+	k.Environment = &environment{ID: k.Environment.ID}
+
+	// the secret is only ever returned on creation
+	stored := k
+	stored.Secret = ""
+	s.apiKeys.put("", k.ID, &stored)
+
+	writeJSONAPIWithoutIncluded(w, http.StatusCreated, &k)
+}
+
+func (s *Server) getAPIKey(w http.ResponseWriter, r *http.Request) {
+	k, ok := s.apiKeys.get("", r.PathValue("id"))
+	if !ok {
+		s.writeJSONAPIError(w, http.StatusNotFound, "not-found", "API Key not found")
+		return
+	}
+	writeJSONAPIWithoutIncluded(w, http.StatusOK, k)
+}
+
+func (s *Server) updateAPIKey(w http.ResponseWriter, r *http.Request) {
+	existing, ok := s.apiKeys.get("", r.PathValue("id"))
+	if !ok {
+		s.writeJSONAPIError(w, http.StatusNotFound, "not-found", "API Key not found")
+		return
+	}
+	var k apiKey
+	if !s.decodeJSONAPI(w, r, &k) {
+		return
+	}
+
+	// only the provided attributes are updated
+	updated := *existing
+	if k.Name != nil {
+		updated.Name = k.Name
+	}
+	if k.Disabled != nil {
+		updated.Disabled = k.Disabled
+	}
+	updated.Timestamps = &timestamps{
+		CreatedAt: existing.Timestamps.CreatedAt,
+		UpdatedAt: now(),
+	}
+	s.apiKeys.put("", updated.ID, &updated)
+
+	writeJSONAPIWithoutIncluded(w, http.StatusOK, &updated)
+}
+
+func (s *Server) deleteAPIKey(w http.ResponseWriter, r *http.Request) {
+	if !s.apiKeys.delete("", r.PathValue("id")) {
+		s.writeJSONAPIError(w, http.StatusNotFound, "not-found", "API Key not found")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
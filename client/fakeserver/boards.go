@@ -0,0 +1,208 @@
+package fakeserver
+
+import (
+	"net/http"
+
+	"github.com/honeycombio/terraform-provider-honeycombio/client"
+)
+
+func (s *Server) listBoards(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, s.boards.list(""))
+}
+
+func (s *Server) createBoard(w http.ResponseWriter, r *http.Request) {
+	var b client.Board
+	if !s.decode(w, r, &b) {
+		return
+	}
+	if s.validationFailed(w, s.validateBoard(&b)) {
+		return
+	}
+
+	b.ID = newID()
+	s.prepareBoard(&b)
+	s.boards.put("", b.ID, &b)
+
+	writeJSON(w, http.StatusCreated, b)
+}
+
+func (s *Server) getBoard(w http.ResponseWriter, r *http.Request) {
+	b, ok := s.boards.get("", r.PathValue("id"))
+	if !ok {
+		s.notFound(w, "Board not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, b)
+}
+
+func (s *Server) updateBoard(w http.ResponseWriter, r *http.Request) {
+	existing, ok := s.boards.get("", r.PathValue("id"))
+	if !ok {
+		s.notFound(w, "Board not found")
+		return
+	}
+	var b client.Board
+	if !s.decode(w, r, &b) {
+		return
+	}
+	if s.validationFailed(w, s.validateBoard(&b)) {
+		return
+	}
+
+	b.ID = existing.ID
+	s.prepareBoard(&b)
+	s.boards.put("", b.ID, &b)
+
+	writeJSON(w, http.StatusOK, b)
+}
+
+func (s *Server) deleteBoard(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	if !s.boards.delete("", id) {
+		s.notFound(w, "Board not found")
+		return
+	}
+	s.boardViews.deleteScope(id)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) validateBoard(b *client.Board) validator {
+	var v validator
+	v.required("name", b.Name == "")
+	for _, p := range b.Panels {
+		switch p.PanelType {
+		case client.BoardPanelTypeQuery:
+			if p.QueryPanel == nil {
+				v.required("panels.query_panel", true)
+				continue
+			}
+			if _, _, ok := s.queries.find(p.QueryPanel.QueryID); !ok {
+				v.invalid("panels", "query "+p.QueryPanel.QueryID+" not found")
+			}
+		case client.BoardPanelTypeSLO:
+			if p.SLOPanel == nil {
+				v.required("panels.slo_panel", true)
+				continue
+			}
+			if _, _, ok := s.slos.find(p.SLOPanel.SLOID); !ok {
+				v.invalid("panels", "SLO "+p.SLOPanel.SLOID+" not found")
+			}
+		case client.BoardPanelTypeText:
+			v.required("panels.text_panel", p.TextPanel == nil)
+		default:
+			v.invalid("panels.type", "must be one of query, slo, text")
+		}
+	}
+	return v
+}
+
+// prepareBoard fills in the defaults and server-side fields of a board.
+func (s *Server) prepareBoard(b *client.Board) {
+	if b.BoardType == "" {
+		b.BoardType = client.BoardTypeFlexible
+	}
+	if b.LayoutGeneration == "" {
+		b.LayoutGeneration = client.LayoutGenerationManual
+	}
+	if b.Tags == nil {
+		b.Tags = []client.Tag{}
+	}
+	b.Links.BoardURL = s.URL + "/" + s.auth.Team.Slug + "/environments/" + s.auth.Environment.Slug + "/board/" + b.ID
+}
+
+func (s *Server) listBoardViews(w http.ResponseWriter, r *http.Request) {
+	board, ok := s.boardScope(w, r)
+	if !ok {
+		return
+	}
+	writeJSON(w, http.StatusOK, s.boardViews.list(board))
+}
+
+func (s *Server) createBoardView(w http.ResponseWriter, r *http.Request) {
+	board, ok := s.boardScope(w, r)
+	if !ok {
+		return
+	}
+	var bv client.BoardView
+	if !s.decode(w, r, &bv) {
+		return
+	}
+	var v validator
+	v.required("name", bv.Name == "")
+	if s.validationFailed(w, v) {
+		return
+	}
+
+	bv.ID = newID()
+	if bv.Filters == nil {
+		bv.Filters = []client.BoardViewFilter{}
+	}
+	s.boardViews.put(board, bv.ID, &bv)
+
+	writeJSON(w, http.StatusCreated, bv)
+}
+
+func (s *Server) getBoardView(w http.ResponseWriter, r *http.Request) {
+	board, ok := s.boardScope(w, r)
+	if !ok {
+		return
+	}
+	bv, ok := s.boardViews.get(board, r.PathValue("id"))
+	if !ok {
+		s.notFound(w, "Board View not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, bv)
+}
+
+func (s *Server) updateBoardView(w http.ResponseWriter, r *http.Request) {
+	board, ok := s.boardScope(w, r)
+	if !ok {
+		return
+	}
+	existing, ok := s.boardViews.get(board, r.PathValue("id"))
+	if !ok {
+		s.notFound(w, "Board View not found")
+		return
+	}
+	var bv client.BoardView
+	if !s.decode(w, r, &bv) {
+		return
+	}
+	var v validator
+	v.required("name", bv.Name == "")
+	if s.validationFailed(w, v) {
+		return
+	}
+
+	bv.ID = existing.ID
+	if bv.Filters == nil {
+		bv.Filters = []client.BoardViewFilter{}
+	}
+	s.boardViews.put(board, bv.ID, &bv)
+
+	writeJSON(w, http.StatusOK, bv)
+}
+
+func (s *Server) deleteBoardView(w http.ResponseWriter, r *http.Request) {
+	board, ok := s.boardScope(w, r)
+	if !ok {
+		return
+	}
+	if !s.boardViews.delete(board, r.PathValue("id")) {
+		s.notFound(w, "Board View not found")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// boardScope resolves the board path parameter of the request, responding
+// with a 404 if the board does not exist.
+func (s *Server) boardScope(w http.ResponseWriter, r *http.Request) (string, bool) {
+	id := r.PathValue("board")
+	if _, ok := s.boards.get("", id); !ok {
+		s.notFound(w, "Board not found")
+		return "", false
+	}
+	return id, true
+}
@@ -0,0 +1,69 @@
+package fakeserver
+
+// collection is an insertion-ordered set of objects keyed by ID and grouped by
+// a scope such as a dataset slug or board ID.
+//
+// Unscoped objects, such as boards and recipients, use the empty scope.
+type collection[T any] struct {
+	items map[string]map[string]*T
+	order map[string][]string
+}
+
+func newCollection[T any]() *collection[T] {
+	return &collection[T]{
+		items: make(map[string]map[string]*T),
+		order: make(map[string][]string),
+	}
+}
+
+func (c *collection[T]) get(scope, id string) (*T, bool) {
+	v, ok := c.items[scope][id]
+	return v, ok
+}
+
+// find returns the first object in any scope with the given ID.
+func (c *collection[T]) find(id string) (*T, string, bool) {
+	for scope, items := range c.items {
+		if v, ok := items[id]; ok {
+			return v, scope, true
+		}
+	}
+	return nil, "", false
+}
+
+func (c *collection[T]) list(scope string) []*T {
+	result := make([]*T, 0, len(c.order[scope]))
+	for _, id := range c.order[scope] {
+		result = append(result, c.items[scope][id])
+	}
+	return result
+}
+
+func (c *collection[T]) put(scope, id string, v *T) {
+	if c.items[scope] == nil {
+		c.items[scope] = make(map[string]*T)
+	}
+	if _, exists := c.items[scope][id]; !exists {
+		c.order[scope] = append(c.order[scope], id)
+	}
+	c.items[scope][id] = v
+}
+
+func (c *collection[T]) delete(scope, id string) bool {
+	if _, ok := c.items[scope][id]; !ok {
+		return false
+	}
+	delete(c.items[scope], id)
+	for i, v := range c.order[scope] {
+		if v == id {
+			c.order[scope] = append(c.order[scope][:i], c.order[scope][i+1:]...)
+			break
+		}
+	}
+	return true
+}
+
+func (c *collection[T]) deleteScope(scope string) {
+	delete(c.items, scope)
+	delete(c.order, scope)
+}
@@ -0,0 +1,389 @@
+package fakeserver
+
+import (
+	"net/http"
+	"reflect"
+	"slices"
+
+	"github.com/honeycombio/terraform-provider-honeycombio/client"
+)
+
+func (s *Server) listDatasets(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, s.datasets.list(""))
+}
+
+func (s *Server) createDataset(w http.ResponseWriter, r *http.Request) {
+	var d client.Dataset
+	if !s.decode(w, r, &d) {
+		return
+	}
+	var v validator
+	v.required("name", d.Name == "")
+	if s.validationFailed(w, v) {
+		return
+	}
+
+	for _, existing := range s.datasets.list("") {
+		if existing.Name == d.Name {
+			// the API doesn't consider this an error
+			writeJSON(w, http.StatusOK, existing)
+			return
+		}
+	}
+
+	d.Slug = slugify(d.Name)
+	d.CreatedAt = now()
+	d.Settings.DeleteProtected = client.ToPtr(true)
+	s.datasets.put("", d.Slug, &d)
+
+	writeJSON(w, http.StatusCreated, d)
+}
+
+func (s *Server) getDataset(w http.ResponseWriter, r *http.Request) {
+	d, ok := s.datasets.get("", r.PathValue("dataset"))
+	if !ok {
+		s.notFound(w, "Dataset not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, d)
+}
+
+func (s *Server) updateDataset(w http.ResponseWriter, r *http.Request) {
+	existing, ok := s.datasets.get("", r.PathValue("dataset"))
+	if !ok {
+		s.notFound(w, "Dataset not found")
+		return
+	}
+	var d client.Dataset
+	if !s.decode(w, r, &d) {
+		return
+	}
+
+	updated := *existing
+	updated.Description = d.Description
+	updated.ExpandJSONDepth = d.ExpandJSONDepth
+	if d.Settings.DeleteProtected != nil {
+		updated.Settings.DeleteProtected = d.Settings.DeleteProtected
+	}
+	s.datasets.put("", updated.Slug, &updated)
+
+	writeJSON(w, http.StatusOK, updated)
+}
+
+func (s *Server) deleteDataset(w http.ResponseWriter, r *http.Request) {
+	slug := r.PathValue("dataset")
+	d, ok := s.datasets.get("", slug)
+	if !ok {
+		s.notFound(w, "Dataset not found")
+		return
+	}
+	if client.PtrValueOrDefault(d.Settings.DeleteProtected, false) {
+		s.conflict(w, "Dataset is delete protected")
+		return
+	}
+
+	s.datasets.delete("", slug)
+	delete(s.datasetDefinitions, slug)
+	s.columns.deleteScope(slug)
+	s.derivedColumns.deleteScope(slug)
+	s.triggers.deleteScope(slug)
+	s.slos.deleteScope(slug)
+	s.burnAlerts.deleteScope(slug)
+	s.markers.deleteScope(slug)
+	s.markerSettings.deleteScope(slug)
+	s.queries.deleteScope(slug)
+	s.queryAnnotations.deleteScope(slug)
+	s.queryResults.deleteScope(slug)
+
+	w.WriteHeader(http.StatusAccepted)
+}
+
+func (s *Server) getDatasetDefinition(w http.ResponseWriter, r *http.Request) {
+	slug, ok := s.datasetScope(w, r, false)
+	if !ok {
+		return
+	}
+	writeJSON(w, http.StatusOK, s.datasetDefinition(slug))
+}
+
+func (s *Server) updateDatasetDefinition(w http.ResponseWriter, r *http.Request) {
+	slug, ok := s.datasetScope(w, r, false)
+	if !ok {
+		return
+	}
+	var d client.DatasetDefinition
+	if !s.decode(w, r, &d) {
+		return
+	}
+
+	// only the provided definitions are updated, and an empty name resets
+	// the definition
+	current := s.datasetDefinition(slug)
+	cv, uv := reflect.ValueOf(current).Elem(), reflect.ValueOf(d)
+	for i := range uv.NumField() {
+		f, ok := uv.Field(i).Interface().(*client.DefinitionColumn)
+		if !ok || f == nil {
+			continue
+		}
+		if f.Name == "" {
+			cv.Field(i).Set(reflect.Zero(cv.Field(i).Type()))
+			continue
+		}
+		def := *f
+		if def.ColumnType == "" {
+			def.ColumnType = "column"
+		}
+		cv.Field(i).Set(reflect.ValueOf(&def))
+	}
+
+	writeJSON(w, http.StatusOK, current)
+}
+
+func (s *Server) datasetDefinition(slug string) *client.DatasetDefinition {
+	d, ok := s.datasetDefinitions[slug]
+	if !ok {
+		d = &client.DatasetDefinition{}
+		s.datasetDefinitions[slug] = d
+	}
+	return d
+}
+
+// inUseByDatasetDefinition returns true if the column is referenced by any
+// of the dataset's definitions.
+func (s *Server) inUseByDatasetDefinition(slug, column string) bool {
+	d, ok := s.datasetDefinitions[slug]
+	if !ok {
+		return false
+	}
+	v := reflect.ValueOf(d).Elem()
+	for i := range v.NumField() {
+		if f, ok := v.Field(i).Interface().(*client.DefinitionColumn); ok && f != nil && f.Name == column {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Server) listColumns(w http.ResponseWriter, r *http.Request) {
+	slug, ok := s.datasetScope(w, r, false)
+	if !ok {
+		return
+	}
+	if keyName := r.URL.Query().Get("key_name"); keyName != "" {
+		for _, c := range s.columns.list(slug) {
+			if c.KeyName == keyName {
+				writeJSON(w, http.StatusOK, c)
+				return
+			}
+		}
+		s.notFound(w, "Column not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, s.columns.list(slug))
+}
+
+func (s *Server) createColumn(w http.ResponseWriter, r *http.Request) {
+	slug, ok := s.datasetScope(w, r, false)
+	if !ok {
+		return
+	}
+	var c client.Column
+	if !s.decode(w, r, &c) {
+		return
+	}
+	var v validator
+	v.required("key_name", c.KeyName == "")
+	if c.Type != nil && !slices.Contains(client.ColumnTypes(), *c.Type) {
+		v.invalid("type", "must be one of string, float, integer, boolean")
+	}
+	if s.validationFailed(w, v) {
+		return
+	}
+	for _, existing := range s.columns.list(slug) {
+		if existing.KeyName == c.KeyName {
+			s.conflict(w, "Column already exists")
+			return
+		}
+	}
+
+	c.ID = newID()
+	if c.Type == nil {
+		c.Type = client.ToPtr(client.ColumnTypeString)
+	}
+	if c.Hidden == nil {
+		c.Hidden = client.ToPtr(false)
+	}
+	c.CreatedAt = now()
+	c.UpdatedAt = c.CreatedAt
+	s.columns.put(slug, c.ID, &c)
+
+	writeJSON(w, http.StatusCreated, c)
+}
+
+func (s *Server) getColumn(w http.ResponseWriter, r *http.Request) {
+	slug, ok := s.datasetScope(w, r, false)
+	if !ok {
+		return
+	}
+	c, ok := s.columns.get(slug, r.PathValue("id"))
+	if !ok {
+		s.notFound(w, "Column not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, c)
+}
+
+func (s *Server) updateColumn(w http.ResponseWriter, r *http.Request) {
+	slug, ok := s.datasetScope(w, r, false)
+	if !ok {
+		return
+	}
+	existing, ok := s.columns.get(slug, r.PathValue("id"))
+	if !ok {
+		s.notFound(w, "Column not found")
+		return
+	}
+	var c client.Column
+	if !s.decode(w, r, &c) {
+		return
+	}
+	if c.Type != nil && !slices.Contains(client.ColumnTypes(), *c.Type) {
+		var v validator
+		v.invalid("type", "must be one of string, float, integer, boolean")
+		s.validationFailed(w, v)
+		return
+	}
+
+	updated := *existing
+	updated.Description = c.Description
+	if c.Hidden != nil {
+		updated.Hidden = c.Hidden
+	}
+	if c.Type != nil {
+		updated.Type = c.Type
+	}
+	updated.UpdatedAt = now()
+	s.columns.put(slug, updated.ID, &updated)
+
+	writeJSON(w, http.StatusOK, updated)
+}
+
+func (s *Server) deleteColumn(w http.ResponseWriter, r *http.Request) {
+	slug, ok := s.datasetScope(w, r, false)
+	if !ok {
+		return
+	}
+	c, ok := s.columns.get(slug, r.PathValue("id"))
+	if !ok {
+		s.notFound(w, "Column not found")
+		return
+	}
+	if s.inUseByDatasetDefinition(slug, c.KeyName) {
+		s.conflict(w, "Column is in use by dataset definition")
+		return
+	}
+	s.columns.delete(slug, c.ID)
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) listDerivedColumns(w http.ResponseWriter, r *http.Request) {
+	slug, ok := s.datasetScope(w, r, true)
+	if !ok {
+		return
+	}
+	if alias := r.URL.Query().Get("alias"); alias != "" {
+		for _, dc := range s.derivedColumns.list(slug) {
+			if dc.Alias == alias {
+				writeJSON(w, http.StatusOK, dc)
+				return
+			}
+		}
+		s.notFound(w, "Derived Column not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, s.derivedColumns.list(slug))
+}
+
+func (s *Server) createDerivedColumn(w http.ResponseWriter, r *http.Request) {
+	slug, ok := s.datasetScope(w, r, true)
+	if !ok {
+		return
+	}
+	var dc client.DerivedColumn
+	if !s.decode(w, r, &dc) {
+		return
+	}
+	var v validator
+	v.required("alias", dc.Alias == "")
+	v.required("expression", dc.Expression == "")
+	if s.validationFailed(w, v) {
+		return
+	}
+	for _, existing := range s.derivedColumns.list(slug) {
+		if existing.Alias == dc.Alias {
+			s.conflict(w, "Derived Column with alias already exists")
+			return
+		}
+	}
+
+	ts := now()
+	dc.ID = newID()
+	dc.CreatedAt = &ts
+	s.derivedColumns.put(slug, dc.ID, &dc)
+
+	writeJSON(w, http.StatusCreated, dc)
+}
+
+func (s *Server) getDerivedColumn(w http.ResponseWriter, r *http.Request) {
+	slug, ok := s.datasetScope(w, r, true)
+	if !ok {
+		return
+	}
+	dc, ok := s.derivedColumns.get(slug, r.PathValue("id"))
+	if !ok {
+		s.notFound(w, "Derived Column not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, dc)
+}
+
+func (s *Server) updateDerivedColumn(w http.ResponseWriter, r *http.Request) {
+	slug, ok := s.datasetScope(w, r, true)
+	if !ok {
+		return
+	}
+	existing, ok := s.derivedColumns.get(slug, r.PathValue("id"))
+	if !ok {
+		s.notFound(w, "Derived Column not found")
+		return
+	}
+	var dc client.DerivedColumn
+	if !s.decode(w, r, &dc) {
+		return
+	}
+	var v validator
+	v.required("expression", dc.Expression == "")
+	if s.validationFailed(w, v) {
+		return
+	}
+
+	updated := *existing
+	updated.Expression = dc.Expression
+	updated.Description = dc.Description
+	s.derivedColumns.put(slug, updated.ID, &updated)
+
+	writeJSON(w, http.StatusOK, updated)
+}
+
+func (s *Server) deleteDerivedColumn(w http.ResponseWriter, r *http.Request) {
+	slug, ok := s.datasetScope(w, r, true)
+	if !ok {
+		return
+	}
+	if !s.derivedColumns.delete(slug, r.PathValue("id")) {
+		s.notFound(w, "Derived Column not found")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
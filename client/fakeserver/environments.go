@@ -0,0 +1,134 @@
+package fakeserver
+
+import (
+	"net/http"
+	"slices"
+
+	"github.com/honeycombio/terraform-provider-honeycombio/client"
+)
+
+var environmentColors = []string{
+	"blue",
+	"green",
+	"gold",
+	"red",
+	"purple",
+	"lightBlue",
+	"lightGreen",
+	"lightGold",
+	"lightRed",
+	"lightPurple",
+}
+
+// environmentAttributes are those environments can be filtered and sorted by.
+var environmentAttributes = listAttributes[environment]{
+	"name": func(e *environment) string { return e.Name },
+	"slug": func(e *environment) string { return e.Slug },
+}
+
+func (s *Server) listEnvironments(w http.ResponseWriter, r *http.Request) {
+	envs, ok := filterAndSort(s, w, r, s.environments.list(""), environmentAttributes)
+	if !ok {
+		return
+	}
+	writeJSONAPIPage(s, w, r, envs, func(e *environment) string { return e.ID })
+}
+
+func (s *Server) createEnvironment(w http.ResponseWriter, r *http.Request) {
+	var e environment
+	if !s.decodeJSONAPI(w, r, &e) {
+		return
+	}
+	var v validator
+	v.required("/data/attributes/name", e.Name == "")
+	if e.Color != nil && !slices.Contains(environmentColors, *e.Color) {
+		v.invalid("/data/attributes/color", "must be a valid color")
+	}
+	if s.jsonapiValidationFailed(w, v) {
+		return
+	}
+	slug := slugify(e.Name)
+	for _, existing := range s.environments.list("") {
+		if existing.Slug == slug {
+			s.writeJSONAPIError(w, http.StatusConflict, "conflict", "Environment with name already exists")
+			return
+		}
+	}
+
+	e.ID = newID()
+	e.Slug = slug
+	if e.Settings == nil {
+		e.Settings = &environmentSettings{}
+	}
+	if e.Settings.DeleteProtected == nil {
+		e.Settings.DeleteProtected = client.ToPtr(true)
+	}
+	s.environments.put("", e.ID, &e)
+
+	writeJSONAPI(w, http.StatusCreated, &e)
+}
+
+func (s *Server) getEnvironment(w http.ResponseWriter, r *http.Request) {
+	e, ok := s.environments.get("", r.PathValue("id"))
+	if !ok {
+		s.writeJSONAPIError(w, http.StatusNotFound, "not-found", "Environment not found")
+		return
+	}
+	writeJSONAPI(w, http.StatusOK, e)
+}
+
+func (s *Server) updateEnvironment(w http.ResponseWriter, r *http.Request) {
+	existing, ok := s.environments.get("", r.PathValue("id"))
+	if !ok {
+		s.writeJSONAPIError(w, http.StatusNotFound, "not-found", "Environment not found")
+		return
+	}
+	var e environment
+	if !s.decodeJSONAPI(w, r, &e) {
+		return
+	}
+	if e.Color != nil && !slices.Contains(environmentColors, *e.Color) {
+		var v validator
+		v.invalid("/data/attributes/color", "must be a valid color")
+		s.jsonapiValidationFailed(w, v)
+		return
+	}
+
+	// only the provided attributes are updated
+	updated := *existing
+	if e.Description != nil {
+		updated.Description = e.Description
+	}
+	if e.Color != nil {
+		updated.Color = e.Color
+	}
+	if e.Settings != nil && e.Settings.DeleteProtected != nil {
+		updated.Settings = &environmentSettings{DeleteProtected: e.Settings.DeleteProtected}
+	}
+	s.environments.put("", updated.ID, &updated)
+
+	writeJSONAPI(w, http.StatusOK, &updated)
+}
+
+func (s *Server) deleteEnvironment(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	e, ok := s.environments.get("", id)
+	if !ok {
+		s.writeJSONAPIError(w, http.StatusNotFound, "not-found", "Environment not found")
+		return
+	}
+	if client.PtrValueOrDefault(e.Settings.DeleteProtected, false) {
+		s.writeJSONAPIError(w, http.StatusConflict, "conflict", "Environment is delete protected")
+		return
+	}
+
+	s.environments.delete("", id)
+	// deleting an environment also deletes its API keys
+	for _, k := range s.apiKeys.list("") {
+		if k.Environment.ID == id {
+			s.apiKeys.delete("", k.ID)
+		}
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,120 @@
+package fakeserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/hashicorp/jsonapi"
+
+	"github.com/honeycombio/terraform-provider-honeycombio/client"
+)
+
+// problemTitles are the RFC7807 titles the API uses for each problem type.
+var problemTitles = map[string]string{
+	"conflict":          "The request conflicts with the current state of the resource.",
+	"forbidden":         "You do not have access to this resource.",
+	"gateway-timeout":   "The server did not respond in time.",
+	"not-found":         "The requested resource cannot be found.",
+	"unauthenticated":   "The API key is invalid or missing.",
+	"unparseable":       "The request body could not be parsed.",
+	"validation-failed": "The provided input is invalid.",
+}
+
+// writeError writes an RFC7807 'Problem Detail' response in the same format
+// as the v1 API.
+func (s *Server) writeError(w http.ResponseWriter, status int, problem, message string, details ...client.ErrorTypeDetail) {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(client.DetailedError{
+		Status:  status,
+		Message: message,
+		Type:    s.URL + "/problems/" + problem,
+		Title:   problemTitles[problem],
+		Details: details,
+	})
+}
+
+func (s *Server) notFound(w http.ResponseWriter, message string) {
+	s.writeError(w, http.StatusNotFound, "not-found", message)
+}
+
+func (s *Server) conflict(w http.ResponseWriter, message string) {
+	s.writeError(w, http.StatusConflict, "conflict", message)
+}
+
+// validationFailed writes a 422 with the provided details. It is a no-op
+// returning false if there are no details.
+func (s *Server) validationFailed(w http.ResponseWriter, details []client.ErrorTypeDetail) bool {
+	if len(details) == 0 {
+		return false
+	}
+	s.writeError(w, http.StatusUnprocessableEntity, "validation-failed", problemTitles["validation-failed"], details...)
+	return true
+}
+
+// validator accumulates field validation failures.
+type validator []client.ErrorTypeDetail
+
+func (v *validator) required(field string, blank bool) {
+	if blank {
+		*v = append(*v, client.ErrorTypeDetail{
+			Code:        "missing",
+			Field:       field,
+			Description: "cannot be blank",
+		})
+	}
+}
+
+func (v *validator) invalid(field, description string) {
+	*v = append(*v, client.ErrorTypeDetail{
+		Code:        "invalid",
+		Field:       field,
+		Description: description,
+	})
+}
+
+// writeJSONAPIError writes a JSON:API error document with a single error in
+// the same format as the v2 API.
+func (s *Server) writeJSONAPIError(w http.ResponseWriter, status int, problem, message string) {
+	s.writeJSONAPIErrors(w, status, problem, &jsonapi.ErrorObject{Detail: message})
+}
+
+// writeJSONAPIErrors writes a JSON:API error document in the same format as
+// the v2 API. The status, code and title of each error are filled in from the
+// problem type if not already set.
+func (s *Server) writeJSONAPIErrors(w http.ResponseWriter, status int, problem string, errs ...*jsonapi.ErrorObject) {
+	for _, e := range errs {
+		if e.Status == "" {
+			e.Status = strconv.Itoa(status)
+		}
+		if e.Code == "" {
+			e.Code = problem
+		}
+		if e.Title == "" {
+			e.Title = problemTitles[problem]
+		}
+	}
+
+	w.Header().Set("Content-Type", jsonapi.MediaType)
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(&jsonapi.ErrorsPayload{Errors: errs})
+}
+
+// jsonapiValidationFailed writes a 422 with an error for each of the details,
+// using the detail's field as the source pointer. It is a no-op returning
+// false if there are no details.
+func (s *Server) jsonapiValidationFailed(w http.ResponseWriter, details []client.ErrorTypeDetail) bool {
+	if len(details) == 0 {
+		return false
+	}
+	errs := make([]*jsonapi.ErrorObject, len(details))
+	for i, d := range details {
+		errs[i] = &jsonapi.ErrorObject{
+			Detail: d.Description,
+			Source: &jsonapi.ErrorSource{Pointer: d.Field},
+		}
+	}
+	s.writeJSONAPIErrors(w, http.StatusUnprocessableEntity, "validation-failed", errs...)
+	return true
+}
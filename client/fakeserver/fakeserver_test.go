@@ -0,0 +1,322 @@
+package fakeserver_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/honeycombio/terraform-provider-honeycombio/client"
+	"github.com/honeycombio/terraform-provider-honeycombio/client/fakeserver"
+)
+
+func newTestServer(t *testing.T, opts ...fakeserver.Option) (*fakeserver.Server, *client.Client) {
+	t.Helper()
+
+	s := fakeserver.New(opts...)
+	t.Cleanup(s.Close)
+
+	c, err := client.NewClientWithConfig(&client.Config{
+		APIKey: s.APIKey(),
+		APIUrl: s.URL,
+	})
+	require.NoError(t, err)
+
+	return s, c
+}
+
+func newTestDataset(t *testing.T, c *client.Client) *client.Dataset {
+	t.Helper()
+
+	ds, err := c.Datasets.Create(context.Background(), &client.Dataset{Name: "Test Dataset"})
+	require.NoError(t, err)
+
+	return ds
+}
+
+func TestServer_Auth(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	t.Run("returns the auth metadata", func(t *testing.T) {
+		_, c := newTestServer(t)
+
+		m, err := c.Auth.List(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, fakeserver.DefaultAuthMetadata(), m)
+	})
+
+	t.Run("rejects an unknown API key", func(t *testing.T) {
+		s, _ := newTestServer(t)
+		c, err := client.NewClientWithConfig(&client.Config{
+			APIKey: "not-the-key",
+			APIUrl: s.URL,
+		})
+		require.NoError(t, err)
+
+		_, err = c.Datasets.List(ctx)
+		var de client.DetailedError
+		require.ErrorAs(t, err, &de)
+		assert.Equal(t, http.StatusUnauthorized, de.Status)
+	})
+
+	t.Run("forbids access not granted to the API key", func(t *testing.T) {
+		m := fakeserver.DefaultAuthMetadata()
+		m.APIKeyAccess.Boards = false
+		_, c := newTestServer(t, fakeserver.WithAuthMetadata(m))
+
+		_, err := c.Boards.List(ctx)
+		var de client.DetailedError
+		require.ErrorAs(t, err, &de)
+		assert.Equal(t, http.StatusForbidden, de.Status)
+
+		_, err = c.Datasets.List(ctx)
+		require.NoError(t, err)
+	})
+
+	t.Run("requires column access to manage queries", func(t *testing.T) {
+		m := fakeserver.DefaultAuthMetadata()
+		m.APIKeyAccess.Columns = false
+		_, c := newTestServer(t, fakeserver.WithAuthMetadata(m))
+		ds := newTestDataset(t, c)
+
+		_, err := c.Queries.Create(ctx, ds.Slug, &client.QuerySpec{})
+		require.ErrorIs(t, err, client.ErrForbidden)
+		_, err = c.QueryAnnotations.List(ctx, ds.Slug)
+		require.ErrorIs(t, err, client.ErrForbidden)
+	})
+
+	t.Run("writes errors as problem details", func(t *testing.T) {
+		s, _ := newTestServer(t)
+
+		r, err := http.Get(s.URL + "/1/datasets")
+		require.NoError(t, err)
+		defer r.Body.Close()
+		assert.Equal(t, http.StatusUnauthorized, r.StatusCode)
+		assert.Equal(t, "application/problem+json", r.Header.Get("Content-Type"))
+	})
+}
+
+func TestServer_Datasets(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	_, c := newTestServer(t)
+
+	ds := newTestDataset(t, c)
+	assert.Equal(t, "test-dataset", ds.Slug)
+	assert.True(t, *ds.Settings.DeleteProtected)
+
+	_, err := c.Datasets.Create(ctx, &client.Dataset{Name: ds.Name})
+	require.ErrorIs(t, err, client.ErrDatasetExists)
+
+	_, err = c.Datasets.Create(ctx, &client.Dataset{})
+	var de client.DetailedError
+	require.ErrorAs(t, err, &de)
+	assert.Equal(t, http.StatusUnprocessableEntity, de.Status)
+	assert.Equal(t, "missing name - cannot be blank", de.Error())
+
+	err = c.Datasets.Delete(ctx, ds.Slug)
+	require.ErrorAs(t, err, &de)
+	assert.True(t, de.IsConflict())
+
+	ds.Settings.DeleteProtected = client.ToPtr(false)
+	_, err = c.Datasets.Update(ctx, ds)
+	require.NoError(t, err)
+	require.NoError(t, c.Datasets.Delete(ctx, ds.Slug))
+
+	_, err = c.Datasets.Get(ctx, ds.Slug)
+	require.ErrorAs(t, err, &de)
+	assert.True(t, de.IsNotFound())
+}
+
+func TestServer_Columns(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	_, c := newTestServer(t)
+	ds := newTestDataset(t, c)
+
+	col, err := c.Columns.Create(ctx, ds.Slug, &client.Column{KeyName: "duration_ms"})
+	require.NoError(t, err)
+	assert.Equal(t, client.ColumnTypeString, *col.Type)
+
+	got, err := c.Columns.GetByKeyName(ctx, ds.Slug, "duration_ms")
+	require.NoError(t, err)
+	assert.Equal(t, col, got)
+
+	_, err = c.Columns.Create(ctx, ds.Slug, &client.Column{KeyName: "duration_ms"})
+	var de client.DetailedError
+	require.ErrorAs(t, err, &de)
+	assert.True(t, de.IsConflict())
+
+	_, err = c.DatasetDefinitions.Update(ctx, ds.Slug, &client.DatasetDefinition{
+		DurationMs: &client.DefinitionColumn{Name: "duration_ms"},
+	})
+	require.NoError(t, err)
+	err = c.Columns.Delete(ctx, ds.Slug, col.ID)
+	require.ErrorAs(t, err, &de)
+	assert.Contains(t, de.Message, "in use by dataset definition")
+}
+
+func TestServer_Triggers(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	_, c := newTestServer(t)
+	ds := newTestDataset(t, c)
+
+	tr, err := c.Triggers.Create(ctx, ds.Slug, &client.Trigger{
+		Name: "Test Trigger",
+		Query: &client.QuerySpec{
+			Calculations: []client.CalculationSpec{{Op: client.CalculationOpCount}},
+		},
+		Threshold: &client.TriggerThreshold{
+			Op:    client.TriggerThresholdOpGreaterThan,
+			Value: 100,
+		},
+		Recipients: []client.NotificationRecipient{
+			{Type: client.RecipientTypeEmail, Target: "alerts@example.com"},
+		},
+	})
+	require.NoError(t, err)
+	assert.NotEmpty(t, tr.ID)
+	assert.Nil(t, tr.Query)
+	assert.NotEmpty(t, tr.QueryID)
+	assert.Equal(t, 900, tr.Frequency)
+	assert.Equal(t, client.TriggerAlertTypeOnChange, tr.AlertType)
+	require.Len(t, tr.Recipients, 1)
+	assert.NotEmpty(t, tr.Recipients[0].ID)
+
+	q, err := c.Queries.Get(ctx, ds.Slug, tr.QueryID)
+	require.NoError(t, err)
+	assert.Equal(t, client.CalculationOpCount, q.Calculations[0].Op)
+
+	rcpt, err := c.Recipients.Get(ctx, tr.Recipients[0].ID)
+	require.NoError(t, err)
+	assert.Equal(t, "alerts@example.com", rcpt.Details.EmailAddress)
+
+	_, err = c.Triggers.Create(ctx, ds.Slug, &client.Trigger{
+		Name:    "Bad Trigger",
+		QueryID: "does-not-exist",
+	})
+	var de client.DetailedError
+	require.ErrorAs(t, err, &de)
+	assert.Equal(t, http.StatusUnprocessableEntity, de.Status)
+	assert.Len(t, de.Details, 2)
+}
+
+func TestServer_SLOs(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	_, c := newTestServer(t)
+	ds := newTestDataset(t, c)
+
+	slo, err := c.SLOs.Create(ctx, ds.Slug, &client.SLO{
+		Name:             "Test SLO",
+		TimePeriodDays:   30,
+		TargetPerMillion: 995000,
+		SLI:              client.SLIRef{Alias: "sli.test"},
+	})
+	require.NoError(t, err)
+
+	ba, err := c.BurnAlerts.Create(ctx, ds.Slug, &client.BurnAlert{
+		AlertType:         client.BurnAlertAlertTypeExhaustionTime,
+		ExhaustionMinutes: client.ToPtr(60),
+		SLO:               client.SLORef{ID: slo.ID},
+	})
+	require.NoError(t, err)
+
+	alerts, err := c.BurnAlerts.ListForSLO(ctx, ds.Slug, slo.ID)
+	require.NoError(t, err)
+	assert.Len(t, alerts, 1)
+
+	// deleting the SLO removes its burn alerts
+	require.NoError(t, c.SLOs.Delete(ctx, ds.Slug, slo.ID))
+	_, err = c.BurnAlerts.Get(ctx, ds.Slug, ba.ID)
+	var de client.DetailedError
+	require.ErrorAs(t, err, &de)
+	assert.True(t, de.IsNotFound())
+}
+
+func TestServer_Boards(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	_, c := newTestServer(t)
+	ds := newTestDataset(t, c)
+
+	q, err := c.Queries.Create(ctx, ds.Slug, &client.QuerySpec{})
+	require.NoError(t, err)
+
+	b, err := c.Boards.Create(ctx, &client.Board{
+		Name: "Test Board",
+		Panels: []client.BoardPanel{
+			{
+				PanelType:  client.BoardPanelTypeQuery,
+				QueryPanel: &client.BoardQueryPanel{QueryID: *q.ID},
+			},
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, client.BoardTypeFlexible, b.BoardType)
+	assert.NotEmpty(t, b.Links.BoardURL)
+
+	_, err = c.Boards.Create(ctx, &client.Board{
+		Name: "Bad Board",
+		Panels: []client.BoardPanel{
+			{
+				PanelType:  client.BoardPanelTypeQuery,
+				QueryPanel: &client.BoardQueryPanel{QueryID: "does-not-exist"},
+			},
+		},
+	})
+	var de client.DetailedError
+	require.ErrorAs(t, err, &de)
+	assert.Equal(t, http.StatusUnprocessableEntity, de.Status)
+}
+
+func TestServer_Markers(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	_, c := newTestServer(t)
+	ds := newTestDataset(t, c)
+
+	m, err := c.Markers.Create(ctx, ds.Slug, &client.Marker{Message: "deploy"})
+	require.NoError(t, err)
+	assert.NotZero(t, m.StartTime)
+
+	got, err := c.Markers.Get(ctx, ds.Slug, m.ID)
+	require.NoError(t, err)
+	assert.Equal(t, m, got)
+
+	require.NoError(t, c.Markers.Delete(ctx, ds.Slug, m.ID))
+	_, err = c.Markers.Get(ctx, ds.Slug, m.ID)
+	var de client.DetailedError
+	require.ErrorAs(t, err, &de)
+	assert.True(t, de.IsNotFound())
+}
+
+func TestServer_QueryResults(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	_, c := newTestServer(t)
+	ds := newTestDataset(t, c)
+
+	q, err := c.Queries.Create(ctx, ds.Slug, &client.QuerySpec{})
+	require.NoError(t, err)
+
+	qr, err := c.QueryResults.Create(ctx, ds.Slug, &client.QueryResultRequest{ID: *q.ID})
+	require.NoError(t, err)
+
+	require.NoError(t, c.QueryResults.Get(ctx, ds.Slug, qr))
+	assert.True(t, qr.Complete)
+	assert.NotNil(t, qr.Data.Results)
+	assert.NotEmpty(t, qr.Links.Url)
+}
@@ -0,0 +1,160 @@
+package fakeserver
+
+import (
+	"net/http"
+
+	"github.com/honeycombio/terraform-provider-honeycombio/client"
+)
+
+func (s *Server) listMarkers(w http.ResponseWriter, r *http.Request) {
+	slug, ok := s.datasetScope(w, r, true)
+	if !ok {
+		return
+	}
+	writeJSON(w, http.StatusOK, s.markers.list(slug))
+}
+
+func (s *Server) createMarker(w http.ResponseWriter, r *http.Request) {
+	slug, ok := s.datasetScope(w, r, true)
+	if !ok {
+		return
+	}
+	var m client.Marker
+	if !s.decode(w, r, &m) {
+		return
+	}
+
+	ts := now()
+	m.ID = newID()
+	if m.StartTime == 0 {
+		m.StartTime = ts.Unix()
+	}
+	m.CreatedAt = &ts
+	m.UpdatedAt = &ts
+	s.markers.put(slug, m.ID, &m)
+
+	writeJSON(w, http.StatusCreated, m)
+}
+
+func (s *Server) updateMarker(w http.ResponseWriter, r *http.Request) {
+	slug, ok := s.datasetScope(w, r, true)
+	if !ok {
+		return
+	}
+	existing, ok := s.markers.get(slug, r.PathValue("id"))
+	if !ok {
+		s.notFound(w, "Marker not found")
+		return
+	}
+	var m client.Marker
+	if !s.decode(w, r, &m) {
+		return
+	}
+
+	ts := now()
+	m.ID = existing.ID
+	if m.StartTime == 0 {
+		m.StartTime = existing.StartTime
+	}
+	m.CreatedAt = existing.CreatedAt
+	m.UpdatedAt = &ts
+	s.markers.put(slug, m.ID, &m)
+
+	writeJSON(w, http.StatusOK, m)
+}
+
+func (s *Server) deleteMarker(w http.ResponseWriter, r *http.Request) {
+	slug, ok := s.datasetScope(w, r, true)
+	if !ok {
+		return
+	}
+	m, ok := s.markers.get(slug, r.PathValue("id"))
+	if !ok {
+		s.notFound(w, "Marker not found")
+		return
+	}
+	s.markers.delete(slug, m.ID)
+
+	writeJSON(w, http.StatusOK, m)
+}
+
+func (s *Server) listMarkerSettings(w http.ResponseWriter, r *http.Request) {
+	slug, ok := s.datasetScope(w, r, true)
+	if !ok {
+		return
+	}
+	writeJSON(w, http.StatusOK, s.markerSettings.list(slug))
+}
+
+func (s *Server) createMarkerSetting(w http.ResponseWriter, r *http.Request) {
+	slug, ok := s.datasetScope(w, r, true)
+	if !ok {
+		return
+	}
+	var m client.MarkerSetting
+	if !s.decode(w, r, &m) {
+		return
+	}
+	var v validator
+	v.required("type", m.Type == "")
+	v.required("color", m.Color == "")
+	if s.validationFailed(w, v) {
+		return
+	}
+	for _, existing := range s.markerSettings.list(slug) {
+		if existing.Type == m.Type {
+			s.conflict(w, "Marker Setting for type already exists")
+			return
+		}
+	}
+
+	ts := now()
+	m.ID = newID()
+	m.CreatedAt = &ts
+	m.UpdatedAt = &ts
+	s.markerSettings.put(slug, m.ID, &m)
+
+	writeJSON(w, http.StatusCreated, m)
+}
+
+func (s *Server) updateMarkerSetting(w http.ResponseWriter, r *http.Request) {
+	slug, ok := s.datasetScope(w, r, true)
+	if !ok {
+		return
+	}
+	existing, ok := s.markerSettings.get(slug, r.PathValue("id"))
+	if !ok {
+		s.notFound(w, "Marker Setting not found")
+		return
+	}
+	var m client.MarkerSetting
+	if !s.decode(w, r, &m) {
+		return
+	}
+	var v validator
+	v.required("type", m.Type == "")
+	v.required("color", m.Color == "")
+	if s.validationFailed(w, v) {
+		return
+	}
+
+	ts := now()
+	m.ID = existing.ID
+	m.CreatedAt = existing.CreatedAt
+	m.UpdatedAt = &ts
+	s.markerSettings.put(slug, m.ID, &m)
+
+	writeJSON(w, http.StatusOK, m)
+}
+
+func (s *Server) deleteMarkerSetting(w http.ResponseWriter, r *http.Request) {
+	slug, ok := s.datasetScope(w, r, true)
+	if !ok {
+		return
+	}
+	if !s.markerSettings.delete(slug, r.PathValue("id")) {
+		s.notFound(w, "Marker Setting not found")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,212 @@
+package fakeserver
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/honeycombio/terraform-provider-honeycombio/client"
+)
+
+func (s *Server) createQuery(w http.ResponseWriter, r *http.Request) {
+	slug, ok := s.datasetScope(w, r, true)
+	if !ok {
+		return
+	}
+	var q client.QuerySpec
+	if !s.decode(w, r, &q) {
+		return
+	}
+	if q.ID != nil {
+		var v validator
+		v.invalid("id", "cannot be provided when creating a query")
+		s.validationFailed(w, v)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, s.storeQuery(slug, &q))
+}
+
+func (s *Server) getQuery(w http.ResponseWriter, r *http.Request) {
+	slug, ok := s.datasetScope(w, r, true)
+	if !ok {
+		return
+	}
+	q, ok := s.queries.get(slug, r.PathValue("id"))
+	if !ok {
+		s.notFound(w, "Query not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, q)
+}
+
+// storeQuery saves a copy of the query, returning the stored query. Queries
+// are immutable, so the copy is never modified once stored.
+func (s *Server) storeQuery(slug string, q *client.QuerySpec) *client.QuerySpec {
+	stored := *q
+	stored.ID = client.ToPtr(newID())
+	s.queries.put(slug, *stored.ID, &stored)
+	return &stored
+}
+
+func (s *Server) listQueryAnnotations(w http.ResponseWriter, r *http.Request) {
+	slug, ok := s.datasetScope(w, r, true)
+	if !ok {
+		return
+	}
+	writeJSON(w, http.StatusOK, s.queryAnnotations.list(slug))
+}
+
+func (s *Server) createQueryAnnotation(w http.ResponseWriter, r *http.Request) {
+	slug, ok := s.datasetScope(w, r, true)
+	if !ok {
+		return
+	}
+	var qa client.QueryAnnotation
+	if !s.decode(w, r, &qa) {
+		return
+	}
+	if s.validationFailed(w, s.validateQueryAnnotation(slug, &qa)) {
+		return
+	}
+
+	ts := now()
+	qa.ID = newID()
+	if qa.Source == "" {
+		qa.Source = client.QueryAnnotationSourceQuery
+	}
+	qa.CreatedAt = &ts
+	qa.UpdatedAt = &ts
+	s.queryAnnotations.put(slug, qa.ID, &qa)
+
+	writeJSON(w, http.StatusCreated, qa)
+}
+
+func (s *Server) getQueryAnnotation(w http.ResponseWriter, r *http.Request) {
+	slug, ok := s.datasetScope(w, r, true)
+	if !ok {
+		return
+	}
+	qa, ok := s.queryAnnotations.get(slug, r.PathValue("id"))
+	if !ok {
+		s.notFound(w, "Query Annotation not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, qa)
+}
+
+func (s *Server) updateQueryAnnotation(w http.ResponseWriter, r *http.Request) {
+	slug, ok := s.datasetScope(w, r, true)
+	if !ok {
+		return
+	}
+	existing, ok := s.queryAnnotations.get(slug, r.PathValue("id"))
+	if !ok {
+		s.notFound(w, "Query Annotation not found")
+		return
+	}
+	var qa client.QueryAnnotation
+	if !s.decode(w, r, &qa) {
+		return
+	}
+	if s.validationFailed(w, s.validateQueryAnnotation(slug, &qa)) {
+		return
+	}
+
+	ts := now()
+	qa.ID = existing.ID
+	qa.Source = existing.Source
+	qa.CreatedAt = existing.CreatedAt
+	qa.UpdatedAt = &ts
+	s.queryAnnotations.put(slug, qa.ID, &qa)
+
+	writeJSON(w, http.StatusOK, qa)
+}
+
+func (s *Server) deleteQueryAnnotation(w http.ResponseWriter, r *http.Request) {
+	slug, ok := s.datasetScope(w, r, true)
+	if !ok {
+		return
+	}
+	if !s.queryAnnotations.delete(slug, r.PathValue("id")) {
+		s.notFound(w, "Query Annotation not found")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) validateQueryAnnotation(slug string, qa *client.QueryAnnotation) validator {
+	var v validator
+	v.required("name", qa.Name == "")
+	v.required("query_id", qa.QueryID == "")
+	if qa.QueryID != "" {
+		if _, ok := s.queries.get(slug, qa.QueryID); !ok {
+			v.invalid("query_id", "query "+qa.QueryID+" not found")
+		}
+	}
+	return v
+}
+
+func (s *Server) createQueryResult(w http.ResponseWriter, r *http.Request) {
+	slug, ok := s.datasetScope(w, r, true)
+	if !ok {
+		return
+	}
+	var req client.QueryResultRequest
+	if !s.decode(w, r, &req) {
+		return
+	}
+	var v validator
+	v.required("query_id", req.ID == "")
+	if req.ID != "" {
+		if _, ok := s.queries.get(slug, req.ID); !ok {
+			v.invalid("query_id", "query "+req.ID+" not found")
+		}
+	}
+	if s.validationFailed(w, v) {
+		return
+	}
+
+	// results are always empty, and are computed immediately unless
+	// the Server has been configured to keep them pending
+	qr := client.QueryResult{
+		ID:       newID(),
+		Complete: s.queryResultPolls == 0,
+	}
+	if !qr.Complete {
+		s.pendingPolls[qr.ID] = s.queryResultPolls
+	}
+	qr.Data.Series = make([]struct {
+		Time time.Time      `json:"time"`
+		Data map[string]any `json:"data"`
+	}, 0)
+	qr.Data.Results = make([]struct {
+		Data map[string]any `json:"data"`
+	}, 0)
+	qr.Links.Url = s.URL + "/" + s.auth.Team.Slug + "/environments/" + s.auth.Environment.Slug +
+		"/datasets/" + slug + "/result/" + qr.ID
+	qr.Links.GraphUrl = qr.Links.Url + "/snapshot"
+	s.queryResults.put(slug, qr.ID, &qr)
+
+	writeJSON(w, http.StatusCreated, qr)
+}
+
+func (s *Server) getQueryResult(w http.ResponseWriter, r *http.Request) {
+	slug, ok := s.datasetScope(w, r, true)
+	if !ok {
+		return
+	}
+	qr, ok := s.queryResults.get(slug, r.PathValue("id"))
+	if !ok {
+		s.notFound(w, "Query Result not found")
+		return
+	}
+	if n, ok := s.pendingPolls[qr.ID]; ok {
+		if n <= 1 {
+			delete(s.pendingPolls, qr.ID)
+			qr.Complete = true
+		} else {
+			s.pendingPolls[qr.ID] = n - 1
+		}
+	}
+	writeJSON(w, http.StatusOK, qr)
+}
@@ -0,0 +1,167 @@
+package fakeserver
+
+import (
+	"net/http"
+	"slices"
+
+	"github.com/honeycombio/terraform-provider-honeycombio/client"
+)
+
+func (s *Server) listRecipients(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, s.recipients.list(""))
+}
+
+func (s *Server) createRecipient(w http.ResponseWriter, r *http.Request) {
+	var rcpt client.Recipient
+	if !s.decode(w, r, &rcpt) {
+		return
+	}
+	if s.validationFailed(w, validateRecipient(&rcpt)) {
+		return
+	}
+	for _, existing := range s.recipients.list("") {
+		if existing.Type == rcpt.Type && recipientTarget(existing) == recipientTarget(&rcpt) {
+			s.conflict(w, "Recipient already exists")
+			return
+		}
+	}
+
+	rcpt.ID = newID()
+	rcpt.CreatedAt = now()
+	rcpt.UpdatedAt = rcpt.CreatedAt
+	s.recipients.put("", rcpt.ID, &rcpt)
+
+	writeJSON(w, http.StatusCreated, rcpt)
+}
+
+func (s *Server) getRecipient(w http.ResponseWriter, r *http.Request) {
+	rcpt, ok := s.recipients.get("", r.PathValue("id"))
+	if !ok {
+		s.notFound(w, "Recipient not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, rcpt)
+}
+
+func (s *Server) updateRecipient(w http.ResponseWriter, r *http.Request) {
+	existing, ok := s.recipients.get("", r.PathValue("id"))
+	if !ok {
+		s.notFound(w, "Recipient not found")
+		return
+	}
+	var rcpt client.Recipient
+	if !s.decode(w, r, &rcpt) {
+		return
+	}
+	if s.validationFailed(w, validateRecipient(&rcpt)) {
+		return
+	}
+
+	updated := *existing
+	updated.Type = rcpt.Type
+	updated.Details = rcpt.Details
+	updated.UpdatedAt = now()
+	s.recipients.put("", updated.ID, &updated)
+
+	writeJSON(w, http.StatusOK, updated)
+}
+
+func (s *Server) deleteRecipient(w http.ResponseWriter, r *http.Request) {
+	if !s.recipients.delete("", r.PathValue("id")) {
+		s.notFound(w, "Recipient not found")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func validateRecipient(r *client.Recipient) validator {
+	var v validator
+	v.required("type", r.Type == "")
+	if r.Type != "" && !slices.Contains(client.RecipientTypes(), r.Type) {
+		v.invalid("type", "unsupported recipient type")
+	}
+	switch r.Type {
+	case client.RecipientTypeEmail:
+		v.required("details.email_address", r.Details.EmailAddress == "")
+	case client.RecipientTypePagerDuty:
+		v.required("details.pagerduty_integration_key", r.Details.PDIntegrationKey == "")
+		v.required("details.pagerduty_integration_name", r.Details.PDIntegrationName == "")
+	case client.RecipientTypeSlack:
+		v.required("details.slack_channel", r.Details.SlackChannel == "")
+	case client.RecipientTypeWebhook, client.RecipientTypeMSTeams, client.RecipientTypeMSTeamsWorkflow:
+		v.required("details.webhook_name", r.Details.WebhookName == "")
+		v.required("details.webhook_url", r.Details.WebhookURL == "")
+	}
+	return v
+}
+
+// recipientTarget returns the value the API uses as the target of a
+// recipient when it is embedded in a trigger or burn alert.
+func recipientTarget(r *client.Recipient) string {
+	switch r.Type {
+	case client.RecipientTypeEmail:
+		return r.Details.EmailAddress
+	case client.RecipientTypePagerDuty:
+		return r.Details.PDIntegrationName
+	case client.RecipientTypeSlack:
+		return r.Details.SlackChannel
+	case client.RecipientTypeMarker:
+		return r.Details.MarkerID
+	default:
+		return r.Details.WebhookName
+	}
+}
+
+// resolveNotificationRecipients fills in the ID, type and target of each
+// notification recipient in the same way the API does. Recipients referenced
+// by ID must exist, while those specified inline by type and target are
+// created if no matching recipient exists.
+func (s *Server) resolveNotificationRecipients(recipients []client.NotificationRecipient) validator {
+	var v validator
+	for i := range recipients {
+		nr := &recipients[i]
+		if nr.ID != "" {
+			rcpt, ok := s.recipients.get("", nr.ID)
+			if !ok {
+				v.invalid("recipients", "recipient "+nr.ID+" not found")
+				continue
+			}
+			nr.Type = rcpt.Type
+			nr.Target = recipientTarget(rcpt)
+			continue
+		}
+
+		found := false
+		for _, rcpt := range s.recipients.list("") {
+			if rcpt.Type == nr.Type && recipientTarget(rcpt) == nr.Target {
+				nr.ID = rcpt.ID
+				found = true
+				break
+			}
+		}
+		if found {
+			continue
+		}
+		if nr.Type != client.RecipientTypeEmail && nr.Type != client.RecipientTypeSlack && nr.Type != client.RecipientTypeMarker {
+			v.invalid("recipients", "recipients of type "+nr.Type.String()+" must be referenced by ID")
+			continue
+		}
+		rcpt := &client.Recipient{
+			ID:        newID(),
+			Type:      nr.Type,
+			CreatedAt: now(),
+		}
+		switch nr.Type {
+		case client.RecipientTypeEmail:
+			rcpt.Details.EmailAddress = nr.Target
+		case client.RecipientTypeSlack:
+			rcpt.Details.SlackChannel = nr.Target
+		case client.RecipientTypeMarker:
+			rcpt.Details.MarkerID = nr.Target
+		}
+		rcpt.UpdatedAt = rcpt.CreatedAt
+		s.recipients.put("", rcpt.ID, rcpt)
+		nr.ID = rcpt.ID
+	}
+	return v
+}
@@ -0,0 +1,305 @@
+// Package fakeserver provides an in-memory implementation of the Honeycomb API
+// for use in tests which should not require access to a live Honeycomb team.
+//
+// Both the v1 API, authenticated with an API key, and the JSON:API-based v2
+// management API, authenticated with an API key ID and secret pair, are
+// served from the same Server.
+//
+// The Server aims to respond with the same status codes and error payloads as
+// the real API for the endpoints used by the embedded clients, but it is not a
+// complete reimplementation: query results are returned immediately and
+// without data, and only a subset of the API's validation is enforced.
+package fakeserver
+
+import (
+	"crypto/rand"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/honeycombio/terraform-provider-honeycombio/client"
+)
+
+const (
+	// DefaultAPIKey is the v1 API key accepted by a Server unless overridden
+	// with WithAPIKey.
+	DefaultAPIKey = "fakeserver-api-key"
+	// DefaultAPIKeyID and DefaultAPIKeySecret are the v2 API key pair
+	// accepted by a Server unless overridden with WithAPIKeyPair.
+	DefaultAPIKeyID     = "hcamk_fakeserver"
+	DefaultAPIKeySecret = "fakeserver-api-key-secret"
+)
+
+// Server is an in-memory fake of the Honeycomb API served over HTTP.
+type Server struct {
+	*httptest.Server
+
+	apiKey       string
+	apiKeyID     string
+	apiKeySecret string
+	auth         client.AuthMetadata
+	scopes       []string
+	createdAt    time.Time
+
+	// queryResultPolls is how many times each query result is polled before
+	// it completes, and pendingPolls is the count remaining per result.
+	queryResultPolls int
+	pendingPolls     map[string]int
+
+	// lostResponses is how many more requests per route pattern are acted
+	// on but answered with a gateway timeout, as if the response was lost.
+	lostResponses map[string]int
+
+	mu                 sync.Mutex
+	requests           map[string]int
+	datasets           *collection[client.Dataset]
+	datasetDefinitions map[string]*client.DatasetDefinition
+	columns            *collection[client.Column]
+	derivedColumns     *collection[client.DerivedColumn]
+	triggers           *collection[client.Trigger]
+	slos               *collection[client.SLO]
+	burnAlerts         *collection[client.BurnAlert]
+	boards             *collection[client.Board]
+	boardViews         *collection[client.BoardView]
+	markers            *collection[client.Marker]
+	markerSettings     *collection[client.MarkerSetting]
+	recipients         *collection[client.Recipient]
+	queries            *collection[client.QuerySpec]
+	queryAnnotations   *collection[client.QueryAnnotation]
+	queryResults       *collection[client.QueryResult]
+	environments       *collection[environment]
+	apiKeys            *collection[apiKey]
+}
+
+// Option configures a Server.
+type Option func(*Server)
+
+// WithAPIKey sets the API key which the Server will accept in the
+// X-Honeycomb-Team header.
+func WithAPIKey(key string) Option {
+	return func(s *Server) { s.apiKey = key }
+}
+
+// WithAPIKeyPair sets the v2 API key ID and secret which the Server will
+// accept as a bearer token.
+func WithAPIKeyPair(id, secret string) Option {
+	return func(s *Server) {
+		s.apiKeyID = id
+		s.apiKeySecret = secret
+	}
+}
+
+// WithScopes overrides the scopes granted to the v2 API key.
+//
+// A request to a v2 endpoint requiring a scope the key has not been granted
+// will receive a 403.
+func WithScopes(scopes ...string) Option {
+	return func(s *Server) { s.scopes = scopes }
+}
+
+// WithAuthMetadata overrides the metadata returned by the Auth endpoint.
+//
+// The APIKeyAccess of the metadata is also used to authorize requests: a
+// request to an endpoint the key does not have access to will receive a 403.
+func WithAuthMetadata(m client.AuthMetadata) Option {
+	return func(s *Server) { s.auth = m }
+}
+
+// WithQueryResultPolls makes query results incomplete until they have been
+// polled the given number of times, rather than completing immediately.
+func WithQueryResultPolls(n int) Option {
+	return func(s *Server) { s.queryResultPolls = n }
+}
+
+// WithLostResponses makes the Server act on the first n requests for the
+// route pattern, such as "POST /1/triggers/{dataset}", but answer them with
+// a 504 Gateway Timeout, as if the response had been lost on its way back.
+func WithLostResponses(pattern string, n int) Option {
+	return func(s *Server) { s.lostResponses[pattern] = n }
+}
+
+// New starts and returns a new Server. The caller should call Close when
+// finished, to shut it down.
+func New(opts ...Option) *Server {
+	s := &Server{
+		apiKey:             DefaultAPIKey,
+		apiKeyID:           DefaultAPIKeyID,
+		apiKeySecret:       DefaultAPIKeySecret,
+		auth:               DefaultAuthMetadata(),
+		scopes:             DefaultScopes(),
+		createdAt:          now(),
+		requests:           make(map[string]int),
+		pendingPolls:       make(map[string]int),
+		lostResponses:      make(map[string]int),
+		datasets:           newCollection[client.Dataset](),
+		datasetDefinitions: make(map[string]*client.DatasetDefinition),
+		columns:            newCollection[client.Column](),
+		derivedColumns:     newCollection[client.DerivedColumn](),
+		triggers:           newCollection[client.Trigger](),
+		slos:               newCollection[client.SLO](),
+		burnAlerts:         newCollection[client.BurnAlert](),
+		boards:             newCollection[client.Board](),
+		boardViews:         newCollection[client.BoardView](),
+		markers:            newCollection[client.Marker](),
+		markerSettings:     newCollection[client.MarkerSetting](),
+		recipients:         newCollection[client.Recipient](),
+		queries:            newCollection[client.QuerySpec](),
+		queryAnnotations:   newCollection[client.QueryAnnotation](),
+		queryResults:       newCollection[client.QueryResult](),
+		environments:       newCollection[environment](),
+		apiKeys:            newCollection[apiKey](),
+	}
+	for _, o := range opts {
+		o(s)
+	}
+
+	mux := http.NewServeMux()
+	s.registerV1Routes(mux)
+	s.registerV2Routes(mux)
+	s.Server = httptest.NewServer(mux)
+
+	return s
+}
+
+// APIKey returns the v1 API key accepted by the Server.
+func (s *Server) APIKey() string { return s.apiKey }
+
+// APIKeyPair returns the v2 API key ID and secret accepted by the Server.
+func (s *Server) APIKeyPair() (string, string) { return s.apiKeyID, s.apiKeySecret }
+
+// RequestCount returns the number of requests the Server has received for
+// the route pattern, such as "GET /2/auth", including those which were
+// rejected.
+func (s *Server) RequestCount(pattern string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.requests[pattern]
+}
+
+// LoseResponses makes the Server lose the responses to the next n
+// requests for the route pattern, as WithLostResponses does from the start.
+func (s *Server) LoseResponses(pattern string, n int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.lostResponses[pattern] = n
+}
+
+// DefaultAuthMetadata returns the AuthMetadata used by a Server unless
+// overridden with WithAuthMetadata. It grants access to everything.
+func DefaultAuthMetadata() client.AuthMetadata {
+	var m client.AuthMetadata
+	m.APIKeyAccess.Boards = true
+	m.APIKeyAccess.Columns = true
+	m.APIKeyAccess.CreateDatasets = true
+	m.APIKeyAccess.Events = true
+	m.APIKeyAccess.Markers = true
+	m.APIKeyAccess.Queries = true
+	m.APIKeyAccess.Recipients = true
+	m.APIKeyAccess.SLOs = true
+	m.APIKeyAccess.Triggers = true
+	m.Environment.Name = "Fake"
+	m.Environment.Slug = "fake"
+	m.Team.Name = "Fake Team"
+	m.Team.Slug = "fake-team"
+	return m
+}
+
+// DefaultScopes returns the scopes granted to the v2 API key unless
+// overridden with WithScopes. It grants access to everything.
+func DefaultScopes() []string {
+	return []string{
+		scopeAPIKeysWrite,
+		scopeEnvironmentsWrite,
+	}
+}
+
+// access determines if the given AuthMetadata permits a request.
+type access func(m *client.AuthMetadata) bool
+
+var (
+	accessAny            access = func(*client.AuthMetadata) bool { return true }
+	accessBoards         access = func(m *client.AuthMetadata) bool { return m.APIKeyAccess.Boards }
+	accessColumns        access = func(m *client.AuthMetadata) bool { return m.APIKeyAccess.Columns }
+	accessCreateDatasets access = func(m *client.AuthMetadata) bool { return m.APIKeyAccess.CreateDatasets }
+	accessMarkers        access = func(m *client.AuthMetadata) bool { return m.APIKeyAccess.Markers }
+	accessQueries        access = func(m *client.AuthMetadata) bool { return m.APIKeyAccess.Queries }
+	accessRecipients     access = func(m *client.AuthMetadata) bool { return m.APIKeyAccess.Recipients }
+	accessSLOs           access = func(m *client.AuthMetadata) bool { return m.APIKeyAccess.SLOs }
+	accessTriggers       access = func(m *client.AuthMetadata) bool { return m.APIKeyAccess.Triggers }
+)
+
+// handle registers a v1 handler on the mux which is wrapped with
+// authentication and authorization checks and holds the Server's lock.
+func (s *Server) handle(mux *http.ServeMux, pattern string, a access, h http.HandlerFunc) {
+	mux.HandleFunc(pattern, func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.requests[pattern]++
+
+		if key := r.Header.Get("X-Honeycomb-Team"); key == "" || key != s.apiKey {
+			s.writeError(w, http.StatusUnauthorized, "unauthenticated", "unknown API key - check your credentials")
+			return
+		}
+		if !a(&s.auth) {
+			s.writeError(w, http.StatusForbidden, "forbidden", "API key does not have access to this resource")
+			return
+		}
+		if s.lostResponses[pattern] > 0 {
+			s.lostResponses[pattern]--
+			h(httptest.NewRecorder(), r)
+			s.writeError(w, http.StatusGatewayTimeout, "gateway-timeout", "gateway timeout")
+			return
+		}
+		h(w, r)
+	})
+}
+
+// decode reads the JSON request body into v, responding with a 400 if the
+// body could not be parsed.
+func (s *Server) decode(w http.ResponseWriter, r *http.Request, v any) bool {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		s.writeError(w, http.StatusBadRequest, "unparseable", "could not parse request body")
+		return false
+	}
+	return true
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if v != nil {
+		_ = json.NewEncoder(w).Encode(v)
+	}
+}
+
+// newID returns a random identifier in the style of those used by the API.
+func newID() string {
+	return rand.Text()[:11]
+}
+
+// now returns the current time truncated to the precision of the API.
+func now() time.Time {
+	return time.Now().UTC().Truncate(time.Second)
+}
+
+// slugify converts a name into a slug in the same way the API does for
+// datasets.
+func slugify(name string) string {
+	var b strings.Builder
+	lastDash := false
+	for _, r := range strings.ToLower(name) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') || r == '_' || r == '.' {
+			b.WriteRune(r)
+			lastDash = false
+			continue
+		}
+		if !lastDash {
+			b.WriteRune('-')
+			lastDash = true
+		}
+	}
+	return strings.Trim(b.String(), "-")
+}
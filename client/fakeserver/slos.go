@@ -0,0 +1,235 @@
+package fakeserver
+
+import (
+	"net/http"
+
+	"github.com/honeycombio/terraform-provider-honeycombio/client"
+)
+
+func (s *Server) listSLOs(w http.ResponseWriter, r *http.Request) {
+	slug, ok := s.datasetScope(w, r, true)
+	if !ok {
+		return
+	}
+	writeJSON(w, http.StatusOK, s.slos.list(slug))
+}
+
+func (s *Server) createSLO(w http.ResponseWriter, r *http.Request) {
+	slug, ok := s.datasetScope(w, r, true)
+	if !ok {
+		return
+	}
+	var slo client.SLO
+	if !s.decode(w, r, &slo) {
+		return
+	}
+	if s.validationFailed(w, s.validateSLO(slug, &slo)) {
+		return
+	}
+
+	slo.ID = newID()
+	if slo.Tags == nil {
+		slo.Tags = []client.Tag{}
+	}
+	slo.CreatedAt = now()
+	slo.UpdatedAt = slo.CreatedAt
+	s.slos.put(slug, slo.ID, &slo)
+
+	writeJSON(w, http.StatusCreated, slo)
+}
+
+func (s *Server) getSLO(w http.ResponseWriter, r *http.Request) {
+	slug, ok := s.datasetScope(w, r, true)
+	if !ok {
+		return
+	}
+	slo, ok := s.slos.get(slug, r.PathValue("id"))
+	if !ok {
+		s.notFound(w, "SLO not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, slo)
+}
+
+func (s *Server) updateSLO(w http.ResponseWriter, r *http.Request) {
+	slug, ok := s.datasetScope(w, r, true)
+	if !ok {
+		return
+	}
+	existing, ok := s.slos.get(slug, r.PathValue("id"))
+	if !ok {
+		s.notFound(w, "SLO not found")
+		return
+	}
+	var slo client.SLO
+	if !s.decode(w, r, &slo) {
+		return
+	}
+	if s.validationFailed(w, s.validateSLO(slug, &slo)) {
+		return
+	}
+
+	slo.ID = existing.ID
+	if slo.Tags == nil {
+		slo.Tags = []client.Tag{}
+	}
+	slo.CreatedAt = existing.CreatedAt
+	slo.UpdatedAt = now()
+	s.slos.put(slug, slo.ID, &slo)
+
+	writeJSON(w, http.StatusOK, slo)
+}
+
+func (s *Server) deleteSLO(w http.ResponseWriter, r *http.Request) {
+	slug, ok := s.datasetScope(w, r, true)
+	if !ok {
+		return
+	}
+	id := r.PathValue("id")
+	if !s.slos.delete(slug, id) {
+		s.notFound(w, "SLO not found")
+		return
+	}
+	// deleting an SLO also deletes its burn alerts
+	for _, ba := range s.burnAlerts.list(slug) {
+		if ba.SLO.ID == id {
+			s.burnAlerts.delete(slug, ba.ID)
+		}
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) validateSLO(slug string, slo *client.SLO) validator {
+	var v validator
+	v.required("name", slo.Name == "")
+	v.required("sli.alias", slo.SLI.Alias == "")
+	v.required("time_period_days", slo.TimePeriodDays == 0)
+	v.required("target_per_million", slo.TargetPerMillion == 0)
+	if slug == client.EnvironmentWideSlug {
+		v.required("dataset_slugs", len(slo.DatasetSlugs) == 0)
+		for _, ds := range slo.DatasetSlugs {
+			if _, ok := s.datasets.get("", ds); !ok {
+				v.invalid("dataset_slugs", "dataset "+ds+" not found")
+			}
+		}
+	}
+	return v
+}
+
+func (s *Server) listBurnAlerts(w http.ResponseWriter, r *http.Request) {
+	slug, ok := s.datasetScope(w, r, true)
+	if !ok {
+		return
+	}
+	sloID := r.URL.Query().Get("slo_id")
+	if sloID == "" {
+		writeJSON(w, http.StatusOK, s.burnAlerts.list(slug))
+		return
+	}
+	if _, ok := s.slos.get(slug, sloID); !ok {
+		s.notFound(w, "SLO not found")
+		return
+	}
+	result := make([]*client.BurnAlert, 0)
+	for _, ba := range s.burnAlerts.list(slug) {
+		if ba.SLO.ID == sloID {
+			result = append(result, ba)
+		}
+	}
+	writeJSON(w, http.StatusOK, result)
+}
+
+func (s *Server) createBurnAlert(w http.ResponseWriter, r *http.Request) {
+	slug, ok := s.datasetScope(w, r, true)
+	if !ok {
+		return
+	}
+	var ba client.BurnAlert
+	if !s.decode(w, r, &ba) {
+		return
+	}
+	if s.validationFailed(w, s.validateBurnAlert(slug, &ba)) {
+		return
+	}
+
+	ba.ID = newID()
+	ba.CreatedAt = now()
+	ba.UpdatedAt = ba.CreatedAt
+	s.burnAlerts.put(slug, ba.ID, &ba)
+
+	writeJSON(w, http.StatusCreated, ba)
+}
+
+func (s *Server) getBurnAlert(w http.ResponseWriter, r *http.Request) {
+	slug, ok := s.datasetScope(w, r, true)
+	if !ok {
+		return
+	}
+	ba, ok := s.burnAlerts.get(slug, r.PathValue("id"))
+	if !ok {
+		s.notFound(w, "Burn Alert not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, ba)
+}
+
+func (s *Server) updateBurnAlert(w http.ResponseWriter, r *http.Request) {
+	slug, ok := s.datasetScope(w, r, true)
+	if !ok {
+		return
+	}
+	existing, ok := s.burnAlerts.get(slug, r.PathValue("id"))
+	if !ok {
+		s.notFound(w, "Burn Alert not found")
+		return
+	}
+	var ba client.BurnAlert
+	if !s.decode(w, r, &ba) {
+		return
+	}
+	if s.validationFailed(w, s.validateBurnAlert(slug, &ba)) {
+		return
+	}
+
+	ba.ID = existing.ID
+	ba.CreatedAt = existing.CreatedAt
+	ba.UpdatedAt = now()
+	s.burnAlerts.put(slug, ba.ID, &ba)
+
+	writeJSON(w, http.StatusOK, ba)
+}
+
+func (s *Server) deleteBurnAlert(w http.ResponseWriter, r *http.Request) {
+	slug, ok := s.datasetScope(w, r, true)
+	if !ok {
+		return
+	}
+	if !s.burnAlerts.delete(slug, r.PathValue("id")) {
+		s.notFound(w, "Burn Alert not found")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) validateBurnAlert(slug string, ba *client.BurnAlert) validator {
+	var v validator
+	v.required("slo.id", ba.SLO.ID == "")
+	if ba.SLO.ID != "" {
+		if _, ok := s.slos.get(slug, ba.SLO.ID); !ok {
+			v.invalid("slo.id", "SLO "+ba.SLO.ID+" not found")
+		}
+	}
+	switch ba.AlertType {
+	case client.BurnAlertAlertTypeExhaustionTime:
+		v.required("exhaustion_minutes", ba.ExhaustionMinutes == nil)
+	case client.BurnAlertAlertTypeBudgetRate:
+		v.required("budget_rate_window_minutes", ba.BudgetRateWindowMinutes == nil)
+		v.required("budget_rate_decrease_threshold_per_million", ba.BudgetRateDecreaseThresholdPerMillion == nil)
+	case "":
+		v.required("alert_type", true)
+	default:
+		v.invalid("alert_type", "must be one of exhaustion_time, budget_rate")
+	}
+	v = append(v, s.resolveNotificationRecipients(ba.Recipients)...)
+	return v
+}
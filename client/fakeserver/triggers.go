@@ -0,0 +1,132 @@
+package fakeserver
+
+import (
+	"net/http"
+
+	"github.com/honeycombio/terraform-provider-honeycombio/client"
+)
+
+func (s *Server) listTriggers(w http.ResponseWriter, r *http.Request) {
+	slug, ok := s.datasetScope(w, r, true)
+	if !ok {
+		return
+	}
+	writeJSON(w, http.StatusOK, s.triggers.list(slug))
+}
+
+func (s *Server) createTrigger(w http.ResponseWriter, r *http.Request) {
+	slug, ok := s.datasetScope(w, r, true)
+	if !ok {
+		return
+	}
+	var t client.Trigger
+	if !s.decode(w, r, &t) {
+		return
+	}
+	if !s.prepareTrigger(w, slug, &t) {
+		return
+	}
+
+	ts := now()
+	t.ID = newID()
+	t.CreatedAt = &ts
+	s.triggers.put(slug, t.ID, &t)
+
+	writeJSON(w, http.StatusCreated, t)
+}
+
+func (s *Server) getTrigger(w http.ResponseWriter, r *http.Request) {
+	slug, ok := s.datasetScope(w, r, true)
+	if !ok {
+		return
+	}
+	t, ok := s.triggers.get(slug, r.PathValue("id"))
+	if !ok {
+		s.notFound(w, "Trigger not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, t)
+}
+
+func (s *Server) updateTrigger(w http.ResponseWriter, r *http.Request) {
+	slug, ok := s.datasetScope(w, r, true)
+	if !ok {
+		return
+	}
+	existing, ok := s.triggers.get(slug, r.PathValue("id"))
+	if !ok {
+		s.notFound(w, "Trigger not found")
+		return
+	}
+	var t client.Trigger
+	if !s.decode(w, r, &t) {
+		return
+	}
+	if !s.prepareTrigger(w, slug, &t) {
+		return
+	}
+
+	t.ID = existing.ID
+	t.CreatedAt = existing.CreatedAt
+	s.triggers.put(slug, t.ID, &t)
+
+	writeJSON(w, http.StatusOK, t)
+}
+
+func (s *Server) deleteTrigger(w http.ResponseWriter, r *http.Request) {
+	slug, ok := s.datasetScope(w, r, true)
+	if !ok {
+		return
+	}
+	if !s.triggers.delete(slug, r.PathValue("id")) {
+		s.notFound(w, "Trigger not found")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// prepareTrigger validates the trigger and fills in the defaults and
+// server-side fields the API would, responding with a 422 on failure.
+func (s *Server) prepareTrigger(w http.ResponseWriter, slug string, t *client.Trigger) bool {
+	var v validator
+	v.required("name", t.Name == "")
+	v.required("threshold", t.Threshold == nil)
+	if t.Query == nil && t.QueryID == "" {
+		v.required("query", true)
+	}
+	if t.Query != nil && t.QueryID != "" {
+		v.invalid("query_id", "cannot be provided with an inline query")
+	}
+	if t.QueryID != "" {
+		if _, ok := s.queries.get(slug, t.QueryID); !ok {
+			v.invalid("query_id", "query "+t.QueryID+" not found")
+		}
+	}
+	v = append(v, s.resolveNotificationRecipients(t.Recipients)...)
+	if s.validationFailed(w, v) {
+		return false
+	}
+
+	if t.Query != nil {
+		q := s.storeQuery(slug, t.Query)
+		t.QueryID = *q.ID
+		t.Query = nil
+	}
+	t.DatasetSlug = slug
+	if t.Frequency == 0 {
+		t.Frequency = 900
+	}
+	if t.AlertType == "" {
+		t.AlertType = client.TriggerAlertTypeOnChange
+	}
+	if t.EvaluationScheduleType == "" {
+		t.EvaluationScheduleType = client.TriggerEvaluationScheduleFrequency
+	}
+	if t.Threshold.ExceededLimit == 0 {
+		t.Threshold.ExceededLimit = 1
+	}
+	if t.Tags == nil {
+		t.Tags = []client.Tag{}
+	}
+	return true
+}
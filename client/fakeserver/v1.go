@@ -0,0 +1,109 @@
+package fakeserver
+
+import (
+	"net/http"
+
+	"github.com/honeycombio/terraform-provider-honeycombio/client"
+)
+
+func (s *Server) registerV1Routes(mux *http.ServeMux) {
+	s.handle(mux, "GET /1/auth", accessAny, s.getAuth)
+
+	s.handle(mux, "GET /1/datasets", accessAny, s.listDatasets)
+	s.handle(mux, "POST /1/datasets", accessCreateDatasets, s.createDataset)
+	s.handle(mux, "GET /1/datasets/{dataset}", accessAny, s.getDataset)
+	s.handle(mux, "PUT /1/datasets/{dataset}", accessCreateDatasets, s.updateDataset)
+	s.handle(mux, "DELETE /1/datasets/{dataset}", accessCreateDatasets, s.deleteDataset)
+
+	s.handle(mux, "GET /1/dataset_definitions/{dataset}", accessColumns, s.getDatasetDefinition)
+	s.handle(mux, "PATCH /1/dataset_definitions/{dataset}", accessColumns, s.updateDatasetDefinition)
+
+	s.handle(mux, "GET /1/columns/{dataset}", accessColumns, s.listColumns)
+	s.handle(mux, "POST /1/columns/{dataset}", accessColumns, s.createColumn)
+	s.handle(mux, "GET /1/columns/{dataset}/{id}", accessColumns, s.getColumn)
+	s.handle(mux, "PUT /1/columns/{dataset}/{id}", accessColumns, s.updateColumn)
+	s.handle(mux, "DELETE /1/columns/{dataset}/{id}", accessColumns, s.deleteColumn)
+
+	s.handle(mux, "GET /1/derived_columns/{dataset}", accessColumns, s.listDerivedColumns)
+	s.handle(mux, "POST /1/derived_columns/{dataset}", accessColumns, s.createDerivedColumn)
+	s.handle(mux, "GET /1/derived_columns/{dataset}/{id}", accessColumns, s.getDerivedColumn)
+	s.handle(mux, "PUT /1/derived_columns/{dataset}/{id}", accessColumns, s.updateDerivedColumn)
+	s.handle(mux, "DELETE /1/derived_columns/{dataset}/{id}", accessColumns, s.deleteDerivedColumn)
+
+	s.handle(mux, "GET /1/triggers/{dataset}", accessTriggers, s.listTriggers)
+	s.handle(mux, "POST /1/triggers/{dataset}", accessTriggers, s.createTrigger)
+	s.handle(mux, "GET /1/triggers/{dataset}/{id}", accessTriggers, s.getTrigger)
+	s.handle(mux, "PUT /1/triggers/{dataset}/{id}", accessTriggers, s.updateTrigger)
+	s.handle(mux, "DELETE /1/triggers/{dataset}/{id}", accessTriggers, s.deleteTrigger)
+
+	s.handle(mux, "GET /1/slos/{dataset}", accessSLOs, s.listSLOs)
+	s.handle(mux, "POST /1/slos/{dataset}", accessSLOs, s.createSLO)
+	s.handle(mux, "GET /1/slos/{dataset}/{id}", accessSLOs, s.getSLO)
+	s.handle(mux, "PUT /1/slos/{dataset}/{id}", accessSLOs, s.updateSLO)
+	s.handle(mux, "DELETE /1/slos/{dataset}/{id}", accessSLOs, s.deleteSLO)
+
+	s.handle(mux, "GET /1/burn_alerts/{dataset}", accessSLOs, s.listBurnAlerts)
+	s.handle(mux, "POST /1/burn_alerts/{dataset}", accessSLOs, s.createBurnAlert)
+	s.handle(mux, "GET /1/burn_alerts/{dataset}/{id}", accessSLOs, s.getBurnAlert)
+	s.handle(mux, "PUT /1/burn_alerts/{dataset}/{id}", accessSLOs, s.updateBurnAlert)
+	s.handle(mux, "DELETE /1/burn_alerts/{dataset}/{id}", accessSLOs, s.deleteBurnAlert)
+
+	s.handle(mux, "GET /1/boards", accessBoards, s.listBoards)
+	s.handle(mux, "POST /1/boards", accessBoards, s.createBoard)
+	s.handle(mux, "GET /1/boards/{id}", accessBoards, s.getBoard)
+	s.handle(mux, "PUT /1/boards/{id}", accessBoards, s.updateBoard)
+	s.handle(mux, "DELETE /1/boards/{id}", accessBoards, s.deleteBoard)
+	s.handle(mux, "GET /1/boards/{board}/views", accessBoards, s.listBoardViews)
+	s.handle(mux, "POST /1/boards/{board}/views", accessBoards, s.createBoardView)
+	s.handle(mux, "GET /1/boards/{board}/views/{id}", accessBoards, s.getBoardView)
+	s.handle(mux, "PUT /1/boards/{board}/views/{id}", accessBoards, s.updateBoardView)
+	s.handle(mux, "DELETE /1/boards/{board}/views/{id}", accessBoards, s.deleteBoardView)
+
+	s.handle(mux, "GET /1/markers/{dataset}", accessMarkers, s.listMarkers)
+	s.handle(mux, "POST /1/markers/{dataset}", accessMarkers, s.createMarker)
+	s.handle(mux, "PUT /1/markers/{dataset}/{id}", accessMarkers, s.updateMarker)
+	s.handle(mux, "DELETE /1/markers/{dataset}/{id}", accessMarkers, s.deleteMarker)
+
+	s.handle(mux, "GET /1/marker_settings/{dataset}", accessMarkers, s.listMarkerSettings)
+	s.handle(mux, "POST /1/marker_settings/{dataset}", accessMarkers, s.createMarkerSetting)
+	s.handle(mux, "PUT /1/marker_settings/{dataset}/{id}", accessMarkers, s.updateMarkerSetting)
+	s.handle(mux, "DELETE /1/marker_settings/{dataset}/{id}", accessMarkers, s.deleteMarkerSetting)
+
+	s.handle(mux, "GET /1/recipients", accessRecipients, s.listRecipients)
+	s.handle(mux, "POST /1/recipients", accessRecipients, s.createRecipient)
+	s.handle(mux, "GET /1/recipients/{id}", accessRecipients, s.getRecipient)
+	s.handle(mux, "PUT /1/recipients/{id}", accessRecipients, s.updateRecipient)
+	s.handle(mux, "DELETE /1/recipients/{id}", accessRecipients, s.deleteRecipient)
+
+	s.handle(mux, "POST /1/queries/{dataset}", accessColumns, s.createQuery)
+	s.handle(mux, "GET /1/queries/{dataset}/{id}", accessColumns, s.getQuery)
+
+	s.handle(mux, "GET /1/query_annotations/{dataset}", accessColumns, s.listQueryAnnotations)
+	s.handle(mux, "POST /1/query_annotations/{dataset}", accessColumns, s.createQueryAnnotation)
+	s.handle(mux, "GET /1/query_annotations/{dataset}/{id}", accessColumns, s.getQueryAnnotation)
+	s.handle(mux, "PUT /1/query_annotations/{dataset}/{id}", accessColumns, s.updateQueryAnnotation)
+	s.handle(mux, "DELETE /1/query_annotations/{dataset}/{id}", accessColumns, s.deleteQueryAnnotation)
+
+	s.handle(mux, "POST /1/query_results/{dataset}", accessQueries, s.createQueryResult)
+	s.handle(mux, "GET /1/query_results/{dataset}/{id}", accessQueries, s.getQueryResult)
+}
+
+func (s *Server) getAuth(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, s.auth)
+}
+
+// datasetScope resolves the dataset path parameter of the request, responding
+// with a 404 if the dataset does not exist.
+//
+// If allowEnvironmentWide is true the environment-wide slug is accepted.
+func (s *Server) datasetScope(w http.ResponseWriter, r *http.Request, allowEnvironmentWide bool) (string, bool) {
+	slug := r.PathValue("dataset")
+	if allowEnvironmentWide && slug == client.EnvironmentWideSlug {
+		return slug, true
+	}
+	if _, ok := s.datasets.get("", slug); !ok {
+		s.notFound(w, "Dataset not found")
+		return "", false
+	}
+	return slug, true
+}
@@ -0,0 +1,298 @@
+package fakeserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/jsonapi"
+)
+
+const (
+	scopeAPIKeysRead       = "api-keys:read"
+	scopeAPIKeysWrite      = "api-keys:write"
+	scopeEnvironmentsRead  = "environments:read"
+	scopeEnvironmentsWrite = "environments:write"
+
+	defaultPageSize = 20
+	maxPageSize     = 100
+)
+
+// The v2 API's JSON:API models.
+//
+// These mirror those of the v2 client, which can't be imported here as the
+// v2 client's own tests make use of the Server.
+type (
+	team struct {
+		ID   string `jsonapi:"primary,teams"`
+		Name string `jsonapi:"attr,name"`
+		Slug string `jsonapi:"attr,slug"`
+	}
+
+	timestamps struct {
+		CreatedAt time.Time `jsonapi:"attr,created,rfc3339,omitempty"`
+		UpdatedAt time.Time `jsonapi:"attr,updated,rfc3339,omitempty"`
+	}
+
+	authMetadata struct {
+		ID         string      `jsonapi:"primary,api-keys"`
+		Name       string      `jsonapi:"attr,name"`
+		KeyType    string      `jsonapi:"attr,key_type"`
+		Disabled   bool        `jsonapi:"attr,disabled"`
+		Scopes     []string    `jsonapi:"attr,scopes"`
+		Timestamps *timestamps `jsonapi:"attr,timestamps"`
+		Team       *team       `jsonapi:"relation,team"`
+	}
+
+	environment struct {
+		ID          string               `jsonapi:"primary,environments"`
+		Name        string               `jsonapi:"attr,name"`
+		Slug        string               `jsonapi:"attr,slug"`
+		Description *string              `jsonapi:"attr,description,omitempty"`
+		Color       *string              `jsonapi:"attr,color,omitempty"`
+		Settings    *environmentSettings `jsonapi:"attr,settings,omitempty"`
+	}
+
+	environmentSettings struct {
+		DeleteProtected *bool `jsonapi:"attr,delete_protected,omitempty"`
+	}
+
+	apiKey struct {
+		ID          string             `jsonapi:"primary,api-keys,omitempty"`
+		Name        *string            `jsonapi:"attr,name,omitempty"`
+		KeyType     string             `jsonapi:"attr,key_type,omitempty"`
+		Disabled    *bool              `jsonapi:"attr,disabled,omitempty"`
+		Secret      string             `jsonapi:"attr,secret,omitempty"`
+		Permissions *apiKeyPermissions `jsonapi:"attr,permissions,omitempty"`
+		Timestamps  *timestamps        `jsonapi:"attr,timestamps,omitempty"`
+		Environment *environment       `jsonapi:"relation,environment"`
+	}
+
+	apiKeyPermissions struct {
+		SendEvents          bool `jsonapi:"attr,send_events,omitempty"`
+		CreateDatasets      bool `jsonapi:"attr,create_datasets,omitempty"`
+		ManageQueries       bool `jsonapi:"attr,manage_columns,omitempty"`
+		RunQueries          bool `jsonapi:"attr,run_queries,omitempty"`
+		ReadServiceMaps     bool `jsonapi:"attr,read_service_maps,omitempty"`
+		ManagePublicBoards  bool `jsonapi:"attr,manage_boards,omitempty"`
+		ManagePrivateBoards bool `jsonapi:"attr,manage_privateBoards,omitempty"`
+		ManageSLOs          bool `jsonapi:"attr,manage_slos,omitempty"`
+		ManageTriggers      bool `jsonapi:"attr,manage_triggers,omitempty"`
+		ManageRecipients    bool `jsonapi:"attr,manage_recipients,omitempty"`
+		ManageMarkers       bool `jsonapi:"attr,manage_markers,omitempty"`
+		VisibleToMembers    bool `jsonapi:"attr,visible_team_members,omitempty"`
+	}
+)
+
+func (s *Server) registerV2Routes(mux *http.ServeMux) {
+	s.handleV2(mux, "GET /2/auth", "", s.getV2Auth)
+
+	s.handleV2(mux, "GET /2/teams/{team}/environments", scopeEnvironmentsRead, s.listEnvironments)
+	s.handleV2(mux, "POST /2/teams/{team}/environments", scopeEnvironmentsWrite, s.createEnvironment)
+	s.handleV2(mux, "GET /2/teams/{team}/environments/{id}", scopeEnvironmentsRead, s.getEnvironment)
+	s.handleV2(mux, "PATCH /2/teams/{team}/environments/{id}", scopeEnvironmentsWrite, s.updateEnvironment)
+	s.handleV2(mux, "DELETE /2/teams/{team}/environments/{id}", scopeEnvironmentsWrite, s.deleteEnvironment)
+
+	s.handleV2(mux, "GET /2/teams/{team}/api-keys", scopeAPIKeysRead, s.listAPIKeys)
+	s.handleV2(mux, "POST /2/teams/{team}/api-keys", scopeAPIKeysWrite, s.createAPIKey)
+	s.handleV2(mux, "GET /2/teams/{team}/api-keys/{id}", scopeAPIKeysRead, s.getAPIKey)
+	s.handleV2(mux, "PATCH /2/teams/{team}/api-keys/{id}", scopeAPIKeysWrite, s.updateAPIKey)
+	s.handleV2(mux, "DELETE /2/teams/{team}/api-keys/{id}", scopeAPIKeysWrite, s.deleteAPIKey)
+}
+
+// handleV2 registers a v2 handler on the mux which is wrapped with
+// authentication, scope and team checks and holds the Server's lock.
+//
+// A write scope implies the corresponding read scope.
+func (s *Server) handleV2(mux *http.ServeMux, pattern, scope string, h http.HandlerFunc) {
+	mux.HandleFunc(pattern, func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.requests[pattern]++
+
+		token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || token != s.apiKeyID+":"+s.apiKeySecret {
+			s.writeJSONAPIErrors(w, http.StatusUnauthorized, "unauthenticated", &jsonapi.ErrorObject{
+				Code:   "unauthenticated/invalid-key",
+				Title:  "invalid API key",
+				Source: &jsonapi.ErrorSource{Header: "Authorization"},
+			})
+			return
+		}
+		if scope != "" && !s.hasScope(scope) {
+			s.writeJSONAPIError(w, http.StatusForbidden, "forbidden", "API key is missing the "+scope+" scope")
+			return
+		}
+		if t := r.PathValue("team"); t != "" && t != s.auth.Team.Slug {
+			s.writeJSONAPIError(w, http.StatusNotFound, "not-found", "Team not found")
+			return
+		}
+		if s.lostResponses[pattern] > 0 {
+			s.lostResponses[pattern]--
+			h(httptest.NewRecorder(), r)
+			s.writeJSONAPIError(w, http.StatusGatewayTimeout, "gateway-timeout", "gateway timeout")
+			return
+		}
+		h(w, r)
+	})
+}
+
+func (s *Server) hasScope(scope string) bool {
+	if slices.Contains(s.scopes, scope) {
+		return true
+	}
+	if resource, ok := strings.CutSuffix(scope, ":read"); ok {
+		return slices.Contains(s.scopes, resource+":write")
+	}
+	return false
+}
+
+func (s *Server) getV2Auth(w http.ResponseWriter, _ *http.Request) {
+	writeJSONAPI(w, http.StatusOK, &authMetadata{
+		ID:       s.apiKeyID,
+		Name:     "Fake Management Key",
+		KeyType:  "management",
+		Disabled: false,
+		Scopes:   s.scopes,
+		Timestamps: &timestamps{
+			CreatedAt: s.createdAt,
+			UpdatedAt: s.createdAt,
+		},
+		Team: &team{
+			ID:   "fake-team-id",
+			Name: s.auth.Team.Name,
+			Slug: s.auth.Team.Slug,
+		},
+	})
+}
+
+// decodeJSONAPI reads the JSON:API request document into v, responding with
+// a 400 if the document could not be parsed.
+func (s *Server) decodeJSONAPI(w http.ResponseWriter, r *http.Request, v any) bool {
+	if err := jsonapi.UnmarshalPayload(r.Body, v); err != nil {
+		s.writeJSONAPIError(w, http.StatusBadRequest, "unparseable", "could not parse request body")
+		return false
+	}
+	return true
+}
+
+// writeJSONAPI writes a JSON:API document for the model, including any
+// related resources.
+func writeJSONAPI(w http.ResponseWriter, status int, model any) {
+	w.Header().Set("Content-Type", jsonapi.MediaType)
+	w.WriteHeader(status)
+	_ = jsonapi.MarshalPayload(w, model)
+}
+
+// writeJSONAPIWithoutIncluded writes a JSON:API document for the model,
+// referencing related resources by type and ID only.
+func writeJSONAPIWithoutIncluded(w http.ResponseWriter, status int, model any) {
+	w.Header().Set("Content-Type", jsonapi.MediaType)
+	w.WriteHeader(status)
+	_ = jsonapi.MarshalPayloadWithoutIncluded(w, model)
+}
+
+// listAttributes maps the names of the attributes a list endpoint can be
+// filtered or sorted by to their value for an item.
+type listAttributes[T any] map[string]func(*T) string
+
+// filterAndSort applies the request's filter[...] and sort parameters to the
+// items, responding with a 400 if the endpoint does not support one.
+//
+// Filters match items whose attribute equals the value exactly. The sort
+// is ascending by the attribute, or descending if it is prefixed by "-".
+func filterAndSort[T any](s *Server, w http.ResponseWriter, r *http.Request, items []*T, attrs listAttributes[T]) ([]*T, bool) {
+	for param, values := range r.URL.Query() {
+		name, ok := strings.CutPrefix(param, "filter[")
+		if !ok {
+			continue
+		}
+		name = strings.TrimSuffix(name, "]")
+		attr, ok := attrs[name]
+		if !ok {
+			s.writeJSONAPIErrors(w, http.StatusBadRequest, "validation-failed", &jsonapi.ErrorObject{
+				Detail: "unsupported filter",
+				Source: &jsonapi.ErrorSource{Parameter: param},
+			})
+			return nil, false
+		}
+		items = slices.DeleteFunc(slices.Clone(items), func(item *T) bool {
+			return attr(item) != values[0]
+		})
+	}
+
+	if sort := r.URL.Query().Get("sort"); sort != "" {
+		name, desc := strings.CutPrefix(sort, "-")
+		attr, ok := attrs[name]
+		if !ok {
+			s.writeJSONAPIErrors(w, http.StatusBadRequest, "validation-failed", &jsonapi.ErrorObject{
+				Detail: "unsupported sort",
+				Source: &jsonapi.ErrorSource{Parameter: "sort"},
+			})
+			return nil, false
+		}
+		items = slices.Clone(items)
+		slices.SortStableFunc(items, func(a, b *T) int {
+			if desc {
+				return strings.Compare(attr(b), attr(a))
+			}
+			return strings.Compare(attr(a), attr(b))
+		})
+	}
+	return items, true
+}
+
+// writeJSONAPIPage writes a page of models as a JSON:API document, with a
+// 'next' link to the following page if there is one.
+//
+// The page is selected by the request's page[size] and page[after] cursor
+// parameters. The cursor is the ID of the last item of the previous page.
+func writeJSONAPIPage[T any](s *Server, w http.ResponseWriter, r *http.Request, items []*T, id func(*T) string) {
+	q := r.URL.Query()
+
+	size := defaultPageSize
+	if v := q.Get("page[size]"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 || n > maxPageSize {
+			s.writeJSONAPIErrors(w, http.StatusBadRequest, "validation-failed", &jsonapi.ErrorObject{
+				Detail: "must be between 1 and " + strconv.Itoa(maxPageSize),
+				Source: &jsonapi.ErrorSource{Parameter: "page[size]"},
+			})
+			return
+		}
+		size = n
+	}
+
+	start := 0
+	if after := q.Get("page[after]"); after != "" {
+		i := slices.IndexFunc(items, func(item *T) bool { return id(item) == after })
+		if i < 0 {
+			s.writeJSONAPIErrors(w, http.StatusBadRequest, "validation-failed", &jsonapi.ErrorObject{
+				Detail: "invalid cursor",
+				Source: &jsonapi.ErrorSource{Parameter: "page[after]"},
+			})
+			return
+		}
+		start = i + 1
+	}
+	end := min(start+size, len(items))
+	page := items[start:end]
+
+	// the models are all known to marshal successfully
+	payload, _ := jsonapi.Marshal(page)
+	if end < len(items) {
+		q.Set("page[size]", strconv.Itoa(size))
+		q.Set("page[after]", id(page[len(page)-1]))
+		payload.(*jsonapi.ManyPayload).Links = &jsonapi.Links{
+			"next": r.URL.Path + "?" + q.Encode(),
+		}
+	}
+
+	w.Header().Set("Content-Type", jsonapi.MediaType)
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(payload)
+}
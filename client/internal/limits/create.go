@@ -0,0 +1,86 @@
+package limits
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/go-retryablehttp"
+)
+
+// statusError is an error describing an HTTP response from the API.
+type statusError interface {
+	error
+	HTTPStatus() int
+}
+
+// CreateOnce creates a resource with a request which would create a
+// duplicate if it was repeated after the API had acted on it.
+//
+// The transport does not retry such requests after a failure which leaves
+// it unknown whether the API acted on them, such as a gateway timeout.
+// Instead, after such a failure, find is used to look for a resource the
+// failed attempts may have created since the first of them started. If one
+// is found it is returned as if the attempt had succeeded, otherwise the
+// create is retried after a backoff, as configured on the client.
+//
+// Nothing is looked up unless an attempt fails.
+func CreateOnce[T any](
+	ctx context.Context,
+	c *retryablehttp.Client,
+	create func(context.Context) (*T, error),
+	find func(ctx context.Context, since time.Time) (*T, error),
+) (*T, error) {
+	started := time.Now()
+
+	var err error
+	for attempt := 0; attempt <= c.RetryMax; attempt++ {
+		if attempt > 0 {
+			wait := c.Backoff(c.RetryWaitMin, c.RetryWaitMax, attempt, nil)
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			case <-time.After(wait):
+			}
+		}
+
+		var created *T
+		created, err = create(ctx)
+		if err == nil || !isAmbiguousFailure(ctx, err) {
+			return created, err
+		}
+
+		found, findErr := find(ctx, started)
+		if findErr != nil {
+			// unable to tell if the attempt succeeded, so don't risk
+			// creating a duplicate
+			return nil, err
+		}
+		if found != nil {
+			return found, nil
+		}
+	}
+	return nil, err
+}
+
+// CreatedSince returns true if a resource created at the time reported by
+// the API was created no earlier than since.
+//
+// The API reports times to the second, so since is truncated to match.
+func CreatedSince(createdAt, since time.Time) bool {
+	return !createdAt.Before(since.Truncate(time.Second))
+}
+
+// isAmbiguousFailure returns true if the error leaves it unknown whether the
+// API acted on the request: a server error or a failure to get a response.
+func isAmbiguousFailure(ctx context.Context, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	var se statusError
+	if errors.As(err, &se) {
+		return se.HTTPStatus() >= http.StatusInternalServerError
+	}
+	return true
+}
@@ -0,0 +1,185 @@
+package limits
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/honeycombio/terraform-provider-honeycombio/client/internal/tracing"
+)
+
+// lowWatermark is the fraction of the rate limit budget below which the
+// Limiter starts spreading the remaining requests over the rest of the
+// window, rather than letting them through as fast as they are made.
+const lowWatermark = 0.2
+
+// Limiter is a token-bucket rate limiter which is shared by all requests made
+// by a client.
+//
+// It enforces an optional fixed requests-per-second ceiling, and also learns
+// the API's rate limit budget from the Ratelimit header of each response so
+// that requests are slowed down before the budget runs out instead of only
+// backing off after a 429.
+//
+// A nil Limiter allows all requests without delay.
+type Limiter struct {
+	mu  sync.Mutex
+	now func() time.Time
+
+	// ceiling is the configured maximum number of requests per second,
+	// or zero if there is no ceiling.
+	ceiling  float64
+	burst    float64
+	tokens   float64
+	refilled time.Time
+
+	// the budget last reported by the API
+	limit     int64
+	remaining int64
+	resetAt   time.Time
+	next      time.Time
+}
+
+// NewLimiter returns a Limiter allowing at most ceiling requests per second.
+// A ceiling of zero or less imposes no fixed limit, leaving the Limiter to
+// only pace requests based on the API's reported budget.
+func NewLimiter(ceiling float64) *Limiter {
+	l := &Limiter{now: time.Now}
+	if ceiling > 0 {
+		l.ceiling = ceiling
+		l.burst = max(1, ceiling)
+		l.tokens = l.burst
+		l.refilled = l.now()
+	}
+	return l
+}
+
+// Wait blocks until a request may be made, or the context is done.
+func (l *Limiter) Wait(ctx context.Context) error {
+	if l == nil {
+		return nil
+	}
+	d := l.reserve()
+	if d <= 0 {
+		return ctx.Err()
+	}
+
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
+
+// reserve claims the next request slot, returning how long the caller must
+// wait before making the request.
+func (l *Limiter) reserve() time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	at := now
+
+	if l.ceiling > 0 {
+		l.tokens = min(l.burst, l.tokens+now.Sub(l.refilled).Seconds()*l.ceiling)
+		l.refilled = now
+		// tokens may go negative: each waiting request has reserved one
+		l.tokens--
+		if l.tokens < 0 {
+			at = now.Add(time.Duration(-l.tokens / l.ceiling * float64(time.Second)))
+		}
+	}
+
+	if l.limit > 0 {
+		if !now.Before(l.resetAt) {
+			// the window has reset, so forget the budget until we learn
+			// the new one
+			l.limit = 0
+		} else if l.remaining <= 0 {
+			at = later(at, l.resetAt)
+		} else if float64(l.remaining) < lowWatermark*float64(l.limit) {
+			slot := later(now, l.next)
+			at = later(at, slot)
+			l.next = slot.Add(l.resetAt.Sub(slot) / time.Duration(l.remaining))
+			l.remaining--
+		} else {
+			l.remaining--
+		}
+	}
+
+	return at.Sub(now)
+}
+
+// Update learns the API's current rate limit budget from the response's
+// Ratelimit header, if present and valid.
+func (l *Limiter) Update(r *http.Response) {
+	if l == nil || r == nil {
+		return
+	}
+	v := r.Header.Get(HeaderRateLimit)
+	if v == "" {
+		return
+	}
+	limit, remaining, reset, err := parseRateLimitHeader(v)
+	if err != nil || limit <= 0 || reset < 0 {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.limit = limit
+	l.remaining = remaining
+	l.resetAt = l.now().Add(time.Duration(reset) * time.Second)
+}
+
+func later(a, b time.Time) time.Time {
+	if b.After(a) {
+		return b
+	}
+	return a
+}
+
+// Transport is an http.RoundTripper which waits on a Limiter before each
+// request and updates it from each response.
+//
+// As it wraps the underlying transport, every attempt made by a retrying
+// client is accounted for.
+type Transport struct {
+	Base    http.RoundTripper
+	Limiter *Limiter
+}
+
+// NewHTTPClient returns a copy of the http.Client with its transport wrapped
+// by a Transport using the Limiter.
+func NewHTTPClient(c *http.Client, l *Limiter) *http.Client {
+	wrapped := *c
+	wrapped.Transport = &Transport{
+		Base:    c.Transport,
+		Limiter: l,
+	}
+	return &wrapped
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	if err := t.Limiter.Wait(req.Context()); err != nil {
+		return nil, err
+	}
+	if wait := time.Since(start); wait >= time.Millisecond {
+		tracing.RecordRateLimitWait(req.Context(), wait)
+	}
+
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	resp, err := base.RoundTrip(req)
+	t.Limiter.Update(resp)
+
+	return resp, err
+}
@@ -0,0 +1,132 @@
+package limits
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestLimiter returns a Limiter with a controllable clock.
+func newTestLimiter(ceiling float64) (*Limiter, *time.Time) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	l := NewLimiter(ceiling)
+	l.now = func() time.Time { return now }
+	l.refilled = now
+	return l, &now
+}
+
+func rateLimitResponse(header string) *http.Response {
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{HeaderRateLimit: []string{header}},
+	}
+}
+
+func TestLimiter_Ceiling(t *testing.T) {
+	t.Parallel()
+
+	l, now := newTestLimiter(2)
+
+	// the burst is allowed through immediately
+	assert.Zero(t, l.reserve())
+	assert.Zero(t, l.reserve())
+	// then requests are spaced out at the ceiling
+	assert.Equal(t, 500*time.Millisecond, l.reserve())
+	assert.Equal(t, time.Second, l.reserve())
+
+	// the bucket refills over time
+	*now = now.Add(5 * time.Second)
+	assert.Zero(t, l.reserve())
+}
+
+func TestLimiter_LearnsFromHeader(t *testing.T) {
+	t.Parallel()
+
+	t.Run("does not delay with plenty of budget", func(t *testing.T) {
+		l, _ := newTestLimiter(0)
+		l.Update(rateLimitResponse("limit=100, remaining=50, reset=10"))
+
+		for range 10 {
+			assert.Zero(t, l.reserve())
+		}
+	})
+
+	t.Run("spreads out the last of the budget", func(t *testing.T) {
+		l, _ := newTestLimiter(0)
+		l.Update(rateLimitResponse("limit=100, remaining=10, reset=10"))
+
+		assert.Zero(t, l.reserve())
+		assert.Equal(t, time.Second, l.reserve())
+		assert.Equal(t, 2*time.Second, l.reserve())
+	})
+
+	t.Run("waits for the reset when the budget is exhausted", func(t *testing.T) {
+		l, now := newTestLimiter(0)
+		l.Update(rateLimitResponse("limit=100, remaining=0, reset=30"))
+
+		assert.Equal(t, 30*time.Second, l.reserve())
+
+		// once the window has passed the budget is forgotten
+		*now = now.Add(31 * time.Second)
+		assert.Zero(t, l.reserve())
+		assert.Zero(t, l.reserve())
+	})
+
+	t.Run("ignores invalid headers", func(t *testing.T) {
+		l, _ := newTestLimiter(0)
+		l.Update(rateLimitResponse("foobar"))
+		l.Update(rateLimitResponse("limit=100, remaining=0, reset=-1"))
+		l.Update(&http.Response{Header: http.Header{}})
+		l.Update(nil)
+
+		assert.Zero(t, l.reserve())
+	})
+}
+
+func TestLimiter_Wait(t *testing.T) {
+	t.Parallel()
+
+	t.Run("a nil Limiter never waits", func(t *testing.T) {
+		var l *Limiter
+		require.NoError(t, l.Wait(context.Background()))
+	})
+
+	t.Run("returns when the context is done", func(t *testing.T) {
+		l := NewLimiter(0)
+		l.Update(rateLimitResponse("limit=100, remaining=0, reset=3600"))
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		require.ErrorIs(t, l.Wait(ctx), context.DeadlineExceeded)
+	})
+}
+
+func TestTransport(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set(HeaderRateLimit, "limit=100, remaining=0, reset=3600")
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(srv.Close)
+
+	l := NewLimiter(0)
+	c := NewHTTPClient(srv.Client(), l)
+
+	resp, err := c.Get(srv.URL)
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	// the limiter has learned the budget is exhausted
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL, nil)
+	require.NoError(t, err)
+	_, err = c.Do(req)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
@@ -29,9 +29,20 @@ const (
 	HeaderRetryAfter = "Retry-After"
 )
 
+// The bounds of the wait before retrying after a server error or a failure
+// to get a response, which is multiplied by the number of attempts made.
+const (
+	serverErrorWaitMin = 500 * time.Millisecond
+	serverErrorWaitMax = 950 * time.Millisecond
+)
+
 // RetryHTTPBackoff is a retryablehttp.Backoff function that will
 // use a linear backoff for all status codes except 429, which will
 // attempt to use the rate limit headers to determine the backoff time
+//
+// The linear backoff waits a random time between serverErrorWaitMin and
+// serverErrorWaitMax times the number of attempts, with the bounds and the
+// wait itself kept between mini and maxi.
 func RetryHTTPBackoff(
 	mini, maxi time.Duration,
 	attemptNum int,
@@ -41,12 +52,9 @@ func RetryHTTPBackoff(
 		return rateLimitBackoff(mini, maxi, r)
 	}
 
-	// if we've not been rate limited, use a linear backoff
-	// but increase the minimum and maximum backoff times
-	// and hand it off to retryablehttp.LinearJitterBackoff
-	mini = 500 * time.Millisecond
-	maxi = 950 * time.Millisecond
-	return retryablehttp.LinearJitterBackoff(mini, maxi, attemptNum, r)
+	lo := min(max(serverErrorWaitMin, mini), maxi)
+	hi := min(max(serverErrorWaitMax, mini), maxi)
+	return min(retryablehttp.LinearJitterBackoff(lo, hi, attemptNum, r), maxi)
 }
 
 // rateLimitBackoff calculates the backoff time for a rate limited request
@@ -85,8 +93,9 @@ func rateLimitBackoff(mini, maxi time.Duration, r *http.Response) time.Duration
 	return mini + jitter
 }
 
-// retryHTTPCheck is a retryablehttp.CheckRetry function that will
-// retry on a 429 or any 5xx status code
+// RetryHTTPCheck is a retryablehttp.CheckRetry function that will retry
+// on a 429, and on a 5xx status code or transport error unless the
+// RetryPolicy of the request, set with WithRetryPolicy, says otherwise.
 func RetryHTTPCheck(
 	ctx context.Context,
 	r *http.Response,
@@ -95,13 +104,17 @@ func RetryHTTPCheck(
 	if ctx.Err() != nil {
 		return false, ctx.Err()
 	}
+	retryFailures := retryPolicyFromContext(ctx) == RetryAnyFailure
 	if err != nil {
-		return true, err
+		return retryFailures, err
 	}
 	if r != nil {
-		if r.StatusCode == http.StatusTooManyRequests || r.StatusCode >= 500 {
+		if r.StatusCode == http.StatusTooManyRequests {
 			return true, nil
 		}
+		if r.StatusCode >= 500 {
+			return retryFailures, nil
+		}
 	}
 	return false, nil
 }
@@ -124,6 +124,36 @@ func TestClient_rateLimitBackoff(t *testing.T) {
 	})
 }
 
+func TestRetryHTTPBackoff(t *testing.T) {
+	t.Parallel()
+
+	resp := &http.Response{StatusCode: http.StatusBadGateway}
+
+	t.Run("defaults to a linear backoff with jitter", func(t *testing.T) {
+		mini, maxi := 200*time.Millisecond, time.Minute
+
+		// the first retry is attempt 0
+		for attempt := range 3 {
+			n := time.Duration(attempt + 1)
+			assert.GreaterOrEqual(t, RetryHTTPBackoff(mini, maxi, attempt, resp), n*serverErrorWaitMin)
+			assert.LessOrEqual(t, RetryHTTPBackoff(mini, maxi, attempt, resp), n*serverErrorWaitMax)
+		}
+
+		waits := make(map[time.Duration]bool)
+		for range 10 {
+			waits[RetryHTTPBackoff(mini, maxi, 0, resp)] = true
+		}
+		assert.Greater(t, len(waits), 1, "expected the wait to be jittered")
+	})
+
+	t.Run("is kept within the configured bounds", func(t *testing.T) {
+		assert.Equal(t, 2*time.Second, RetryHTTPBackoff(2*time.Second, 10*time.Second, 0, resp))
+		assert.Equal(t, 300*time.Millisecond, RetryHTTPBackoff(100*time.Millisecond, 300*time.Millisecond, 5, resp))
+		assert.LessOrEqual(t, RetryHTTPBackoff(100*time.Millisecond, time.Second, 1, nil), time.Second,
+			"expected transport errors to back off the same")
+	})
+}
+
 func TestClient_parseRateLimitHeader(t *testing.T) {
 	t.Parallel()
 
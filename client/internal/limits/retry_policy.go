@@ -0,0 +1,70 @@
+package limits
+
+import (
+	"context"
+	"net/http"
+	"strings"
+)
+
+// RetryPolicy describes which failed attempts of a request may be retried
+// without risking the API acting on the request more than once.
+type RetryPolicy int
+
+const (
+	// RetryAnyFailure retries transport errors, 429s and 5xxs. It is for
+	// requests which have the same effect however many times they are made.
+	RetryAnyFailure RetryPolicy = iota
+	// RetryRejected only retries 429s, which the API rejects without acting
+	// on. It is for requests which may create a duplicate if repeated after
+	// the API has acted on them, but failed to say so, such as after a
+	// gateway timeout.
+	RetryRejected
+)
+
+// idempotentCreates are the collections of the API for which repeating a
+// create is harmless, as duplicates are indistinguishable.
+//
+// Creates in any other collection may leave a duplicate, or fail with a
+// conflict, if repeated after the API has acted on them.
+var idempotentCreates = map[string]bool{
+	"queries":       true,
+	"query_results": true,
+}
+
+// RetryPolicyFor returns the RetryPolicy for a request to the API path
+// with the method.
+func RetryPolicyFor(method, path string) RetryPolicy {
+	if method != http.MethodPost {
+		return RetryAnyFailure
+	}
+
+	// v1 paths name the collection directly after the version, while v2
+	// paths nest it under the team: /2/teams/{team}/{collection}
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	var collection string
+	switch {
+	case len(segments) >= 4 && segments[0] == "2" && segments[1] == "teams":
+		collection = segments[3]
+	case len(segments) >= 2:
+		collection = segments[1]
+	}
+	if idempotentCreates[collection] {
+		return RetryAnyFailure
+	}
+	return RetryRejected
+}
+
+type retryPolicyKey struct{}
+
+// WithRetryPolicy returns a copy of the context with the RetryPolicy of the
+// request made with it, for RetryHTTPCheck.
+func WithRetryPolicy(ctx context.Context, p RetryPolicy) context.Context {
+	return context.WithValue(ctx, retryPolicyKey{}, p)
+}
+
+func retryPolicyFromContext(ctx context.Context) RetryPolicy {
+	if p, ok := ctx.Value(retryPolicyKey{}).(RetryPolicy); ok {
+		return p
+	}
+	return RetryAnyFailure
+}
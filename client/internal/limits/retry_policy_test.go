@@ -0,0 +1,85 @@
+package limits
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetryPolicyFor(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		method string
+		path   string
+		policy RetryPolicy
+	}{
+		{http.MethodGet, "/1/triggers/my-dataset", RetryAnyFailure},
+		{http.MethodPut, "/1/triggers/my-dataset/abc123", RetryAnyFailure},
+		{http.MethodDelete, "/1/markers/my-dataset/abc123", RetryAnyFailure},
+		{http.MethodPost, "/1/triggers/my-dataset", RetryRejected},
+		{http.MethodPost, "/1/markers/my-dataset", RetryRejected},
+		{http.MethodPost, "/1/columns/my-dataset", RetryRejected},
+		{http.MethodPost, "/1/derived_columns/my-dataset", RetryRejected},
+		{http.MethodPost, "/1/boards", RetryRejected},
+		{http.MethodPost, "/1/queries/my-dataset", RetryAnyFailure},
+		{http.MethodPost, "/1/query_results/my-dataset", RetryAnyFailure},
+		{http.MethodPost, "/1/datasets", RetryRejected},
+		{http.MethodPost, "/2/teams/my-team/api-keys", RetryRejected},
+		{http.MethodPost, "/2/teams/my-team/environments", RetryRejected},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.method+" "+tc.path, func(t *testing.T) {
+			assert.Equal(t, tc.policy, RetryPolicyFor(tc.method, tc.path))
+		})
+	}
+}
+
+func TestRetryHTTPCheck(t *testing.T) {
+	t.Parallel()
+
+	transportErr := errors.New("connection reset")
+	testCases := []struct {
+		name   string
+		policy RetryPolicy
+		status int
+		err    error
+		retry  bool
+	}{
+		{"success", RetryAnyFailure, http.StatusOK, nil, false},
+		{"client error", RetryAnyFailure, http.StatusBadRequest, nil, false},
+		{"rate limited", RetryAnyFailure, http.StatusTooManyRequests, nil, true},
+		{"server error", RetryAnyFailure, http.StatusBadGateway, nil, true},
+		{"transport error", RetryAnyFailure, 0, transportErr, true},
+		{"rejected rate limited", RetryRejected, http.StatusTooManyRequests, nil, true},
+		{"rejected server error", RetryRejected, http.StatusGatewayTimeout, nil, false},
+		{"rejected transport error", RetryRejected, 0, transportErr, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := WithRetryPolicy(context.Background(), tc.policy)
+			var resp *http.Response
+			if tc.status != 0 {
+				resp = &http.Response{StatusCode: tc.status}
+			}
+
+			retry, err := RetryHTTPCheck(ctx, resp, tc.err)
+			assert.Equal(t, tc.retry, retry)
+			assert.Equal(t, tc.err, err)
+		})
+	}
+
+	t.Run("cancelled context", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		retry, err := RetryHTTPCheck(ctx, &http.Response{StatusCode: http.StatusBadGateway}, nil)
+		assert.False(t, retry)
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+}
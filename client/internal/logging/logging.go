@@ -0,0 +1,205 @@
+// Package logging provides structured logging of the requests made to the
+// Honeycomb API and their responses, with sensitive values redacted.
+package logging
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"sync/atomic"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	// Mask replaces sensitive values in logged headers and bodies.
+	Mask = "***"
+
+	// maxBodySize is the largest request or response body which will be
+	// logged, beyond which the body is truncated.
+	maxBodySize = 64 << 10
+
+	fieldOperationID = "honeycomb_operation_id"
+	fieldAttempt     = "honeycomb_attempt"
+	fieldAttemptID   = "honeycomb_attempt_id"
+)
+
+// sensitiveHeaders are the request headers which carry credentials.
+var sensitiveHeaders = []string{
+	"Authorization",
+	"X-Honeycomb-Team",
+}
+
+// sensitiveKeys are the keys of JSON object members whose values are
+// credentials: webhook secrets, PagerDuty integration keys and API key
+// secrets.
+var sensitiveKeys = map[string]struct{}{
+	"secret":                    {},
+	"webhook_secret":            {},
+	"pagerduty_integration_key": {},
+}
+
+type operationKey struct{}
+
+// operation is a single logical request to the API, which may be made in
+// several attempts as it is retried.
+type operation struct {
+	id       string
+	attempts atomic.Int64
+}
+
+// WithOperation returns a copy of the context marking the start of a new
+// logical operation, giving it a correlation ID which is added to every
+// log line about the operation.
+func WithOperation(ctx context.Context) context.Context {
+	op := &operation{id: newID()}
+	ctx = context.WithValue(ctx, operationKey{}, op)
+	return tflog.SetField(ctx, fieldOperationID, op.id)
+}
+
+// Transport is an http.RoundTripper which logs each request made through it,
+// and the response to it, at debug level via tflog.
+//
+// As it wraps the underlying transport, every attempt made by a retrying
+// client is logged with its own attempt number and correlation ID.
+type Transport struct {
+	Base http.RoundTripper
+}
+
+// NewHTTPClient returns a copy of the http.Client with its transport wrapped
+// by a Transport.
+func NewHTTPClient(c *http.Client) *http.Client {
+	wrapped := *c
+	wrapped.Transport = &Transport{Base: c.Transport}
+	return &wrapped
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	op, ok := ctx.Value(operationKey{}).(*operation)
+	if !ok {
+		ctx = WithOperation(ctx)
+		op = ctx.Value(operationKey{}).(*operation)
+	}
+	attempt := op.attempts.Add(1)
+	ctx = tflog.SetField(ctx, fieldAttempt, attempt)
+	ctx = tflog.SetField(ctx, fieldAttemptID, op.id+"-"+strconv.FormatInt(attempt, 10))
+
+	fields := map[string]any{
+		"http_method":          req.Method,
+		"http_url":             req.URL.String(),
+		"http_request_headers": RedactHeaders(req.Header),
+	}
+	if req.Body != nil && req.Body != http.NoBody {
+		body, err := io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		// RoundTrippers must not modify the request they are given
+		req = req.Clone(req.Context())
+		req.Body = io.NopCloser(bytes.NewReader(body))
+		fields["http_request_body"] = RedactBody(body)
+	}
+	tflog.Debug(ctx, "Sending Honeycomb API request", fields)
+
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	resp, err := base.RoundTrip(req)
+	if err != nil {
+		tflog.Debug(ctx, "Honeycomb API request failed", map[string]any{
+			"http_method": req.Method,
+			"http_url":    req.URL.String(),
+			"error":       err.Error(),
+		})
+		return resp, err
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	tflog.Debug(ctx, "Received Honeycomb API response", map[string]any{
+		"http_method":           req.Method,
+		"http_url":              req.URL.String(),
+		"http_status_code":      resp.StatusCode,
+		"http_response_headers": RedactHeaders(resp.Header),
+		"http_response_body":    RedactBody(body),
+	})
+
+	return resp, nil
+}
+
+// RedactHeaders returns the headers as a map suitable for logging, with the
+// values of those carrying credentials masked.
+func RedactHeaders(h http.Header) map[string]string {
+	redacted := make(map[string]string, len(h))
+	for k, v := range h {
+		if len(v) > 0 {
+			redacted[k] = v[0]
+		}
+	}
+	for _, k := range sensitiveHeaders {
+		if _, ok := redacted[k]; ok {
+			redacted[k] = Mask
+		}
+	}
+	return redacted
+}
+
+// RedactBody returns the body as a string suitable for logging.
+//
+// JSON bodies have the values of any object members holding credentials
+// masked, at any depth. Other bodies are logged as they are.
+func RedactBody(body []byte) string {
+	var v any
+	if err := json.Unmarshal(body, &v); err == nil {
+		buf := bytes.NewBuffer(nil)
+		enc := json.NewEncoder(buf)
+		// bodies such as webhook templates are full of HTML-significant characters
+		enc.SetEscapeHTML(false)
+		if err := enc.Encode(redactValue(v)); err == nil {
+			body = bytes.TrimSuffix(buf.Bytes(), []byte("\n"))
+		}
+	}
+	if len(body) > maxBodySize {
+		return string(body[:maxBodySize]) + "... (truncated)"
+	}
+	return string(body)
+}
+
+func redactValue(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		for k, val := range v {
+			if _, ok := sensitiveKeys[k]; ok {
+				if s, ok := val.(string); !ok || s != "" {
+					v[k] = Mask
+				}
+				continue
+			}
+			v[k] = redactValue(val)
+		}
+	case []any:
+		for i, val := range v {
+			v[i] = redactValue(val)
+		}
+	}
+	return v
+}
+
+func newID() string {
+	b := make([]byte, 8)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,143 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedactHeaders(t *testing.T) {
+	t.Parallel()
+
+	h := http.Header{
+		"Authorization":    {"Bearer hcamk_123:secret"},
+		"X-Honeycomb-Team": {"configkey"},
+		"Content-Type":     {"application/json"},
+	}
+
+	assert.Equal(t, map[string]string{
+		"Authorization":    Mask,
+		"X-Honeycomb-Team": Mask,
+		"Content-Type":     "application/json",
+	}, RedactHeaders(h))
+	assert.Equal(t, "Bearer hcamk_123:secret", h.Get("Authorization"), "headers should not be modified")
+}
+
+func TestRedactBody(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "webhook recipient",
+			input:    `{"type":"webhook","details":{"webhook_name":"test","webhook_secret":"s3cr3t","webhook_payloads":{"payload_templates":{"trigger":{"body":"<b>{{.Name}}</b>"}}}}}`,
+			expected: `{"details":{"webhook_name":"test","webhook_payloads":{"payload_templates":{"trigger":{"body":"<b>{{.Name}}</b>"}}},"webhook_secret":"***"},"type":"webhook"}`,
+		},
+		{
+			name:     "pagerduty recipients in a list",
+			input:    `[{"details":{"pagerduty_integration_key":"abc123"}},{"details":{"pagerduty_integration_key":"def456"}}]`,
+			expected: `[{"details":{"pagerduty_integration_key":"***"}},{"details":{"pagerduty_integration_key":"***"}}]`,
+		},
+		{
+			name:     "api key secret",
+			input:    `{"data":{"type":"api-keys","attributes":{"name":"test","secret":"abcdef"}}}`,
+			expected: `{"data":{"attributes":{"name":"test","secret":"***"},"type":"api-keys"}}`,
+		},
+		{
+			name:     "empty secrets are left as is",
+			input:    `{"webhook_secret":""}`,
+			expected: `{"webhook_secret":""}`,
+		},
+		{
+			name:     "non-JSON body",
+			input:    "not json",
+			expected: "not json",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, RedactBody([]byte(tc.input)))
+		})
+	}
+
+	t.Run("truncates large bodies", func(t *testing.T) {
+		body := RedactBody([]byte(strings.Repeat("a", maxBodySize+1)))
+		assert.True(t, strings.HasSuffix(body, "... (truncated)"))
+		assert.Len(t, body, maxBodySize+len("... (truncated)"))
+	})
+}
+
+func TestTransport(t *testing.T) {
+	t.Parallel()
+
+	var attempts int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		assert.JSONEq(t, `{"secret":"abcdef"}`, string(body), "the request body should be passed on intact")
+
+		attempts++
+		if attempts == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":"1","secret":"abcdef"}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	var logs bytes.Buffer
+	ctx := WithOperation(tflogtest.RootLogger(context.Background(), &logs))
+	c := NewHTTPClient(srv.Client())
+
+	// make two attempts of the same operation, as a retrying client would
+	for range 2 {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, srv.URL, strings.NewReader(`{"secret":"abcdef"}`))
+		require.NoError(t, err)
+		req.Header.Set("X-Honeycomb-Team", "configkey")
+
+		resp, err := c.Do(req)
+		require.NoError(t, err)
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		resp.Body.Close()
+		if resp.StatusCode == http.StatusOK {
+			assert.JSONEq(t, `{"id":"1","secret":"abcdef"}`, string(body), "the response body should be passed on intact")
+		}
+	}
+
+	output := logs.String()
+	assert.NotContains(t, output, "abcdef")
+	assert.NotContains(t, output, "configkey")
+
+	entries, err := tflogtest.MultilineJSONDecode(&logs)
+	require.NoError(t, err)
+	require.Len(t, entries, 4)
+
+	opID := entries[0][fieldOperationID]
+	assert.NotEmpty(t, opID)
+	for i, e := range entries {
+		attempt := i/2 + 1
+		assert.Equal(t, opID, e[fieldOperationID], "all attempts should share the operation ID")
+		assert.EqualValues(t, attempt, e[fieldAttempt])
+		assert.Equal(t, opID.(string)+"-"+strconv.Itoa(attempt), e[fieldAttemptID])
+	}
+
+	assert.Equal(t, "Sending Honeycomb API request", entries[2]["@message"])
+	assert.Equal(t, `{"secret":"***"}`, entries[2]["http_request_body"])
+	assert.Equal(t, "Received Honeycomb API response", entries[3]["@message"])
+	assert.EqualValues(t, http.StatusOK, entries[3]["http_status_code"])
+	assert.Equal(t, `{"id":"1","secret":"***"}`, entries[3]["http_response_body"])
+}
@@ -0,0 +1,206 @@
+// Package tracing instruments the requests made to the Honeycomb API with
+// OpenTelemetry spans.
+//
+// Spans are created with the global TracerProvider, so they are only
+// recorded once the application has installed one.
+package tracing
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// TracerName is the name of the tracer creating the spans.
+	TracerName = "github.com/honeycombio/terraform-provider-honeycombio/client"
+
+	AttrResourceType   = attribute.Key("honeycomb.resource_type")
+	AttrAttempt        = attribute.Key("honeycomb.attempt")
+	AttrRetryBackoffMs = attribute.Key("honeycomb.retry_backoff_ms")
+	AttrRateLimitMs    = attribute.Key("honeycomb.rate_limit_wait_ms")
+
+	attrMethod      = attribute.Key("http.request.method")
+	attrRoute       = attribute.Key("url.template")
+	attrStatusCode  = attribute.Key("http.response.status_code")
+	attrResendCount = attribute.Key("http.request.resend_count")
+	attrServer      = attribute.Key("server.address")
+	attrErrorType   = attribute.Key("error.type")
+)
+
+type operationKey struct{}
+
+// operation is a single logical request to the API, which may be made in
+// several attempts as it is retried.
+type operation struct {
+	mu       sync.Mutex
+	attempts int
+	// lastEnded is when the previous attempt completed, from which the
+	// time spent backing off before the next is measured
+	lastEnded time.Time
+}
+
+// WithOperation returns a copy of the context marking the start of a new
+// logical operation, so that each of its attempts is numbered.
+func WithOperation(ctx context.Context) context.Context {
+	return context.WithValue(ctx, operationKey{}, &operation{})
+}
+
+// Transport is an http.RoundTripper which records a client span for each
+// request made through it.
+//
+// As it wraps the underlying transport, every attempt made by a retrying
+// client is recorded as its own span, carrying its attempt number and how
+// long was spent backing off before it was made.
+type Transport struct {
+	Base http.RoundTripper
+}
+
+// NewHTTPClient returns a copy of the http.Client with its transport wrapped
+// by a Transport.
+func NewHTTPClient(c *http.Client) *http.Client {
+	wrapped := *c
+	wrapped.Transport = &Transport{Base: c.Transport}
+	return &wrapped
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	op, ok := ctx.Value(operationKey{}).(*operation)
+	if !ok {
+		op = &operation{}
+	}
+	op.mu.Lock()
+	op.attempts++
+	attempt := op.attempts
+	var backoff time.Duration
+	if !op.lastEnded.IsZero() {
+		backoff = time.Since(op.lastEnded)
+	}
+	op.mu.Unlock()
+
+	route, resourceType := RouteTemplate(req.URL.Path)
+	attrs := []attribute.KeyValue{
+		attrMethod.String(req.Method),
+		attrRoute.String(route),
+		attrServer.String(req.URL.Hostname()),
+		AttrResourceType.String(resourceType),
+		AttrAttempt.Int(attempt),
+	}
+	if attempt > 1 {
+		attrs = append(attrs,
+			attrResendCount.Int(attempt-1),
+			AttrRetryBackoffMs.Int64(backoff.Milliseconds()),
+		)
+	}
+
+	ctx, span := otel.Tracer(TracerName).Start(ctx, req.Method+" "+route,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attrs...),
+	)
+	defer func() {
+		span.End()
+		op.mu.Lock()
+		op.lastEnded = time.Now()
+		op.mu.Unlock()
+	}()
+
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	resp, err := base.RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		span.SetAttributes(attrErrorType.String("transport"))
+		return resp, err
+	}
+
+	span.SetAttributes(attrStatusCode.Int(resp.StatusCode))
+	if resp.StatusCode >= http.StatusBadRequest {
+		span.SetStatus(codes.Error, http.StatusText(resp.StatusCode))
+		span.SetAttributes(attrErrorType.String(http.StatusText(resp.StatusCode)))
+	}
+	return resp, nil
+}
+
+// RecordRateLimitWait records on the span of the request how long it was
+// held back to stay within the rate limit.
+func RecordRateLimitWait(ctx context.Context, wait time.Duration) {
+	trace.SpanFromContext(ctx).SetAttributes(AttrRateLimitMs.Int64(wait.Milliseconds()))
+}
+
+// collections are the path segments of the API naming a type of resource,
+// rather than identifying one.
+var collections = map[string]bool{
+	"api-keys":            true,
+	"auth":                true,
+	"boards":              true,
+	"burn_alerts":         true,
+	"columns":             true,
+	"dataset_definitions": true,
+	"datasets":            true,
+	"derived_columns":     true,
+	"environments":        true,
+	"marker_settings":     true,
+	"markers":             true,
+	"queries":             true,
+	"query_annotations":   true,
+	"query_results":       true,
+	"recipients":          true,
+	"slos":                true,
+	"teams":               true,
+	"triggers":            true,
+	"views":               true,
+}
+
+// datasetScoped are the collections whose resources belong to a dataset,
+// which is named by the first segment following them.
+var datasetScoped = map[string]bool{
+	"burn_alerts":         true,
+	"columns":             true,
+	"dataset_definitions": true,
+	"derived_columns":     true,
+	"marker_settings":     true,
+	"markers":             true,
+	"queries":             true,
+	"query_annotations":   true,
+	"query_results":       true,
+	"slos":                true,
+	"triggers":            true,
+}
+
+// RouteTemplate returns the path with the segments identifying resources
+// replaced by placeholders, such as "/1/boards/{id}/views/{id}", and the
+// type of resource the path addresses, such as "views".
+func RouteTemplate(path string) (string, string) {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	var resourceType, collection string
+	for i, s := range segments {
+		switch {
+		case i == 0:
+			// the API version
+		case collections[s]:
+			collection = s
+			if s != "teams" {
+				resourceType = s
+			}
+		case collection == "teams":
+			segments[i] = "{team}"
+		case datasetScoped[collection] && segments[i-1] == collection:
+			segments[i] = "{dataset}"
+		default:
+			segments[i] = "{id}"
+		}
+	}
+	return "/" + strings.Join(segments, "/"), resourceType
+}
@@ -0,0 +1,102 @@
+package tracing
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestRouteTemplate(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		path         string
+		route        string
+		resourceType string
+	}{
+		{"/1/auth", "/1/auth", "auth"},
+		{"/1/boards", "/1/boards", "boards"},
+		{"/1/boards/abc123/views/def456", "/1/boards/{id}/views/{id}", "views"},
+		{"/1/triggers/my-dataset/abc123", "/1/triggers/{dataset}/{id}", "triggers"},
+		{"/1/triggers/my-dataset", "/1/triggers/{dataset}", "triggers"},
+		{"/1/recipients/abc123", "/1/recipients/{id}", "recipients"},
+		{"/1/datasets/my-dataset", "/1/datasets/{id}", "datasets"},
+		{"/2/teams/my-team/environments/hcaen_123", "/2/teams/{team}/environments/{id}", "environments"},
+		{"/2/teams/my-team/api-keys", "/2/teams/{team}/api-keys", "api-keys"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.path, func(t *testing.T) {
+			route, resourceType := RouteTemplate(tc.path)
+			assert.Equal(t, tc.route, route)
+			assert.Equal(t, tc.resourceType, resourceType)
+		})
+	}
+}
+
+func TestTransport(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	before := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter)))
+	t.Cleanup(func() { otel.SetTracerProvider(before) })
+
+	var attempts int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		attempts++
+		if attempts == 1 {
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(srv.Close)
+
+	c := NewHTTPClient(srv.Client())
+	ctx := WithOperation(context.Background())
+
+	// make two attempts of the same operation, as a retrying client would
+	for i := range 2 {
+		if i > 0 {
+			time.Sleep(10 * time.Millisecond)
+		}
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL+"/1/boards/abc123", nil)
+		require.NoError(t, err)
+		resp, err := c.Do(req)
+		require.NoError(t, err)
+		resp.Body.Close()
+	}
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 2)
+
+	attrs := func(s tracetest.SpanStub) map[attribute.Key]attribute.Value {
+		m := make(map[attribute.Key]attribute.Value)
+		for _, kv := range s.Attributes {
+			m[kv.Key] = kv.Value
+		}
+		return m
+	}
+
+	first, second := attrs(spans[0]), attrs(spans[1])
+	assert.Equal(t, "GET /1/boards/{id}", spans[0].Name)
+	assert.Equal(t, codes.Error, spans[0].Status.Code)
+	assert.Equal(t, int64(1), first[AttrAttempt].AsInt64())
+	assert.Equal(t, int64(http.StatusTooManyRequests), first[attrStatusCode].AsInt64())
+	assert.NotContains(t, first, AttrRetryBackoffMs)
+
+	assert.Equal(t, codes.Unset, spans[1].Status.Code)
+	assert.Equal(t, int64(2), second[AttrAttempt].AsInt64())
+	assert.Equal(t, int64(1), second[attrResendCount].AsInt64())
+	assert.GreaterOrEqual(t, second[AttrRetryBackoffMs].AsInt64(), int64(10))
+	assert.Equal(t, "boards", second[AttrResourceType].AsString())
+}
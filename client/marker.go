@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"net/http"
 	"time"
+
+	"github.com/honeycombio/terraform-provider-honeycombio/client/internal/limits"
 )
 
 // Markers describes all the marker-related methods that the Honeycomb API
@@ -23,6 +25,10 @@ type Markers interface {
 
 	// Create a new marker in this dataset. When creating a marker ID may not
 	// be set.
+	//
+	// If an attempt fails without it being known whether the API created the
+	// marker, a matching marker created since the attempt is looked for and
+	// returned before the create is retried.
 	Create(ctx context.Context, dataset string, m *Marker) (*Marker, error)
 
 	// Update an existing marker.
@@ -95,9 +101,42 @@ func (s *markers) Get(ctx context.Context, dataset string, id string) (*Marker,
 }
 
 func (s *markers) Create(ctx context.Context, dataset string, data *Marker) (*Marker, error) {
-	var m Marker
-	err := s.client.Do(ctx, "POST", fmt.Sprintf("/1/markers/%s", urlEncodeDataset(dataset)), data, &m)
-	return &m, err
+	return limits.CreateOnce(ctx, s.client.httpClient,
+		func(ctx context.Context) (*Marker, error) {
+			var m Marker
+			err := s.client.Do(ctx, "POST", fmt.Sprintf("/1/markers/%s", urlEncodeDataset(dataset)), data, &m)
+			return &m, err
+		},
+		func(ctx context.Context, since time.Time) (*Marker, error) {
+			existing, err := s.List(ctx, dataset)
+			if err != nil {
+				return nil, err
+			}
+			for _, m := range existing {
+				if m.matchesCreated(data, since) {
+					return &m, nil
+				}
+			}
+			return nil, nil
+		},
+	)
+}
+
+// matchesCreated returns true if the marker looks to have been created from
+// data by an attempt made at or after since.
+func (m *Marker) matchesCreated(data *Marker, since time.Time) bool {
+	if m.Message != data.Message || m.Type != data.Type || m.URL != data.URL {
+		return false
+	}
+	if data.StartTime != 0 && m.StartTime != data.StartTime {
+		return false
+	}
+	if data.EndTime != 0 && m.EndTime != data.EndTime {
+		return false
+	}
+	// markers are commonly identical but for when they were placed, so
+	// only consider those created since the attempt
+	return m.CreatedAt != nil && limits.CreatedSince(*m.CreatedAt, since)
 }
 
 func (s *markers) Update(ctx context.Context, dataset string, data *Marker) (*Marker, error) {
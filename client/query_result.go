@@ -2,6 +2,8 @@ package client
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"time"
 )
@@ -11,8 +13,13 @@ import (
 //
 // API docs: https://docs.honeycomb.io/api/query-results/
 type QueryResults interface {
-	// Get the query results by ID.
-	Get(ctx context.Context, dataset string, q *QueryResult) error
+	// Get the query results by ID, polling until the query result is complete.
+	//
+	// Polling backs off exponentially, and can be tuned with WithPollInterval.
+	// If the query result is not complete once WithMaxWait's maximum wait or
+	// the context's deadline has passed, a *QueryStillRunningError is
+	// returned and q holds the most recently polled, partial, result.
+	Get(ctx context.Context, dataset string, q *QueryResult, opts ...QueryResultPollOption) error
 
 	// Create a new query result with a given query specification.
 	Create(ctx context.Context, dataset string, data *QueryResultRequest) (*QueryResult, error)
@@ -62,31 +69,309 @@ type QueryResultData struct {
 	} `json:"results"`
 }
 
-const QueryResultPollInterval time.Duration = 200 * time.Millisecond
+// CompareKeySuffix is appended to the key of a calculation or formula to
+// name its value from the comparison time period, in the results of a query
+// with a CompareTimeOffsetSeconds.
+const CompareKeySuffix = "_compare"
+
+// ResultKey returns the key the calculation's value is returned under in
+// query results: its name if it has one, otherwise its operator applied to
+// its column, such as "P99(duration_ms)".
+func (c CalculationSpec) ResultKey() string {
+	if c.Name != nil && *c.Name != "" {
+		return *c.Name
+	}
+	if c.Column == nil || *c.Column == "" {
+		return string(c.Op)
+	}
+	return string(c.Op) + "(" + *c.Column + ")"
+}
+
+// TypedQueryResultData is QueryResultData decoded according to the
+// QuerySpec of the query which produced it.
+type TypedQueryResultData struct {
+	// Series is the time series of the query, one row per group per
+	// time bucket.
+	Series []QueryResultRow
+	// Results is the summary table of the query, one row per group.
+	Results []QueryResultRow
+}
+
+// QueryResultRow is a single decoded row of a query result.
+type QueryResultRow struct {
+	// Time is the start of the row's time bucket, and is zero for rows of
+	// the summary table.
+	Time time.Time
+	// Group holds the row's value of each of the query's breakdowns, keyed by
+	// column. Values are strings, numbers or booleans as returned by the API,
+	// or nil if the events of the group had no value for the column.
+	Group map[string]any
+	// Values holds the row's value of each calculation and formula, keyed by
+	// their ResultKey and formula name respectively.
+	//
+	// Calculations and formulas with no value for the row are omitted.
+	Values map[string]float64
+	// Heatmaps holds the row's buckets of each HEATMAP calculation, keyed by
+	// ResultKey.
+	Heatmaps map[string][]HeatmapBucket
+	// Compare holds the row's value of each calculation and formula over the
+	// comparison time period, keyed as Values is. It is only set for queries
+	// with a CompareTimeOffsetSeconds.
+	Compare map[string]float64
+}
+
+// HeatmapBucket is a single bucket of a HEATMAP calculation.
+type HeatmapBucket struct {
+	// Min is the inclusive lower bound of the bucket.
+	Min float64 `json:"min"`
+	// Max is the exclusive upper bound of the bucket.
+	Max float64 `json:"max"`
+	// Count is the number of events in the bucket.
+	Count int64 `json:"count"`
+}
+
+// UnmarshalJSON decodes a bucket either from an object, or from a bare
+// number holding only the bucket's count.
+func (b *HeatmapBucket) UnmarshalJSON(data []byte) error {
+	var count int64
+	if err := json.Unmarshal(data, &count); err == nil {
+		*b = HeatmapBucket{Count: count}
+		return nil
+	}
+	type bucket HeatmapBucket
+	return json.Unmarshal(data, (*bucket)(b))
+}
+
+// Decode returns the data typed according to the QuerySpec which produced it.
+//
+// An error is returned if a value is not of the type its calculation or
+// formula calls for.
+func (d QueryResultData) Decode(spec *QuerySpec) (*TypedQueryResultData, error) {
+	if spec == nil {
+		spec = &QuerySpec{}
+	}
+	calcs := spec.Calculations
+	if len(calcs) == 0 {
+		// COUNT is applied if no calculations are provided
+		calcs = []CalculationSpec{{Op: CalculationOpCount}}
+	}
+	compare := spec.CompareTimeOffsetSeconds != nil
+
+	decodeRow := func(data map[string]any) (QueryResultRow, error) {
+		row := QueryResultRow{
+			Group:  make(map[string]any, len(spec.Breakdowns)),
+			Values: make(map[string]float64),
+		}
+		for _, b := range spec.Breakdowns {
+			if v, ok := data[b]; ok {
+				row.Group[b] = v
+			}
+		}
+
+		// number returns the numeric value under the key, if there is one
+		number := func(key string) (float64, bool, error) {
+			v, ok := data[key]
+			if !ok || v == nil {
+				return 0, false, nil
+			}
+			f, ok := v.(float64)
+			if !ok {
+				return 0, false, fmt.Errorf("result %q: expected a number but got %T", key, v)
+			}
+			return f, true, nil
+		}
+
+		keys := make([]string, 0, len(calcs)+len(spec.Formulas))
+		for _, c := range calcs {
+			key := c.ResultKey()
+			if c.Op == CalculationOpHeatmap {
+				v, ok := data[key]
+				if !ok || v == nil {
+					continue
+				}
+				// round-trip the value through JSON to decode the buckets
+				b, err := json.Marshal(v)
+				if err != nil {
+					return row, fmt.Errorf("result %q: %w", key, err)
+				}
+				var buckets []HeatmapBucket
+				if err := json.Unmarshal(b, &buckets); err != nil {
+					return row, fmt.Errorf("result %q: expected heatmap buckets: %w", key, err)
+				}
+				if row.Heatmaps == nil {
+					row.Heatmaps = make(map[string][]HeatmapBucket)
+				}
+				row.Heatmaps[key] = buckets
+				continue
+			}
+			keys = append(keys, key)
+		}
+		for _, f := range spec.Formulas {
+			keys = append(keys, f.Name)
+		}
+		for _, key := range keys {
+			if f, ok, err := number(key); err != nil {
+				return row, err
+			} else if ok {
+				row.Values[key] = f
+			}
+			if !compare {
+				continue
+			}
+			if f, ok, err := number(key + CompareKeySuffix); err != nil {
+				return row, err
+			} else if ok {
+				if row.Compare == nil {
+					row.Compare = make(map[string]float64)
+				}
+				row.Compare[key] = f
+			}
+		}
+
+		return row, nil
+	}
+
+	typed := &TypedQueryResultData{
+		Series:  make([]QueryResultRow, 0, len(d.Series)),
+		Results: make([]QueryResultRow, 0, len(d.Results)),
+	}
+	for i, s := range d.Series {
+		row, err := decodeRow(s.Data)
+		if err != nil {
+			return nil, fmt.Errorf("series[%d]: %w", i, err)
+		}
+		row.Time = s.Time
+		typed.Series = append(typed.Series, row)
+	}
+	for i, r := range d.Results {
+		row, err := decodeRow(r.Data)
+		if err != nil {
+			return nil, fmt.Errorf("results[%d]: %w", i, err)
+		}
+		typed.Results = append(typed.Results, row)
+	}
+
+	return typed, nil
+}
+
+const (
+	// QueryResultPollInterval is the default delay before first polling
+	// for a query result.
+	QueryResultPollInterval time.Duration = 200 * time.Millisecond
+	// QueryResultMaxPollInterval is the default cap on the delay between
+	// polls for a query result.
+	QueryResultMaxPollInterval time.Duration = 5 * time.Second
+
+	// queryResultPollMultiplier is how much the delay between polls grows
+	// after each poll.
+	queryResultPollMultiplier = 2
+)
+
+// QueryResultPollOptions controls how QueryResults.Get polls for a query
+// result to complete.
+type QueryResultPollOptions struct {
+	// InitialInterval is the delay before the first poll.
+	InitialInterval time.Duration
+	// MaxInterval caps the delay between polls.
+	MaxInterval time.Duration
+	// MaxWait is the longest to wait for the query result to complete.
+	// If zero, Get waits until the context is done.
+	MaxWait time.Duration
+}
+
+type QueryResultPollOption func(*QueryResultPollOptions)
+
+// WithPollInterval sets the delay before the first poll for a query
+// result, and the cap on the delay between polls backs off to.
+func WithPollInterval(initial, max time.Duration) QueryResultPollOption {
+	return func(o *QueryResultPollOptions) {
+		o.InitialInterval = initial
+		o.MaxInterval = max
+	}
+}
+
+// WithMaxWait sets the longest to wait for a query result to complete.
+func WithMaxWait(d time.Duration) QueryResultPollOption {
+	return func(o *QueryResultPollOptions) { o.MaxWait = d }
+}
+
+// QueryStillRunningError is returned by QueryResults.Get when a query result
+// has not completed in the time allowed.
+//
+// The query result continues to run, and can be fetched again by its ID.
+type QueryStillRunningError struct {
+	// ID of the query result.
+	ID string
+	// Waited is how long Get waited for the query result to complete.
+	Waited time.Duration
+
+	err error
+}
+
+func (e *QueryStillRunningError) Error() string {
+	return fmt.Sprintf("query result %s still running after %s", e.ID, e.Waited.Round(time.Millisecond))
+}
+
+// Unwrap returns the context error which ended the wait.
+func (e *QueryStillRunningError) Unwrap() error { return e.err }
 
 type QueryResultLinks struct {
 	Url      string `json:"query_url"`
 	GraphUrl string `json:"graph_image_url"`
 }
 
-func (s *queryResults) Get(ctx context.Context, dataset string, q *QueryResult) error {
-	var err error
+func (s *queryResults) Get(ctx context.Context, dataset string, q *QueryResult, opts ...QueryResultPollOption) error {
+	o := QueryResultPollOptions{
+		InitialInterval: QueryResultPollInterval,
+		MaxInterval:     QueryResultMaxPollInterval,
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if o.InitialInterval <= 0 {
+		o.InitialInterval = QueryResultPollInterval
+	}
+	o.MaxInterval = max(o.MaxInterval, o.InitialInterval)
+	if o.MaxWait > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, o.MaxWait)
+		defer cancel()
+	}
 	resultUri := fmt.Sprintf("/1/query_results/%s/%s", urlEncodeDataset(dataset), q.ID)
 
-	ticker := time.NewTicker(QueryResultPollInterval)
+	start := time.Now()
+	// a deadline passing is reported as the query still running
+	asStillRunning := func(err error) error {
+		if ctx.Err() == nil || !errors.Is(err, context.DeadlineExceeded) {
+			return err
+		}
+		return &QueryStillRunningError{
+			ID:     q.ID,
+			Waited: time.Since(start),
+			err:    err,
+		}
+	}
+
+	interval := o.InitialInterval
+	timer := time.NewTimer(interval)
+	defer timer.Stop()
 	for {
 		select {
-		case <-ticker.C:
-			// poll until complete or errored
-			if err = s.client.Do(ctx, "GET", resultUri, nil, &q); err != nil {
-				return err
-			}
-			if q.Complete {
-				return nil
-			}
+		case <-timer.C:
 		case <-ctx.Done():
-			return ctx.Err()
+			return asStillRunning(ctx.Err())
 		}
+
+		// poll until complete or errored
+		if err := s.client.Do(ctx, "GET", resultUri, nil, q); err != nil {
+			return asStillRunning(err)
+		}
+		if q.Complete {
+			return nil
+		}
+
+		interval = min(interval*queryResultPollMultiplier, o.MaxInterval)
+		timer.Reset(interval)
 	}
 }
 
@@ -2,12 +2,15 @@ package client_test
 
 import (
 	"context"
+	"encoding/json"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/honeycombio/terraform-provider-honeycombio/client"
+	"github.com/honeycombio/terraform-provider-honeycombio/client/fakeserver"
 )
 
 func TestQueryResults(t *testing.T) {
@@ -60,3 +63,190 @@ func TestQueryResults(t *testing.T) {
 		assert.True(t, de.IsNotFound())
 	})
 }
+
+func TestQueryResults_Polling(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	const getResult = "GET /1/query_results/{dataset}/{id}"
+
+	// createResult creates a query result for an empty query against a new dataset
+	createResult := func(t *testing.T, c *client.Client) (string, *client.QueryResult) {
+		t.Helper()
+
+		ds, err := c.Datasets.Create(ctx, &client.Dataset{Name: "polling"})
+		require.NoError(t, err)
+		q, err := c.Queries.Create(ctx, ds.Slug, &client.QuerySpec{})
+		require.NoError(t, err)
+		qr, err := c.QueryResults.Create(ctx, ds.Slug, &client.QueryResultRequest{ID: *q.ID})
+		require.NoError(t, err)
+		require.False(t, qr.Complete, "query result should start incomplete")
+
+		return ds.Slug, qr
+	}
+
+	t.Run("polls until complete", func(t *testing.T) {
+		s, c := newFakeTestClient(t, fakeserver.WithQueryResultPolls(3))
+		dataset, qr := createResult(t, c)
+
+		err := c.QueryResults.Get(ctx, dataset, qr, client.WithPollInterval(time.Millisecond, 4*time.Millisecond))
+		require.NoError(t, err)
+		assert.True(t, qr.Complete)
+		assert.Equal(t, 3, s.RequestCount(getResult))
+	})
+
+	t.Run("backs off between polls", func(t *testing.T) {
+		s, c := newFakeTestClient(t, fakeserver.WithQueryResultPolls(100))
+		dataset, qr := createResult(t, c)
+
+		// waits of 10, 20, 40 and 80ms fit in the time allowed, but not a fifth
+		err := c.QueryResults.Get(ctx, dataset, qr,
+			client.WithPollInterval(10*time.Millisecond, time.Second),
+			client.WithMaxWait(250*time.Millisecond),
+		)
+		require.Error(t, err)
+		assert.Equal(t, 4, s.RequestCount(getResult))
+	})
+
+	t.Run("times out with the query still running", func(t *testing.T) {
+		_, c := newFakeTestClient(t, fakeserver.WithQueryResultPolls(100))
+		dataset, qr := createResult(t, c)
+
+		err := c.QueryResults.Get(ctx, dataset, qr,
+			client.WithPollInterval(time.Millisecond, 5*time.Millisecond),
+			client.WithMaxWait(50*time.Millisecond),
+		)
+
+		var stillRunning *client.QueryStillRunningError
+		require.ErrorAs(t, err, &stillRunning)
+		assert.Equal(t, qr.ID, stillRunning.ID)
+		assert.GreaterOrEqual(t, stillRunning.Waited, 50*time.Millisecond)
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.False(t, qr.Complete)
+	})
+
+	t.Run("context cancellation is not a timeout", func(t *testing.T) {
+		_, c := newFakeTestClient(t, fakeserver.WithQueryResultPolls(100))
+		dataset, qr := createResult(t, c)
+
+		cctx, cancel := context.WithCancel(ctx)
+		cancel()
+		err := c.QueryResults.Get(cctx, dataset, qr)
+
+		require.ErrorIs(t, err, context.Canceled)
+		var stillRunning *client.QueryStillRunningError
+		assert.NotErrorAs(t, err, &stillRunning)
+	})
+}
+
+func TestQueryResultData_Decode(t *testing.T) {
+	t.Parallel()
+
+	decode := func(t *testing.T, data string) client.QueryResultData {
+		t.Helper()
+
+		var d client.QueryResultData
+		require.NoError(t, json.Unmarshal([]byte(data), &d))
+		return d
+	}
+
+	t.Run("calculations and breakdowns", func(t *testing.T) {
+		d := decode(t, `{
+			"series": [
+				{"time": "2025-01-01T00:00:00Z", "data": {"COUNT": 3, "P99(duration_ms)": 12.5, "service.name": "api"}}
+			],
+			"results": [
+				{"data": {"COUNT": 10, "P99(duration_ms)": 20.25, "service.name": "api", "http.status_code": 200}},
+				{"data": {"COUNT": 2, "P99(duration_ms)": null, "service.name": null, "http.status_code": 500}}
+			]
+		}`)
+
+		typed, err := d.Decode(&client.QuerySpec{
+			Calculations: []client.CalculationSpec{
+				{Op: client.CalculationOpCount},
+				{Op: client.CalculationOpP99, Column: client.ToPtr("duration_ms")},
+			},
+			Breakdowns: []string{"service.name", "http.status_code"},
+		})
+		require.NoError(t, err)
+
+		require.Len(t, typed.Series, 1)
+		assert.Equal(t, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), typed.Series[0].Time)
+		assert.Equal(t, map[string]float64{"COUNT": 3, "P99(duration_ms)": 12.5}, typed.Series[0].Values)
+		assert.Equal(t, map[string]any{"service.name": "api"}, typed.Series[0].Group)
+
+		require.Len(t, typed.Results, 2)
+		assert.True(t, typed.Results[0].Time.IsZero())
+		assert.Equal(t, map[string]float64{"COUNT": 10, "P99(duration_ms)": 20.25}, typed.Results[0].Values)
+		assert.Equal(t, map[string]any{"service.name": "api", "http.status_code": float64(200)}, typed.Results[0].Group)
+		assert.Equal(t, map[string]float64{"COUNT": 2}, typed.Results[1].Values, "null values should be omitted")
+		assert.Equal(t, map[string]any{"service.name": nil, "http.status_code": float64(500)}, typed.Results[1].Group)
+		assert.Nil(t, typed.Results[1].Compare)
+	})
+
+	t.Run("default calculation", func(t *testing.T) {
+		typed, err := decode(t, `{"results": [{"data": {"COUNT": 42}}]}`).Decode(&client.QuerySpec{})
+		require.NoError(t, err)
+		require.Len(t, typed.Results, 1)
+		assert.Equal(t, map[string]float64{"COUNT": 42}, typed.Results[0].Values)
+	})
+
+	t.Run("named calculations and formulas", func(t *testing.T) {
+		d := decode(t, `{"results": [{"data": {"errors": 5, "total": 50, "error_rate": 0.1}}]}`)
+
+		typed, err := d.Decode(&client.QuerySpec{
+			Calculations: []client.CalculationSpec{
+				{Op: client.CalculationOpCount, Name: client.ToPtr("errors")},
+				{Op: client.CalculationOpCount, Name: client.ToPtr("total")},
+			},
+			Formulas: []client.FormulaSpec{
+				{Name: "error_rate", Expression: "DIV($errors, $total)"},
+			},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, map[string]float64{"errors": 5, "total": 50, "error_rate": 0.1}, typed.Results[0].Values)
+	})
+
+	t.Run("heatmaps", func(t *testing.T) {
+		d := decode(t, `{"results": [{"data": {
+			"HEATMAP(duration_ms)": [{"min": 0, "max": 10, "count": 4}, {"min": 10, "max": 20, "count": 1}],
+			"HEATMAP(size)": [3, 0, 7]
+		}}]}`)
+
+		typed, err := d.Decode(&client.QuerySpec{
+			Calculations: []client.CalculationSpec{
+				{Op: client.CalculationOpHeatmap, Column: client.ToPtr("duration_ms")},
+				{Op: client.CalculationOpHeatmap, Column: client.ToPtr("size")},
+			},
+		})
+		require.NoError(t, err)
+		assert.Empty(t, typed.Results[0].Values)
+		assert.Equal(t, map[string][]client.HeatmapBucket{
+			"HEATMAP(duration_ms)": {{Min: 0, Max: 10, Count: 4}, {Min: 10, Max: 20, Count: 1}},
+			"HEATMAP(size)":        {{Count: 3}, {Count: 0}, {Count: 7}},
+		}, typed.Results[0].Heatmaps)
+	})
+
+	t.Run("compare", func(t *testing.T) {
+		d := decode(t, `{"results": [{"data": {"COUNT": 10, "COUNT_compare": 8}}]}`)
+
+		typed, err := d.Decode(&client.QuerySpec{
+			Calculations:             []client.CalculationSpec{{Op: client.CalculationOpCount}},
+			CompareTimeOffsetSeconds: client.ToPtr(3600),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, map[string]float64{"COUNT": 10}, typed.Results[0].Values)
+		assert.Equal(t, map[string]float64{"COUNT": 8}, typed.Results[0].Compare)
+	})
+
+	t.Run("mistyped values", func(t *testing.T) {
+		_, err := decode(t, `{"results": [{"data": {"COUNT": 1}}, {"data": {"COUNT": "many"}}]}`).Decode(nil)
+		require.Error(t, err)
+		assert.Equal(t, `results[1]: result "COUNT": expected a number but got string`, err.Error())
+
+		_, err = decode(t, `{"results": [{"data": {"HEATMAP(d)": "hot"}}]}`).Decode(&client.QuerySpec{
+			Calculations: []client.CalculationSpec{{Op: client.CalculationOpHeatmap, Column: client.ToPtr("d")}},
+		})
+		require.ErrorContains(t, err, `result "HEATMAP(d)": expected heatmap buckets`)
+	})
+}
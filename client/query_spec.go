@@ -1,14 +1,22 @@
 package client
 
 import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
+	"fmt"
 	"reflect"
+	"slices"
+	"strconv"
+	"strings"
 	"time"
 )
 
 const (
 	DefaultQueryTimeRange = 2 * 60 * 60
 	DefaultQueryLimit     = 1000
+	MaxQueryLimit         = 1000
 )
 
 // ValidTimeCompareOffsets are the valid time offsets for comparison queries, in seconds.
@@ -93,115 +101,259 @@ func (qs *QuerySpec) Encode() (string, error) {
 	return string(b), nil
 }
 
-// Determines if two QuerySpecs are equivalent
+// Determines if two QuerySpecs are equivalent: if their normalized forms
+// are the same.
 func (qs *QuerySpec) EquivalentTo(other QuerySpec) bool {
-	// The order of Calculations is important for visualization rendering, so we're looking for equality
-	calcMatch := true
-	if len(qs.Calculations) != len(other.Calculations) {
-		calcMatch = false
+	a, err := json.Marshal(qs.Normalize())
+	if err != nil {
+		return false
+	}
+	b, err := json.Marshal(other.Normalize())
+	if err != nil {
+		return false
+	}
+	return bytes.Equal(a, b)
+}
+
+// Normalize returns a copy of the QuerySpec in canonical form, so that
+// equivalent QuerySpecs are equal once normalized.
+//
+//   - the default COUNT calculation is made explicit, as the API returns it
+//   - fields set to their default value are omitted: the AND filter
+//     combination, the ascending sort order, the default limit and time
+//     range, and a granularity of 0
+//   - empty lists are omitted
+//   - lists whose order does not matter (filters, havings and calculated
+//     fields) are sorted
+//   - the ID is omitted
+//
+// The order of calculations, formulas, breakdowns and orders is kept, as it
+// matters for visualization rendering.
+func (qs *QuerySpec) Normalize() *QuerySpec {
+	n := &QuerySpec{
+		Breakdowns:               slices.Clone(qs.Breakdowns),
+		Formulas:                 slices.Clone(qs.Formulas),
+		CalculatedFields:         sortedByJSON(qs.CalculatedFields),
+		Filters:                  sortedByJSON(qs.Filters),
+		Havings:                  sortedByJSON(qs.Havings),
+		StartTime:                qs.StartTime,
+		EndTime:                  qs.EndTime,
+		CompareTimeOffsetSeconds: qs.CompareTimeOffsetSeconds,
+	}
+
+	// For non-metrics datasets, COUNT is applied if no calculations are provided.
+	//
+	// We _should_ check that the default matches the expected default from
+	// the dataset, otherwise we'll incorrectly assume that empty and COUNT are
+	// the same for metrics (which doesn't have a default).
+	//
+	// We're ignoring this problem for now since COUNT is disallowed
+	// for use by metrics queries, and often isn't what users actually want.
+	// See https://docs.honeycomb.io/investigate/query/examples-metrics#common-select-operations
+	if len(qs.Calculations) == 0 {
+		n.Calculations = []CalculationSpec{{Op: CalculationOpCount}}
 	} else {
-		for i := range qs.Calculations {
-			if !qs.Calculations[i].EquivalentTo(other.Calculations[i]) {
-				calcMatch = false
-				break
+		n.Calculations = make([]CalculationSpec, len(qs.Calculations))
+		for i, c := range qs.Calculations {
+			c.Filters = sortedByJSON(c.Filters)
+			if c.FilterCombination == DefaultFilterCombination {
+				c.FilterCombination = ""
 			}
+			n.Calculations[i] = c
 		}
 	}
 
-	if !calcMatch {
-		// isDefaultBareCalc reports whether calcs matches dataset-level defaults filled in by the API.
-		isDefaultBareCalc := func(calcs []CalculationSpec) bool {
-			if len(calcs) != 1 {
-				return false
-			}
-			c := calcs[0]
-			if c.Column != nil || c.Name != nil || len(c.Filters) != 0 {
-				return false
-			}
+	if qs.FilterCombination != DefaultFilterCombination {
+		n.FilterCombination = qs.FilterCombination
+	}
 
-			// We _should_ check that the default matches the expected default from the
-			// the dataset, otherwise we'll incorrectly assume that empty and COUNT are
-			// the same for metrics (which doesn't have a default).
-			//
-			// We're ignoring this problem for now since COUNT is disallowed
-			// for use by metrics queries, and often isn't what users actually want.
-			// See https://docs.honeycomb.io/investigate/query/examples-metrics#common-select-operations
-			return c.Op == CalculationOpCount
+	if len(qs.Orders) > 0 {
+		n.Orders = make([]OrderSpec, len(qs.Orders))
+		for i, o := range qs.Orders {
+			if o.Order != nil && *o.Order == SortOrderAsc {
+				o.Order = nil
+			}
+			n.Orders[i] = o
 		}
+	}
 
-		if !isDefaultBareCalc(qs.Calculations) && len(qs.Calculations) != 0 ||
-			!isDefaultBareCalc(other.Calculations) && len(other.Calculations) != 0 {
-			return false
-		}
+	if len(n.Breakdowns) == 0 {
+		n.Breakdowns = nil
+	}
+	if len(n.Formulas) == 0 {
+		n.Formulas = nil
+	}
+	if qs.Limit != nil && *qs.Limit != DefaultQueryLimit {
+		n.Limit = qs.Limit
+	}
+	if qs.TimeRange != nil && *qs.TimeRange != DefaultQueryTimeRange {
+		n.TimeRange = qs.TimeRange
+	}
+	// Granularity may be exported out of the Query Builder as '0' when not provided
+	if qs.Granularity != nil && *qs.Granularity != 0 {
+		n.Granularity = qs.Granularity
 	}
 
-	// the exact order of calculated fields does not matter, but their equivalence does
-	if !Equivalent(qs.CalculatedFields, other.CalculatedFields) {
-		return false
+	return n
+}
+
+// Hash returns a stable hash of the QuerySpec's normalized form, which is
+// the same for all equivalent QuerySpecs.
+func (qs *QuerySpec) Hash() (string, error) {
+	b, err := json.Marshal(qs.Normalize())
+	if err != nil {
+		return "", err
 	}
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:]), nil
+}
 
-	// The order of Formulas is important for visualization rendering
-	if !reflect.DeepEqual(qs.Formulas, other.Formulas) &&
-		// an empty Formulas is equivalent to a nil Formulas
-		((qs.Formulas != nil || len(other.Formulas) != 0) &&
-			(len(qs.Formulas) != 0 || other.Formulas != nil)) {
-		return false
+// sortedByJSON returns a sorted copy of the slice, ordered by the JSON
+// encoding of its elements, or nil if the slice is empty.
+func sortedByJSON[T any](s []T) []T {
+	if len(s) == 0 {
+		return nil
+	}
+	type keyed struct {
+		key string
+		v   T
 	}
+	ks := make([]keyed, len(s))
+	for i, v := range s {
+		b, _ := json.Marshal(v)
+		ks[i] = keyed{key: string(b), v: v}
+	}
+	slices.SortStableFunc(ks, func(a, b keyed) int { return strings.Compare(a.key, b.key) })
 
-	// Orders have a default ascending order, so we need to check equivalence
-	if len(qs.Orders) != len(other.Orders) {
-		return false
+	sorted := make([]T, len(ks))
+	for i, k := range ks {
+		sorted[i] = k.v
 	}
-	for i := range qs.Orders {
-		if PtrValueOrDefault(qs.Orders[i].Order, SortOrderAsc) != PtrValueOrDefault(other.Orders[i].Order, SortOrderAsc) {
-			return false
-		}
-		if !reflect.DeepEqual(qs.Orders[i].Column, other.Orders[i].Column) {
-			return false
-		}
-		if !reflect.DeepEqual(qs.Orders[i].Op, other.Orders[i].Op) {
-			return false
-		}
+	return sorted
+}
+
+// Validate checks the QuerySpec against the rules the API applies to
+// queries, so that mistakes can be found before the query is sent.
+//
+// If any rule is broken, the returned error is a FieldErrors naming each
+// offending field.
+func (qs *QuerySpec) Validate() error {
+	var errs FieldErrors
+	addErr := func(field, msg string) {
+		errs = append(errs, FieldError{Field: field, Message: msg})
 	}
 
-	// the exact order of filters does not matter, but their equvalence does
-	if !Equivalent(qs.Filters, other.Filters) {
-		return false
+	// COUNT is applied if no calculations are provided
+	calculations := qs.Calculations
+	if len(calculations) == 0 {
+		calculations = []CalculationSpec{{Op: CalculationOpCount}}
 	}
-	if ValueOrDefault(qs.FilterCombination, DefaultFilterCombination) != ValueOrDefault(other.FilterCombination, DefaultFilterCombination) {
-		return false
+
+	if qs.Limit != nil && (*qs.Limit < 1 || *qs.Limit > MaxQueryLimit) {
+		addErr("limit", "limit must be between 1 and "+strconv.Itoa(MaxQueryLimit))
 	}
 
-	if !reflect.DeepEqual(qs.Breakdowns, other.Breakdowns) &&
-		// an empty Breakdowns is equivalent to a nil Breakdowns, so we need to check that
-		// as DeepEqual will not consider them equal
-		((qs.Breakdowns != nil || len(other.Breakdowns) != 0) &&
-			(len(qs.Breakdowns) != 0 || other.Breakdowns != nil)) {
-		return false
+	if qs.TimeRange != nil && *qs.TimeRange <= 0 {
+		addErr("time_range", "time_range must be greater than zero")
+	}
+	if qs.TimeRange != nil && qs.StartTime != nil && qs.EndTime != nil {
+		addErr("time_range", "specify at most two of time_range, start_time and end_time")
+	}
+	if qs.StartTime != nil && qs.EndTime != nil && *qs.EndTime <= *qs.StartTime {
+		addErr("end_time", "end_time must be after start_time")
+	}
+	timeRange := int64(DefaultQueryTimeRange)
+	if qs.TimeRange != nil {
+		timeRange = int64(*qs.TimeRange)
+	} else if qs.StartTime != nil && qs.EndTime != nil {
+		timeRange = *qs.EndTime - *qs.StartTime
 	}
 
-	// the exact order of havings does not matter, but their equvalence does
-	if !Equivalent(qs.Havings, other.Havings) {
-		return false
+	// Granularity may be exported out of the Query Builder as '0' when not provided.
+	//
+	// The API documents time_range/10 as the largest granularity, but accepts
+	// any granularity up to the time range itself.
+	if g := int64(PtrValueOrDefault(qs.Granularity, 0)); g != 0 {
+		switch {
+		case g < 0:
+			addErr("granularity", "granularity can not be negative")
+		case g > timeRange:
+			addErr("granularity", "granularity can not be greater than time_range")
+		case g < timeRange/1000:
+			addErr("granularity", "granularity can not be less than time_range/1000")
+		}
 	}
-	if PtrValueOrDefault(qs.Limit, DefaultQueryLimit) != PtrValueOrDefault(other.Limit, DefaultQueryLimit) {
-		return false
+
+	// names which havings and orders may reference
+	names := make(map[string]struct{})
+	for _, c := range calculations {
+		if c.Name != nil {
+			names[*c.Name] = struct{}{}
+		}
 	}
-	if PtrValueOrDefault(qs.TimeRange, DefaultQueryTimeRange) != PtrValueOrDefault(other.TimeRange, DefaultQueryTimeRange) {
-		return false
+	for _, f := range qs.Formulas {
+		names[f.Name] = struct{}{}
 	}
-	if !reflect.DeepEqual(qs.StartTime, other.StartTime) || !reflect.DeepEqual(qs.EndTime, other.EndTime) {
-		return false
+
+	for i, h := range qs.Havings {
+		field := "havings[" + strconv.Itoa(i) + "]"
+		if h.CalculateOp == nil {
+			// havings without an operator filter on a named calculation or formula
+			if _, ok := names[PtrValueOrDefault(h.Column, "")]; !ok {
+				addErr(field+".column", "having must reference a named calculation or formula")
+			}
+			continue
+		}
+		if !slices.ContainsFunc(calculations, func(c CalculationSpec) bool {
+			return c.Op == *h.CalculateOp && reflect.DeepEqual(c.Column, h.Column)
+		}) {
+			addErr(field+".calculate_op", string(*h.CalculateOp)+" missing matching calculation")
+		}
 	}
-	// Granularity may be exported out of the Query Builder as '0' when not provided
-	if PtrValueOrDefault(qs.Granularity, 0) != PtrValueOrDefault(other.Granularity, 0) {
-		return false
+
+	for i, o := range qs.Orders {
+		field := "orders[" + strconv.Itoa(i) + "]"
+		if o.Op != nil && *o.Op == CalculationOpHeatmap {
+			addErr(field+".op", "cannot order by HEATMAP")
+			continue
+		}
+		found := slices.ContainsFunc(calculations, func(c CalculationSpec) bool {
+			return reflect.DeepEqual(o.Column, c.Column)
+		})
+		if !found && o.Column != nil {
+			_, found = names[*o.Column]
+			found = found ||
+				slices.Contains(qs.Breakdowns, *o.Column) ||
+				slices.ContainsFunc(qs.CalculatedFields, func(f CalculatedFieldSpec) bool {
+					return f.Name == *o.Column
+				})
+		}
+		if !found {
+			addErr(field, "missing matching calculation, formula, or breakdown")
+		}
 	}
-	if !reflect.DeepEqual(qs.CompareTimeOffsetSeconds, other.CompareTimeOffsetSeconds) {
-		return false
+
+	if qs.CompareTimeOffsetSeconds != nil {
+		offset := int64(*qs.CompareTimeOffsetSeconds)
+		if offset < timeRange {
+			addErr("compare_time_offset_seconds", "compare_time_offset must be greater than the queries time range.")
+		}
+		if !slices.Contains(ValidTimeCompareOffsets, offset) {
+			valid := make([]string, len(ValidTimeCompareOffsets))
+			for i, v := range ValidTimeCompareOffsets {
+				valid[i] = strconv.FormatInt(v, 10)
+			}
+			addErr("compare_time_offset_seconds", fmt.Sprintf(
+				"compare_time_offset is an invalid value. Valid values are: %s",
+				strings.Join(valid, ", "),
+			))
+		}
 	}
 
-	return true
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
 }
 
 // CalculationSpec represents a calculation within a query.
@@ -0,0 +1,189 @@
+package client
+
+import (
+	"encoding/json"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// QuerySpecChange is a single semantic difference between two QuerySpecs.
+type QuerySpecChange struct {
+	// Field is the name of the QuerySpec field which changed, as in its JSON.
+	Field string
+	// Description is a human-readable description of the change.
+	Description string
+}
+
+func (c QuerySpecChange) String() string {
+	return c.Field + ": " + c.Description
+}
+
+// Diff returns the semantic differences from the QuerySpec to the other.
+//
+// Differences which do not change the meaning of the query, as described by
+// Normalize, are ignored. Lists whose order does not matter are compared as
+// sets, while those whose order does matter also report being reordered.
+func (qs *QuerySpec) Diff(other QuerySpec) []QuerySpecChange {
+	a, b := qs.Normalize(), other.Normalize()
+
+	var changes []QuerySpecChange
+	add := func(field, description string) {
+		changes = append(changes, QuerySpecChange{Field: field, Description: description})
+	}
+	diffList := func(field string, from, to []string, ordered bool) {
+		removed, added := listDifference(from, to), listDifference(to, from)
+		for _, v := range removed {
+			add(field, "removed "+v)
+		}
+		for _, v := range added {
+			add(field, "added "+v)
+		}
+		if ordered && len(removed) == 0 && len(added) == 0 && !slices.Equal(from, to) {
+			add(field, "reordered from "+formatList(from)+" to "+formatList(to))
+		}
+	}
+	diffValue := func(field, from, to string) {
+		if from != to {
+			add(field, "changed from "+from+" to "+to)
+		}
+	}
+
+	diffList("calculations", describeAll(a.Calculations, describeCalculation), describeAll(b.Calculations, describeCalculation), true)
+	diffList("calculated_fields", describeAll(a.CalculatedFields, describeCalculatedField), describeAll(b.CalculatedFields, describeCalculatedField), false)
+	diffList("formulas", describeAll(a.Formulas, describeFormula), describeAll(b.Formulas, describeFormula), true)
+	diffList("filters", describeAll(a.Filters, describeFilter), describeAll(b.Filters, describeFilter), false)
+	diffValue("filter_combination",
+		string(ValueOrDefault(a.FilterCombination, DefaultFilterCombination)),
+		string(ValueOrDefault(b.FilterCombination, DefaultFilterCombination)),
+	)
+	diffList("breakdowns", a.Breakdowns, b.Breakdowns, true)
+	diffList("orders", describeAll(a.Orders, describeOrder), describeAll(b.Orders, describeOrder), true)
+	diffList("havings", describeAll(a.Havings, describeHaving), describeAll(b.Havings, describeHaving), false)
+	diffValue("limit", formatIntPtr(a.Limit, DefaultQueryLimit), formatIntPtr(b.Limit, DefaultQueryLimit))
+	diffValue("time_range", formatIntPtr(a.TimeRange, DefaultQueryTimeRange), formatIntPtr(b.TimeRange, DefaultQueryTimeRange))
+	diffValue("start_time", formatInt64Ptr(a.StartTime), formatInt64Ptr(b.StartTime))
+	diffValue("end_time", formatInt64Ptr(a.EndTime), formatInt64Ptr(b.EndTime))
+	diffValue("granularity", formatIntPtr(a.Granularity, 0), formatIntPtr(b.Granularity, 0))
+	diffValue("compare_time_offset_seconds",
+		formatInt64Ptr(intPtrToInt64(a.CompareTimeOffsetSeconds)),
+		formatInt64Ptr(intPtrToInt64(b.CompareTimeOffsetSeconds)),
+	)
+
+	return changes
+}
+
+// listDifference returns the elements of a which are not in b, counting
+// repeated elements.
+func listDifference(a, b []string) []string {
+	remaining := slices.Clone(b)
+	var diff []string
+	for _, v := range a {
+		if i := slices.Index(remaining, v); i >= 0 {
+			remaining = slices.Delete(remaining, i, i+1)
+			continue
+		}
+		diff = append(diff, v)
+	}
+	return diff
+}
+
+func describeAll[T any](s []T, describe func(T) string) []string {
+	descriptions := make([]string, len(s))
+	for i, v := range s {
+		descriptions[i] = describe(v)
+	}
+	return descriptions
+}
+
+func describeCalculation(c CalculationSpec) string {
+	s := string(c.Op)
+	if c.Column != nil {
+		s += "(" + *c.Column + ")"
+	}
+	if c.Name != nil {
+		s = *c.Name + " = " + s
+	}
+	if len(c.Filters) > 0 {
+		combination := " " + string(ValueOrDefault(c.FilterCombination, DefaultFilterCombination)) + " "
+		s += " WHERE " + strings.Join(describeAll(c.Filters, describeFilter), combination)
+	}
+	return s
+}
+
+func describeCalculatedField(f CalculatedFieldSpec) string {
+	return f.Name + " = " + f.Expression
+}
+
+func describeFormula(f FormulaSpec) string {
+	return f.Name + " = " + f.Expression
+}
+
+func describeFilter(f FilterSpec) string {
+	if f.Op.IsUnary() {
+		return f.Column + " " + string(f.Op)
+	}
+	return f.Column + " " + string(f.Op) + " " + formatValue(f.Value)
+}
+
+func describeOrder(o OrderSpec) string {
+	var s string
+	switch {
+	case o.Op != nil && o.Column != nil:
+		s = string(*o.Op) + "(" + *o.Column + ")"
+	case o.Op != nil:
+		s = string(*o.Op)
+	case o.Column != nil:
+		s = *o.Column
+	}
+	return s + " " + string(PtrValueOrDefault(o.Order, SortOrderAsc))
+}
+
+func describeHaving(h HavingSpec) string {
+	var s string
+	switch {
+	case h.CalculateOp != nil && h.Column != nil:
+		s = string(*h.CalculateOp) + "(" + *h.Column + ")"
+	case h.CalculateOp != nil:
+		s = string(*h.CalculateOp)
+	case h.Column != nil:
+		s = *h.Column
+	}
+	if h.Op != nil {
+		s += " " + string(*h.Op)
+	}
+	return s + " " + formatValue(h.Value)
+}
+
+func formatValue(v any) string {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return "?"
+	}
+	return string(b)
+}
+
+func formatList(s []string) string {
+	return "[" + strings.Join(s, ", ") + "]"
+}
+
+func formatIntPtr(v *int, def int) string {
+	if v == nil {
+		return strconv.Itoa(def) + " (default)"
+	}
+	return strconv.Itoa(*v)
+}
+
+func formatInt64Ptr(v *int64) string {
+	if v == nil {
+		return "unset"
+	}
+	return strconv.FormatInt(*v, 10)
+}
+
+func intPtrToInt64(v *int) *int64 {
+	if v == nil {
+		return nil
+	}
+	return ToPtr(int64(*v))
+}
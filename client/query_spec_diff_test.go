@@ -0,0 +1,137 @@
+package client_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/honeycombio/terraform-provider-honeycombio/client"
+)
+
+func TestQuerySpec_Diff(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		a, b     client.QuerySpec
+		expected []client.QuerySpecChange
+	}{
+		{
+			name: "equivalent queries",
+			a: client.QuerySpec{
+				Filters: []client.FilterSpec{
+					{Column: "colA", Op: client.FilterOpEquals, Value: "a"},
+					{Column: "colB", Op: client.FilterOpExists},
+				},
+				TimeRange: client.ToPtr(client.DefaultQueryTimeRange),
+			},
+			b: client.QuerySpec{
+				Calculations: []client.CalculationSpec{{Op: client.CalculationOpCount}},
+				Filters: []client.FilterSpec{
+					{Column: "colB", Op: client.FilterOpExists},
+					{Column: "colA", Op: client.FilterOpEquals, Value: "a"},
+				},
+				FilterCombination: client.FilterCombinationAnd,
+			},
+		},
+		{
+			name: "calculations added and removed",
+			a: client.QuerySpec{
+				Calculations: []client.CalculationSpec{
+					{Op: client.CalculationOpCount},
+					{Op: client.CalculationOpP99, Column: client.ToPtr("duration_ms")},
+				},
+			},
+			b: client.QuerySpec{
+				Calculations: []client.CalculationSpec{
+					{Op: client.CalculationOpCount},
+					{
+						Op:      client.CalculationOpCount,
+						Name:    client.ToPtr("errors"),
+						Filters: []client.FilterSpec{{Column: "error", Op: client.FilterOpEquals, Value: true}},
+					},
+				},
+			},
+			expected: []client.QuerySpecChange{
+				{Field: "calculations", Description: "removed P99(duration_ms)"},
+				{Field: "calculations", Description: "added errors = COUNT WHERE error = true"},
+			},
+		},
+		{
+			name: "breakdowns reordered",
+			a:    client.QuerySpec{Breakdowns: []string{"service.name", "name"}},
+			b:    client.QuerySpec{Breakdowns: []string{"name", "service.name"}},
+			expected: []client.QuerySpecChange{
+				{Field: "breakdowns", Description: "reordered from [service.name, name] to [name, service.name]"},
+			},
+		},
+		{
+			name: "filters changed",
+			a: client.QuerySpec{
+				Filters: []client.FilterSpec{
+					{Column: "colA", Op: client.FilterOpEquals, Value: "a"},
+					{Column: "colB", Op: client.FilterOpIn, Value: []any{"x", "y"}},
+				},
+			},
+			b: client.QuerySpec{
+				Filters: []client.FilterSpec{
+					{Column: "colB", Op: client.FilterOpIn, Value: []any{"x", "y"}},
+					{Column: "colA", Op: client.FilterOpNotEquals, Value: "a"},
+				},
+				FilterCombination: client.FilterCombinationOr,
+			},
+			expected: []client.QuerySpecChange{
+				{Field: "filters", Description: `removed colA = "a"`},
+				{Field: "filters", Description: `added colA != "a"`},
+				{Field: "filter_combination", Description: "changed from AND to OR"},
+			},
+		},
+		{
+			name: "time window changed",
+			a: client.QuerySpec{
+				TimeRange:   client.ToPtr(3600),
+				Granularity: client.ToPtr(60),
+			},
+			b: client.QuerySpec{
+				StartTime:                client.ToPtr(int64(1577836800)),
+				CompareTimeOffsetSeconds: client.ToPtr(86400),
+			},
+			expected: []client.QuerySpecChange{
+				{Field: "time_range", Description: "changed from 3600 to 7200 (default)"},
+				{Field: "start_time", Description: "changed from unset to 1577836800"},
+				{Field: "granularity", Description: "changed from 60 to 0 (default)"},
+				{Field: "compare_time_offset_seconds", Description: "changed from unset to 86400"},
+			},
+		},
+		{
+			name: "orders, havings and limit changed",
+			a: client.QuerySpec{
+				Orders:  []client.OrderSpec{{Op: client.ToPtr(client.CalculationOpCount), Order: client.ToPtr(client.SortOrderDesc)}},
+				Havings: []client.HavingSpec{{CalculateOp: client.ToPtr(client.CalculationOpCount), Op: client.ToPtr(client.HavingOpGreaterThan), Value: 10}},
+			},
+			b: client.QuerySpec{
+				Orders: []client.OrderSpec{{Op: client.ToPtr(client.CalculationOpCount)}},
+				Limit:  client.ToPtr(10),
+			},
+			expected: []client.QuerySpecChange{
+				{Field: "orders", Description: "removed COUNT descending"},
+				{Field: "orders", Description: "added COUNT ascending"},
+				{Field: "havings", Description: "removed COUNT > 10"},
+				{Field: "limit", Description: "changed from 1000 (default) to 10"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.a.Diff(tt.b))
+		})
+	}
+}
+
+func TestQuerySpecChange_String(t *testing.T) {
+	t.Parallel()
+
+	c := client.QuerySpecChange{Field: "breakdowns", Description: "added name"}
+	assert.Equal(t, "breakdowns: added name", c.String())
+}
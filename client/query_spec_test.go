@@ -589,6 +589,255 @@ func TestQuerySpec_EquivalentTo(t *testing.T) {
 	}
 }
 
+func TestQuerySpec_Normalize(t *testing.T) {
+	t.Parallel()
+
+	qs := client.QuerySpec{
+		ID: client.ToPtr("abc123"),
+		Calculations: []client.CalculationSpec{
+			{
+				Op:   client.CalculationOpCount,
+				Name: client.ToPtr("errors"),
+				Filters: []client.FilterSpec{
+					{Column: "status", Op: client.FilterOpEquals, Value: "error"},
+					{Column: "service", Op: client.FilterOpEquals, Value: "api"},
+				},
+				FilterCombination: client.FilterCombinationAnd,
+			},
+		},
+		Filters: []client.FilterSpec{
+			{Column: "colB", Op: client.FilterOpExists},
+			{Column: "colA", Op: client.FilterOpGreaterThan, Value: 5},
+		},
+		FilterCombination: client.FilterCombinationAnd,
+		Breakdowns:        []string{"colB", "colA"},
+		Formulas:          []client.FormulaSpec{},
+		Orders: []client.OrderSpec{
+			{Column: client.ToPtr("colB"), Order: client.ToPtr(client.SortOrderAsc)},
+			{Column: client.ToPtr("colA"), Order: client.ToPtr(client.SortOrderDesc)},
+		},
+		Limit:       client.ToPtr(client.DefaultQueryLimit),
+		TimeRange:   client.ToPtr(client.DefaultQueryTimeRange),
+		Granularity: client.ToPtr(0),
+	}
+
+	assert.Equal(t, &client.QuerySpec{
+		Calculations: []client.CalculationSpec{
+			{
+				Op:   client.CalculationOpCount,
+				Name: client.ToPtr("errors"),
+				Filters: []client.FilterSpec{
+					{Column: "service", Op: client.FilterOpEquals, Value: "api"},
+					{Column: "status", Op: client.FilterOpEquals, Value: "error"},
+				},
+			},
+		},
+		Filters: []client.FilterSpec{
+			{Column: "colA", Op: client.FilterOpGreaterThan, Value: 5},
+			{Column: "colB", Op: client.FilterOpExists},
+		},
+		Breakdowns: []string{"colB", "colA"},
+		Orders: []client.OrderSpec{
+			{Column: client.ToPtr("colB")},
+			{Column: client.ToPtr("colA"), Order: client.ToPtr(client.SortOrderDesc)},
+		},
+	}, qs.Normalize())
+
+	assert.Equal(t, "abc123", *qs.ID, "the receiver should not be modified")
+	assert.Equal(t, "status", qs.Calculations[0].Filters[0].Column, "the receiver should not be modified")
+	assert.Equal(t, qs.Normalize(), qs.Normalize().Normalize(), "normalizing should be idempotent")
+
+	t.Run("empty calculations become COUNT", func(t *testing.T) {
+		assert.Equal(t,
+			[]client.CalculationSpec{{Op: client.CalculationOpCount}},
+			(&client.QuerySpec{}).Normalize().Calculations,
+		)
+	})
+}
+
+func TestQuerySpec_Hash(t *testing.T) {
+	t.Parallel()
+
+	a := client.QuerySpec{
+		Filters: []client.FilterSpec{
+			{Column: "colA", Op: client.FilterOpEquals, Value: "a"},
+			{Column: "colB", Op: client.FilterOpEquals, Value: "b"},
+		},
+		TimeRange: client.ToPtr(client.DefaultQueryTimeRange),
+	}
+	b := client.QuerySpec{
+		ID:           client.ToPtr("abc123"),
+		Calculations: []client.CalculationSpec{{Op: client.CalculationOpCount}},
+		Filters: []client.FilterSpec{
+			{Column: "colB", Op: client.FilterOpEquals, Value: "b"},
+			{Column: "colA", Op: client.FilterOpEquals, Value: "a"},
+		},
+		FilterCombination: client.FilterCombinationAnd,
+	}
+	c := client.QuerySpec{
+		Filters: []client.FilterSpec{
+			{Column: "colA", Op: client.FilterOpEquals, Value: "a"},
+		},
+	}
+
+	hashA, err := a.Hash()
+	require.NoError(t, err)
+	hashB, err := b.Hash()
+	require.NoError(t, err)
+	hashC, err := c.Hash()
+	require.NoError(t, err)
+
+	assert.Len(t, hashA, 64)
+	assert.Equal(t, hashA, hashB, "equivalent queries should have the same hash")
+	assert.NotEqual(t, hashA, hashC, "different queries should have different hashes")
+}
+
+func TestQuerySpec_Validate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		spec     client.QuerySpec
+		expected client.FieldErrors
+	}{
+		{
+			name: "empty query",
+			spec: client.QuerySpec{},
+		},
+		{
+			name: "valid query",
+			spec: client.QuerySpec{
+				Calculations: []client.CalculationSpec{
+					{Op: client.CalculationOpCount},
+					{Op: client.CalculationOpP99, Column: client.ToPtr("duration_ms")},
+					{Op: client.CalculationOpCount, Name: client.ToPtr("errors")},
+				},
+				CalculatedFields: []client.CalculatedFieldSpec{{Name: "is_slow", Expression: "GT($duration_ms, 100)"}},
+				Formulas:         []client.FormulaSpec{{Name: "error_rate", Expression: "DIV($errors, $COUNT)"}},
+				Breakdowns:       []string{"service.name"},
+				Orders: []client.OrderSpec{
+					{Op: client.ToPtr(client.CalculationOpCount)},
+					{Op: client.ToPtr(client.CalculationOpP99), Column: client.ToPtr("duration_ms")},
+					{Column: client.ToPtr("service.name")},
+					{Column: client.ToPtr("error_rate")},
+					{Column: client.ToPtr("is_slow")},
+				},
+				Havings: []client.HavingSpec{
+					{CalculateOp: client.ToPtr(client.CalculationOpP99), Column: client.ToPtr("duration_ms"), Op: client.ToPtr(client.HavingOpGreaterThan), Value: 100},
+					{Column: client.ToPtr("error_rate"), Op: client.ToPtr(client.HavingOpGreaterThan), Value: 0.1},
+				},
+				Limit:                    client.ToPtr(100),
+				TimeRange:                client.ToPtr(3600),
+				Granularity:              client.ToPtr(60),
+				CompareTimeOffsetSeconds: client.ToPtr(86400),
+			},
+		},
+		{
+			name: "zero granularity",
+			spec: client.QuerySpec{TimeRange: client.ToPtr(86400), Granularity: client.ToPtr(0)},
+		},
+		{
+			name: "limit out of range",
+			spec: client.QuerySpec{Limit: client.ToPtr(1001)},
+			expected: client.FieldErrors{
+				{Field: "limit", Message: "limit must be between 1 and 1000"},
+			},
+		},
+		{
+			name: "overspecified time",
+			spec: client.QuerySpec{
+				TimeRange: client.ToPtr(7200),
+				StartTime: client.ToPtr(int64(1577836800)),
+				EndTime:   client.ToPtr(int64(1577844000)),
+			},
+			expected: client.FieldErrors{
+				{Field: "time_range", Message: "specify at most two of time_range, start_time and end_time"},
+			},
+		},
+		{
+			name: "end before start",
+			spec: client.QuerySpec{
+				StartTime: client.ToPtr(int64(1577844000)),
+				EndTime:   client.ToPtr(int64(1577836800)),
+			},
+			expected: client.FieldErrors{
+				{Field: "end_time", Message: "end_time must be after start_time"},
+			},
+		},
+		{
+			name: "granularity too large",
+			spec: client.QuerySpec{TimeRange: client.ToPtr(120), Granularity: client.ToPtr(121)},
+			expected: client.FieldErrors{
+				{Field: "granularity", Message: "granularity can not be greater than time_range"},
+			},
+		},
+		{
+			name: "granularity too small for the absolute time range",
+			spec: client.QuerySpec{
+				StartTime:   client.ToPtr(int64(1577836800)),
+				EndTime:     client.ToPtr(int64(1577836800 + 60000)),
+				Granularity: client.ToPtr(59),
+			},
+			expected: client.FieldErrors{
+				{Field: "granularity", Message: "granularity can not be less than time_range/1000"},
+			},
+		},
+		{
+			name: "unmatched orders",
+			spec: client.QuerySpec{
+				Calculations: []client.CalculationSpec{{Op: client.CalculationOpHeatmap, Column: client.ToPtr("duration_ms")}},
+				Orders: []client.OrderSpec{
+					{Column: client.ToPtr("service.name")},
+					{Op: client.ToPtr(client.CalculationOpHeatmap), Column: client.ToPtr("duration_ms")},
+				},
+			},
+			expected: client.FieldErrors{
+				{Field: "orders[0]", Message: "missing matching calculation, formula, or breakdown"},
+				{Field: "orders[1].op", Message: "cannot order by HEATMAP"},
+			},
+		},
+		{
+			name: "unmatched havings",
+			spec: client.QuerySpec{
+				Calculations: []client.CalculationSpec{{Op: client.CalculationOpP99, Column: client.ToPtr("duration_ms")}},
+				Havings: []client.HavingSpec{
+					{CalculateOp: client.ToPtr(client.CalculationOpP95), Column: client.ToPtr("duration_ms"), Op: client.ToPtr(client.HavingOpGreaterThan), Value: 100},
+					{Column: client.ToPtr("error_rate"), Op: client.ToPtr(client.HavingOpGreaterThan), Value: 0.1},
+				},
+			},
+			expected: client.FieldErrors{
+				{Field: "havings[0].calculate_op", Message: "P95 missing matching calculation"},
+				{Field: "havings[1].column", Message: "having must reference a named calculation or formula"},
+			},
+		},
+		{
+			name: "invalid compare offset",
+			spec: client.QuerySpec{TimeRange: client.ToPtr(7200), CompareTimeOffsetSeconds: client.ToPtr(3600)},
+			expected: client.FieldErrors{
+				{Field: "compare_time_offset_seconds", Message: "compare_time_offset must be greater than the queries time range."},
+			},
+		},
+		{
+			name: "unsupported compare offset",
+			spec: client.QuerySpec{TimeRange: client.ToPtr(7200), CompareTimeOffsetSeconds: client.ToPtr(10000)},
+			expected: client.FieldErrors{
+				{Field: "compare_time_offset_seconds", Message: "compare_time_offset is an invalid value. Valid values are: 1800, 3600, 7200, 28800, 86400, 604800, 2419200, 15724800"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.spec.Validate()
+			if tt.expected == nil {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorIs(t, err, client.ErrValidation)
+			assert.Equal(t, tt.expected, err)
+		})
+	}
+}
+
 func TestCalculationOp_ColumnCategories(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,543 @@
+package client
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// ParseQueryText compiles a query written in Honeycomb's query builder
+// terms into a QuerySpec.
+//
+// A query is made up of the following clauses, each of which is optional
+// and may appear at most once, in any order:
+//
+//	VISUALIZE COUNT, P99(duration_ms)
+//	WHERE service.name = api AND status_code >= 500
+//	GROUP BY route, status_code
+//	ORDER BY COUNT DESC, route
+//	HAVING P99(duration_ms) > 1000
+//	LIMIT 50
+//	TIME 2h
+//	GRANULARITY 1m
+//
+// Calculations are any of CalculationOps, optionally applied to a column.
+// Filters are a column and any of FilterOps, followed by a value unless the
+// operator is unary, or a parenthesized list of values for the in and
+// not-in operators. Filters may be combined with AND or OR, but not both.
+// Havings are a calculation, any of HavingOps and a number, combined with AND.
+//
+// Keywords and operators are case-insensitive. Values are numbers, true or
+// false, or strings, which must be double-quoted if they contain spaces or
+// punctuation, or would otherwise be read as a keyword or number. Columns
+// may be quoted with backticks for the same reasons. Durations are a whole
+// number of seconds, optionally suffixed with a unit of s, m, h, d or w.
+//
+// The returned error is a *QueryTextError locating the problem.
+func ParseQueryText(text string) (*QuerySpec, error) {
+	tokens, err := tokenizeQueryText(text)
+	if err != nil {
+		return nil, err
+	}
+	p := &queryTextParser{tokens: tokens, end: len(text)}
+	return p.parse()
+}
+
+// QueryTextError is a problem with the text of a query, and where it is.
+type QueryTextError struct {
+	// Pos is the byte offset of the problem in the text.
+	Pos int
+	// Msg describes the problem.
+	Msg string
+}
+
+func (e *QueryTextError) Error() string {
+	return fmt.Sprintf("query text at position %d: %s", e.Pos+1, e.Msg)
+}
+
+type queryTextTokenKind int
+
+const (
+	tokenWord queryTextTokenKind = iota
+	tokenString
+	tokenQuotedColumn
+	tokenOperator
+	tokenPunct
+)
+
+type queryTextToken struct {
+	kind queryTextTokenKind
+	text string
+	pos  int
+}
+
+// operatorChars are the characters making up the symbolic operators.
+const operatorChars = "=!<>"
+
+func tokenizeQueryText(text string) ([]queryTextToken, error) {
+	var tokens []queryTextToken
+	for i := 0; i < len(text); {
+		c, size := utf8.DecodeRuneInString(text[i:])
+		switch {
+		case unicode.IsSpace(c):
+			i += size
+		case c == '(' || c == ')' || c == ',':
+			tokens = append(tokens, queryTextToken{kind: tokenPunct, text: string(c), pos: i})
+			i++
+		case c == '"':
+			end := i + 1
+			for end < len(text) && text[end] != '"' {
+				if text[end] == '\\' {
+					end++
+				}
+				end++
+			}
+			if end >= len(text) {
+				return nil, &QueryTextError{Pos: i, Msg: "unterminated string"}
+			}
+			s, err := strconv.Unquote(text[i : end+1])
+			if err != nil {
+				return nil, &QueryTextError{Pos: i, Msg: "invalid string: " + err.Error()}
+			}
+			tokens = append(tokens, queryTextToken{kind: tokenString, text: s, pos: i})
+			i = end + 1
+		case c == '`':
+			end := strings.IndexByte(text[i+1:], '`')
+			if end < 0 {
+				return nil, &QueryTextError{Pos: i, Msg: "unterminated column"}
+			}
+			tokens = append(tokens, queryTextToken{kind: tokenQuotedColumn, text: text[i+1 : i+1+end], pos: i})
+			i += end + 2
+		case strings.ContainsRune(operatorChars, c):
+			end := i
+			for end < len(text) && strings.IndexByte(operatorChars, text[end]) >= 0 {
+				end++
+			}
+			tokens = append(tokens, queryTextToken{kind: tokenOperator, text: text[i:end], pos: i})
+			i = end
+		default:
+			end := i
+			for end < len(text) {
+				r, size := utf8.DecodeRuneInString(text[end:])
+				if unicode.IsSpace(r) || strings.ContainsRune(`(),"`+"`"+operatorChars, r) {
+					break
+				}
+				end += size
+			}
+			tokens = append(tokens, queryTextToken{kind: tokenWord, text: text[i:end], pos: i})
+			i = end
+		}
+	}
+	return tokens, nil
+}
+
+// queryTextKeywords are the words which must be quoted to be used as
+// columns or values.
+var queryTextKeywords = []string{
+	"VISUALIZE", "WHERE", "GROUP", "ORDER", "BY", "HAVING", "LIMIT", "TIME",
+	"GRANULARITY", "AND", "OR", "ASC", "DESC",
+}
+
+type queryTextParser struct {
+	tokens []queryTextToken
+	i      int
+	// end is the length of the text, where errors about running out of
+	// tokens are reported
+	end int
+}
+
+func (p *queryTextParser) peek() (queryTextToken, bool) {
+	if p.i >= len(p.tokens) {
+		return queryTextToken{}, false
+	}
+	return p.tokens[p.i], true
+}
+
+func (p *queryTextParser) next() (queryTextToken, bool) {
+	t, ok := p.peek()
+	if ok {
+		p.i++
+	}
+	return t, ok
+}
+
+func (p *queryTextParser) errorf(format string, args ...any) error {
+	pos := p.end
+	if t, ok := p.peek(); ok {
+		pos = t.pos
+	}
+	return &QueryTextError{Pos: pos, Msg: fmt.Sprintf(format, args...)}
+}
+
+// peekKeyword reports whether the next token is the keyword.
+func (p *queryTextParser) peekKeyword(keyword string) bool {
+	t, ok := p.peek()
+	return ok && t.kind == tokenWord && strings.EqualFold(t.text, keyword)
+}
+
+// acceptKeyword consumes the next token if it is the keyword.
+func (p *queryTextParser) acceptKeyword(keyword string) bool {
+	if p.peekKeyword(keyword) {
+		p.i++
+		return true
+	}
+	return false
+}
+
+func (p *queryTextParser) expectKeyword(keyword string) error {
+	if !p.acceptKeyword(keyword) {
+		return p.errorf("expected %s", keyword)
+	}
+	return nil
+}
+
+// acceptPunct consumes the next token if it is the punctuation.
+func (p *queryTextParser) acceptPunct(punct string) bool {
+	if t, ok := p.peek(); ok && t.kind == tokenPunct && t.text == punct {
+		p.i++
+		return true
+	}
+	return false
+}
+
+func (p *queryTextParser) expectPunct(punct string) error {
+	if !p.acceptPunct(punct) {
+		return p.errorf("expected %q", punct)
+	}
+	return nil
+}
+
+func (p *queryTextParser) parse() (*QuerySpec, error) {
+	qs := &QuerySpec{}
+	seen := make(map[string]bool)
+
+	for {
+		t, ok := p.peek()
+		if !ok {
+			return qs, nil
+		}
+		if t.kind != tokenWord {
+			return nil, p.errorf("expected a clause but found %q", t.text)
+		}
+		clause := strings.ToUpper(t.text)
+		if seen[clause] {
+			return nil, p.errorf("%s may only be given once", clause)
+		}
+		seen[clause] = true
+		p.i++
+
+		var err error
+		switch clause {
+		case "VISUALIZE":
+			qs.Calculations, err = parseList(p, p.parseCalculation)
+		case "WHERE":
+			qs.Filters, qs.FilterCombination, err = p.parseFilters()
+		case "GROUP":
+			if err = p.expectKeyword("BY"); err == nil {
+				qs.Breakdowns, err = parseList(p, p.parseColumn)
+			}
+		case "ORDER":
+			if err = p.expectKeyword("BY"); err == nil {
+				qs.Orders, err = parseList(p, p.parseOrder)
+			}
+		case "HAVING":
+			qs.Havings, err = p.parseHavings()
+		case "LIMIT":
+			var n int
+			if n, err = p.parseInt(); err == nil {
+				qs.Limit = &n
+			}
+		case "TIME":
+			var d int
+			if d, err = p.parseDuration(); err == nil {
+				qs.TimeRange = &d
+			}
+		case "GRANULARITY":
+			var d int
+			if d, err = p.parseDuration(); err == nil {
+				qs.Granularity = &d
+			}
+		default:
+			p.i--
+			return nil, p.errorf("unknown clause %q", t.text)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+// parseList parses one or more comma-separated items.
+func parseList[T any](p *queryTextParser, parseItem func() (T, error)) ([]T, error) {
+	var items []T
+	for {
+		item, err := parseItem()
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+		if !p.acceptPunct(",") {
+			return items, nil
+		}
+	}
+}
+
+func (p *queryTextParser) parseColumn() (string, error) {
+	t, ok := p.peek()
+	switch {
+	case !ok:
+		return "", p.errorf("expected a column")
+	case t.kind == tokenQuotedColumn:
+	case t.kind == tokenWord && !slices.ContainsFunc(queryTextKeywords, func(k string) bool {
+		return strings.EqualFold(k, t.text)
+	}):
+	default:
+		return "", p.errorf("expected a column but found %q", t.text)
+	}
+	p.i++
+	return t.text, nil
+}
+
+// parseCalculationOp consumes the next token if it is a calculation operator.
+func (p *queryTextParser) parseCalculationOp() (CalculationOp, bool) {
+	t, ok := p.peek()
+	if !ok || t.kind != tokenWord {
+		return "", false
+	}
+	op := CalculationOp(strings.ToUpper(t.text))
+	if !slices.Contains(CalculationOps(), op) {
+		return "", false
+	}
+	p.i++
+	return op, true
+}
+
+func (p *queryTextParser) parseCalculation() (CalculationSpec, error) {
+	op, ok := p.parseCalculationOp()
+	if !ok {
+		return CalculationSpec{}, p.errorf("expected a calculation")
+	}
+	calc := CalculationSpec{Op: op}
+	if p.acceptPunct("(") {
+		column, err := p.parseColumn()
+		if err != nil {
+			return calc, err
+		}
+		calc.Column = &column
+		if err := p.expectPunct(")"); err != nil {
+			return calc, err
+		}
+	}
+	return calc, nil
+}
+
+func (p *queryTextParser) parseFilters() ([]FilterSpec, FilterCombination, error) {
+	var filters []FilterSpec
+	var combination FilterCombination
+	for {
+		f, err := p.parseFilter()
+		if err != nil {
+			return nil, "", err
+		}
+		filters = append(filters, f)
+
+		var c FilterCombination
+		switch {
+		case p.peekKeyword("AND"):
+			c = FilterCombinationAnd
+		case p.peekKeyword("OR"):
+			c = FilterCombinationOr
+		default:
+			// AND is the default, and is left unset as the API leaves it unset
+			if combination == FilterCombinationAnd {
+				combination = ""
+			}
+			return filters, combination, nil
+		}
+		if combination != "" && c != combination {
+			return nil, "", p.errorf("filters can not be combined with both AND and OR")
+		}
+		combination = c
+		p.i++
+	}
+}
+
+func (p *queryTextParser) parseFilter() (FilterSpec, error) {
+	column, err := p.parseColumn()
+	if err != nil {
+		return FilterSpec{}, err
+	}
+	f := FilterSpec{Column: column}
+
+	t, ok := p.next()
+	if ok && (t.kind == tokenOperator || t.kind == tokenWord) {
+		f.Op = FilterOpFromString(strings.ToLower(t.text))
+	}
+	if f.Op == "" {
+		if ok {
+			p.i--
+		}
+		return f, p.errorf("expected a filter operator")
+	}
+
+	switch {
+	case f.Op.IsUnary():
+	case f.Op.IsArray():
+		if err := p.expectPunct("("); err != nil {
+			return f, err
+		}
+		values, err := parseList(p, func() (any, error) { return p.parseValue(false) })
+		if err != nil {
+			return f, err
+		}
+		if err := p.expectPunct(")"); err != nil {
+			return f, err
+		}
+		f.Value = values
+	default:
+		if f.Value, err = p.parseValue(f.Op.IsString()); err != nil {
+			return f, err
+		}
+	}
+	return f, nil
+}
+
+// parseValue parses a value, which is always a string if asString is set.
+func (p *queryTextParser) parseValue(asString bool) (any, error) {
+	t, ok := p.peek()
+	switch {
+	case ok && t.kind == tokenString:
+		p.i++
+		return t.text, nil
+	case ok && t.kind == tokenWord && !slices.ContainsFunc(queryTextKeywords, func(k string) bool {
+		return strings.EqualFold(k, t.text)
+	}):
+		p.i++
+	default:
+		return nil, p.errorf("expected a value")
+	}
+
+	if asString {
+		return t.text, nil
+	}
+	if b, err := strconv.ParseBool(t.text); err == nil && (t.text == "true" || t.text == "false") {
+		return b, nil
+	}
+	if n, err := strconv.ParseInt(t.text, 10, 64); err == nil {
+		return n, nil
+	}
+	if f, err := strconv.ParseFloat(t.text, 64); err == nil {
+		return f, nil
+	}
+	return t.text, nil
+}
+
+func (p *queryTextParser) parseOrder() (OrderSpec, error) {
+	var o OrderSpec
+	if op, ok := p.parseCalculationOp(); ok {
+		o.Op = &op
+		if p.acceptPunct("(") {
+			column, err := p.parseColumn()
+			if err != nil {
+				return o, err
+			}
+			o.Column = &column
+			if err := p.expectPunct(")"); err != nil {
+				return o, err
+			}
+		}
+	} else {
+		column, err := p.parseColumn()
+		if err != nil {
+			return o, err
+		}
+		o.Column = &column
+	}
+
+	switch {
+	case p.acceptKeyword("DESC"):
+		o.Order = ToPtr(SortOrderDesc)
+	case p.acceptKeyword("ASC"):
+		// ascending is the default, and is left unset as the API leaves it unset
+	}
+	return o, nil
+}
+
+func (p *queryTextParser) parseHavings() ([]HavingSpec, error) {
+	var havings []HavingSpec
+	for {
+		calc, err := p.parseCalculation()
+		if err != nil {
+			return nil, err
+		}
+		h := HavingSpec{CalculateOp: &calc.Op, Column: calc.Column}
+
+		t, ok := p.peek()
+		if !ok || t.kind != tokenOperator || !slices.Contains(HavingOps(), HavingOp(t.text)) {
+			return nil, p.errorf("expected a having operator")
+		}
+		p.i++
+		h.Op = ToPtr(HavingOp(t.text))
+
+		v, err := p.parseValue(false)
+		if err != nil {
+			return nil, err
+		}
+		switch v.(type) {
+		case int64, float64:
+		default:
+			p.i--
+			return nil, p.errorf("expected a number")
+		}
+		h.Value = v
+		havings = append(havings, h)
+
+		if !p.acceptKeyword("AND") {
+			return havings, nil
+		}
+	}
+}
+
+func (p *queryTextParser) parseInt() (int, error) {
+	t, ok := p.peek()
+	if !ok || t.kind != tokenWord {
+		return 0, p.errorf("expected a number")
+	}
+	n, err := strconv.Atoi(t.text)
+	if err != nil {
+		return 0, p.errorf("expected a number but found %q", t.text)
+	}
+	p.i++
+	return n, nil
+}
+
+// durationUnits are the number of seconds in each of the units of a duration.
+var durationUnits = map[byte]int{
+	's': 1,
+	'm': 60,
+	'h': 60 * 60,
+	'd': 24 * 60 * 60,
+	'w': 7 * 24 * 60 * 60,
+}
+
+func (p *queryTextParser) parseDuration() (int, error) {
+	t, ok := p.peek()
+	if !ok || t.kind != tokenWord {
+		return 0, p.errorf("expected a duration")
+	}
+	digits, unit := t.text, 1
+	if n := len(digits); n > 0 {
+		if u, ok := durationUnits[digits[n-1]]; ok {
+			digits, unit = digits[:n-1], u
+		}
+	}
+	n, err := strconv.Atoi(digits)
+	if err != nil || n <= 0 {
+		return 0, p.errorf("expected a duration but found %q", t.text)
+	}
+	p.i++
+	return n * unit, nil
+}
@@ -0,0 +1,156 @@
+package client_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/honeycombio/terraform-provider-honeycombio/client"
+)
+
+func TestParseQueryText(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		text     string
+		expected *client.QuerySpec
+	}{
+		{
+			name:     "empty",
+			text:     "",
+			expected: &client.QuerySpec{},
+		},
+		{
+			name: "full query",
+			text: "VISUALIZE COUNT, P99(duration_ms) WHERE service.name = api AND status_code >= 500 " +
+				"GROUP BY route ORDER BY COUNT DESC LIMIT 50 TIME 2h",
+			expected: &client.QuerySpec{
+				Calculations: []client.CalculationSpec{
+					{Op: client.CalculationOpCount},
+					{Op: client.CalculationOpP99, Column: client.ToPtr("duration_ms")},
+				},
+				Filters: []client.FilterSpec{
+					{Column: "service.name", Op: client.FilterOpEquals, Value: "api"},
+					{Column: "status_code", Op: client.FilterOpGreaterThanOrEqual, Value: int64(500)},
+				},
+				Breakdowns: []string{"route"},
+				Orders: []client.OrderSpec{
+					{Op: client.ToPtr(client.CalculationOpCount), Order: client.ToPtr(client.SortOrderDesc)},
+				},
+				Limit:     client.ToPtr(50),
+				TimeRange: client.ToPtr(7200),
+			},
+		},
+		{
+			name: "non-ASCII unquoted values",
+			text: "WHERE name = Åsa AND city = voilà",
+			expected: &client.QuerySpec{
+				Filters: []client.FilterSpec{
+					{Column: "name", Op: client.FilterOpEquals, Value: "Åsa"},
+					{Column: "city", Op: client.FilterOpEquals, Value: "voilà"},
+				},
+			},
+		},
+		{
+			name: "case-insensitive keywords and any clause order",
+			text: "time 30m where error exists or `http status` != \"OK\" visualize heatmap(duration_ms) granularity 60",
+			expected: &client.QuerySpec{
+				Calculations: []client.CalculationSpec{
+					{Op: client.CalculationOpHeatmap, Column: client.ToPtr("duration_ms")},
+				},
+				Filters: []client.FilterSpec{
+					{Column: "error", Op: client.FilterOpExists},
+					{Column: "http status", Op: client.FilterOpNotEquals, Value: "OK"},
+				},
+				FilterCombination: client.FilterCombinationOr,
+				TimeRange:         client.ToPtr(1800),
+				Granularity:       client.ToPtr(60),
+			},
+		},
+		{
+			name: "filter values",
+			text: `WHERE a in (1, 2.5, x) AND b = true AND c starts-with 123 AND d does-not-exist AND e = "and"`,
+			expected: &client.QuerySpec{
+				Filters: []client.FilterSpec{
+					{Column: "a", Op: client.FilterOpIn, Value: []any{int64(1), 2.5, "x"}},
+					{Column: "b", Op: client.FilterOpEquals, Value: true},
+					{Column: "c", Op: client.FilterOpStartsWith, Value: "123"},
+					{Column: "d", Op: client.FilterOpDoesNotExist},
+					{Column: "e", Op: client.FilterOpEquals, Value: "and"},
+				},
+			},
+		},
+		{
+			name: "orders and havings",
+			text: "VISUALIZE AVG(duration_ms), COUNT GROUP BY name ORDER BY name, AVG(duration_ms) ASC " +
+				"HAVING AVG(duration_ms) > 100 AND COUNT >= 10",
+			expected: &client.QuerySpec{
+				Calculations: []client.CalculationSpec{
+					{Op: client.CalculationOpAvg, Column: client.ToPtr("duration_ms")},
+					{Op: client.CalculationOpCount},
+				},
+				Breakdowns: []string{"name"},
+				Orders: []client.OrderSpec{
+					{Column: client.ToPtr("name")},
+					{Op: client.ToPtr(client.CalculationOpAvg), Column: client.ToPtr("duration_ms")},
+				},
+				Havings: []client.HavingSpec{
+					{
+						CalculateOp: client.ToPtr(client.CalculationOpAvg),
+						Column:      client.ToPtr("duration_ms"),
+						Op:          client.ToPtr(client.HavingOpGreaterThan),
+						Value:       int64(100),
+					},
+					{
+						CalculateOp: client.ToPtr(client.CalculationOpCount),
+						Op:          client.ToPtr(client.HavingOpGreaterThanOrEqual),
+						Value:       int64(10),
+					},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			qs, err := client.ParseQueryText(tt.text)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, qs)
+		})
+	}
+}
+
+func TestParseQueryText_Errors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		text     string
+		expected string
+	}{
+		{"VISUALIZE", "query text at position 10: expected a calculation"},
+		{"VISUALIZE FOO(bar)", "query text at position 11: expected a calculation"},
+		{"VISUALIZE COUNT VISUALIZE MAX(a)", "query text at position 17: VISUALIZE may only be given once"},
+		{"SELECT COUNT", `query text at position 1: unknown clause "SELECT"`},
+		{"WHERE a = 1 AND b = 2 OR c = 3", "query text at position 23: filters can not be combined with both AND and OR"},
+		{"WHERE a ~ 1", "query text at position 9: expected a filter operator"},
+		{"WHERE a in 1", `query text at position 12: expected "("`},
+		{`WHERE a = "unterminated`, "query text at position 11: unterminated string"},
+		{"GROUP route", "query text at position 7: expected BY"},
+		{"GROUP BY where", `query text at position 10: expected a column but found "where"`},
+		{"HAVING COUNT > x", "query text at position 16: expected a number"},
+		{"HAVING COUNT exists 1", "query text at position 14: expected a having operator"},
+		{"LIMIT ten", `query text at position 7: expected a number but found "ten"`},
+		{"TIME 2y", `query text at position 6: expected a duration but found "2y"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			_, err := client.ParseQueryText(tt.text)
+			var textErr *client.QueryTextError
+			require.ErrorAs(t, err, &textErr)
+			assert.EqualError(t, err, tt.expected)
+		})
+	}
+}
@@ -0,0 +1,140 @@
+package client
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"slices"
+
+	cleanhttp "github.com/hashicorp/go-cleanhttp"
+)
+
+// Environment variables which may be used to configure how the clients
+// connect to the Honeycomb API.
+const (
+	DefaultCACertFileEnv     = "HONEYCOMB_CA_CERT_FILE"
+	DefaultClientCertFileEnv = "HONEYCOMB_CLIENT_CERT_FILE"
+	DefaultClientKeyFileEnv  = "HONEYCOMB_CLIENT_KEY_FILE"
+	DefaultProxyURLEnv       = "HONEYCOMB_PROXY_URL"
+	DefaultTLSMinVersionEnv  = "HONEYCOMB_TLS_MIN_VERSION"
+)
+
+// tlsVersions maps the supported TLS minimum versions to their values in
+// crypto/tls.
+var tlsVersions = map[string]uint16{
+	"1.2": tls.VersionTLS12,
+	"1.3": tls.VersionTLS13,
+}
+
+// TLSVersions returns an exhaustive list of the TLS minimum versions
+// accepted by TransportConfig.
+func TLSVersions() []string {
+	versions := make([]string, 0, len(tlsVersions))
+	for v := range tlsVersions {
+		versions = append(versions, v)
+	}
+	slices.Sort(versions)
+	return versions
+}
+
+// TransportConfig describes how to connect to the Honeycomb API, for
+// networks where the defaults will not do, such as those with an egress
+// proxy intercepting TLS.
+type TransportConfig struct {
+	// Path to a PEM-encoded bundle of CA certificates to trust in addition
+	// to the system's.
+	CACertFile string
+	// Paths to a PEM-encoded client certificate and its key, used for mutual
+	// TLS. Either both or neither must be set.
+	ClientCertFile string
+	ClientKeyFile  string
+	// URL of a proxy to send requests through. By default, the proxy is
+	// taken from the HTTPS_PROXY and NO_PROXY environment variables.
+	ProxyURL string
+	// The minimum version of TLS to accept, one of TLSVersions.
+	// Defaults to the crypto/tls default.
+	TLSMinVersion string
+}
+
+// TransportConfigFromEnv returns a TransportConfig populated from the
+// environment variables.
+func TransportConfigFromEnv() TransportConfig {
+	return TransportConfig{
+		CACertFile:     os.Getenv(DefaultCACertFileEnv),
+		ClientCertFile: os.Getenv(DefaultClientCertFileEnv),
+		ClientKeyFile:  os.Getenv(DefaultClientKeyFileEnv),
+		ProxyURL:       os.Getenv(DefaultProxyURLEnv),
+		TLSMinVersion:  os.Getenv(DefaultTLSMinVersionEnv),
+	}
+}
+
+// IsZero reports whether the TransportConfig leaves everything at its
+// default.
+func (c TransportConfig) IsZero() bool {
+	return c == TransportConfig{}
+}
+
+// NewHTTPClient returns a pooled HTTP client which connects as described,
+// suitable for the HTTPClient of Config.
+func (c TransportConfig) NewHTTPClient() (*http.Client, error) {
+	httpClient := cleanhttp.DefaultPooledClient()
+	transport, ok := httpClient.Transport.(*http.Transport)
+	if !ok {
+		return nil, errors.New("unexpected transport type")
+	}
+	if transport.TLSClientConfig == nil {
+		transport.TLSClientConfig = &tls.Config{}
+	}
+	tlsConfig := transport.TLSClientConfig
+
+	if c.CACertFile != "" {
+		pem, err := os.ReadFile(c.CACertFile)
+		if err != nil {
+			return nil, fmt.Errorf("could not read CA certificates: %w", err)
+		}
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no CA certificates found in %q", c.CACertFile)
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if (c.ClientCertFile == "") != (c.ClientKeyFile == "") {
+		return nil, errors.New("a client certificate and key must be configured together")
+	}
+	if c.ClientCertFile != "" {
+		cert, err := tls.LoadX509KeyPair(c.ClientCertFile, c.ClientKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("could not load client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	if c.TLSMinVersion != "" {
+		v, ok := tlsVersions[c.TLSMinVersion]
+		if !ok {
+			return nil, fmt.Errorf("unsupported TLS minimum version %q, must be one of %v", c.TLSMinVersion, TLSVersions())
+		}
+		tlsConfig.MinVersion = v
+	}
+
+	if c.ProxyURL != "" {
+		proxyURL, err := url.Parse(c.ProxyURL)
+		if err != nil {
+			return nil, fmt.Errorf("could not parse proxy URL: %w", err)
+		}
+		if proxyURL.Scheme == "" || proxyURL.Host == "" {
+			return nil, fmt.Errorf("invalid proxy URL %q, must include a scheme and host", c.ProxyURL)
+		}
+		transport.Proxy = http.ProxyURL(proxyURL)
+	}
+
+	return httpClient, nil
+}
@@ -0,0 +1,164 @@
+package client_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/honeycombio/terraform-provider-honeycombio/client"
+)
+
+func TestTransportConfig_NewHTTPClient(t *testing.T) {
+	t.Parallel()
+
+	ok := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	t.Run("defaults", func(t *testing.T) {
+		c, err := client.TransportConfig{}.NewHTTPClient()
+		require.NoError(t, err)
+		assert.NotNil(t, c.Transport)
+	})
+
+	t.Run("trusts the CA bundle", func(t *testing.T) {
+		srv := httptest.NewTLSServer(ok)
+		t.Cleanup(srv.Close)
+
+		c, err := client.TransportConfig{}.NewHTTPClient()
+		require.NoError(t, err)
+		_, err = c.Get(srv.URL)
+		require.Error(t, err, "the test server's certificate should not be trusted by default")
+
+		caFile := writePEM(t, "ca.pem", "CERTIFICATE", srv.Certificate().Raw)
+		c, err = client.TransportConfig{CACertFile: caFile}.NewHTTPClient()
+		require.NoError(t, err)
+		resp, err := c.Get(srv.URL)
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+	})
+
+	t.Run("presents the client certificate", func(t *testing.T) {
+		var presented int
+		srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			presented = len(r.TLS.PeerCertificates)
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		srv.TLS = &tls.Config{ClientAuth: tls.RequireAnyClientCert}
+		srv.StartTLS()
+		t.Cleanup(srv.Close)
+
+		certFile, keyFile := writeClientCert(t)
+		c, err := client.TransportConfig{
+			CACertFile:     writePEM(t, "ca.pem", "CERTIFICATE", srv.Certificate().Raw),
+			ClientCertFile: certFile,
+			ClientKeyFile:  keyFile,
+		}.NewHTTPClient()
+		require.NoError(t, err)
+		resp, err := c.Get(srv.URL)
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, 1, presented)
+	})
+
+	t.Run("enforces the TLS minimum version", func(t *testing.T) {
+		srv := httptest.NewUnstartedServer(ok)
+		srv.TLS = &tls.Config{MaxVersion: tls.VersionTLS12}
+		srv.StartTLS()
+		t.Cleanup(srv.Close)
+
+		caFile := writePEM(t, "ca.pem", "CERTIFICATE", srv.Certificate().Raw)
+		c, err := client.TransportConfig{CACertFile: caFile, TLSMinVersion: "1.3"}.NewHTTPClient()
+		require.NoError(t, err)
+		_, err = c.Get(srv.URL)
+		require.Error(t, err)
+	})
+
+	t.Run("sends requests through the proxy", func(t *testing.T) {
+		var proxied string
+		proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			proxied = r.URL.String()
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		t.Cleanup(proxy.Close)
+
+		c, err := client.TransportConfig{ProxyURL: proxy.URL}.NewHTTPClient()
+		require.NoError(t, err)
+		resp, err := c.Get("http://api.honeycomb.invalid/1/auth")
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, "http://api.honeycomb.invalid/1/auth", proxied)
+	})
+
+	t.Run("invalid configuration", func(t *testing.T) {
+		for name, cfg := range map[string]client.TransportConfig{
+			"missing CA file":         {CACertFile: filepath.Join(t.TempDir(), "missing.pem")},
+			"CA file without certs":   {CACertFile: writePEM(t, "empty.pem", "NOTHING", []byte("x"))},
+			"certificate without key": {ClientCertFile: "cert.pem"},
+			"unsupported TLS version": {TLSMinVersion: "1.1"},
+			"proxy URL without host":  {ProxyURL: "proxy.example.com"},
+		} {
+			t.Run(name, func(t *testing.T) {
+				_, err := cfg.NewHTTPClient()
+				require.Error(t, err)
+			})
+		}
+	})
+}
+
+func TestTransportConfigFromEnv(t *testing.T) {
+	t.Setenv(client.DefaultCACertFileEnv, "ca.pem")
+	t.Setenv(client.DefaultProxyURLEnv, "http://proxy:3128")
+	t.Setenv(client.DefaultTLSMinVersionEnv, "1.3")
+
+	assert.Equal(t, client.TransportConfig{
+		CACertFile:    "ca.pem",
+		ProxyURL:      "http://proxy:3128",
+		TLSMinVersion: "1.3",
+	}, client.TransportConfigFromEnv())
+}
+
+func writePEM(t *testing.T, name, blockType string, der []byte) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}), 0o600))
+	return path
+}
+
+// writeClientCert writes a self-signed client certificate and its key,
+// returning their paths.
+func writeClientCert(t *testing.T) (string, string) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "terraform"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	cert, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+
+	return writePEM(t, "client.pem", "CERTIFICATE", cert), writePEM(t, "client-key.pem", "PRIVATE KEY", keyDER)
+}
@@ -5,6 +5,9 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"time"
+
+	"github.com/honeycombio/terraform-provider-honeycombio/client/internal/limits"
 )
 
 // Triggers describes all the trigger-related methods that the Honeycomb API
@@ -20,6 +23,10 @@ type Triggers interface {
 
 	// Create a new trigger in this dataset. When creating a new trigger ID
 	// may not be set.
+	//
+	// If an attempt fails without it being known whether the API created the
+	// trigger, a trigger matching t by name, description, frequency and
+	// threshold is looked for and returned before the create is retried.
 	Create(ctx context.Context, dataset string, t *Trigger) (*Trigger, error)
 
 	// Update an existing trigger. Missing (optional) fields will set to their
@@ -85,6 +92,9 @@ type Trigger struct {
 	// Tags are used to categorize triggers. They can be used to filtering triggers
 	// and are useful for grouping triggers together.
 	Tags []Tag `json:"tags"`
+
+	// Read only
+	CreatedAt *time.Time `json:"created_at,omitempty"`
 }
 
 type TriggerBaselineDetails struct {
@@ -187,9 +197,36 @@ func (s *triggers) Get(ctx context.Context, dataset string, id string) (*Trigger
 }
 
 func (s *triggers) Create(ctx context.Context, dataset string, data *Trigger) (*Trigger, error) {
-	var t Trigger
-	err := s.client.Do(ctx, "POST", fmt.Sprintf("/1/triggers/%s", urlEncodeDataset(dataset)), data, &t)
-	return &t, err
+	return limits.CreateOnce(ctx, s.client.httpClient,
+		func(ctx context.Context) (*Trigger, error) {
+			var t Trigger
+			err := s.client.Do(ctx, "POST", fmt.Sprintf("/1/triggers/%s", urlEncodeDataset(dataset)), data, &t)
+			return &t, err
+		},
+		func(ctx context.Context, since time.Time) (*Trigger, error) {
+			existing, err := s.List(ctx, dataset)
+			if err != nil {
+				return nil, err
+			}
+			for _, t := range existing {
+				if t.matchesCreated(data, since) {
+					return &t, nil
+				}
+			}
+			return nil, nil
+		},
+	)
+}
+
+// matchesCreated returns true if the trigger looks to have been created
+// from data by an attempt made at or after since.
+func (t *Trigger) matchesCreated(data *Trigger, since time.Time) bool {
+	if t.Name != data.Name {
+		return false
+	}
+	// an identical trigger may have been created by someone else before,
+	// so only consider those created since the attempt
+	return t.CreatedAt != nil && limits.CreatedSince(*t.CreatedAt, since)
 }
 
 func (s *triggers) Update(ctx context.Context, dataset string, data *Trigger) (*Trigger, error) {
@@ -2,12 +2,15 @@ package v2
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/hashicorp/jsonapi"
 
 	hnyclient "github.com/honeycombio/terraform-provider-honeycombio/client"
+	"github.com/honeycombio/terraform-provider-honeycombio/client/internal/limits"
 )
 
 // Compile-time proof of interface implementation.
@@ -30,11 +33,29 @@ type apiKeys struct {
 	client *Client
 }
 
+// Create creates an API key.
+//
+// Repeating a create after the API had acted on it would leave a duplicate
+// key behind, so after a failure which leaves that unknown the key is
+// looked up by name and environment. As its secret is only returned on
+// creation, a key found to have been created by a failed attempt is deleted
+// and the create retried, so that the returned key always has its secret.
 func (a *apiKeys) Create(ctx context.Context, k *APIKey) (*APIKey, error) {
 	slug, err := a.client.teamSlug(ctx)
 	if err != nil {
 		return nil, err
 	}
+	return limits.CreateOnce(ctx, a.client.http,
+		func(ctx context.Context) (*APIKey, error) {
+			return a.create(ctx, slug, k)
+		},
+		func(ctx context.Context, since time.Time) (*APIKey, error) {
+			return nil, a.deleteCreated(ctx, k, since)
+		},
+	)
+}
+
+func (a *apiKeys) create(ctx context.Context, slug string, k *APIKey) (*APIKey, error) {
 	r, err := a.client.Do(ctx,
 		http.MethodPost,
 		fmt.Sprintf(apiKeysPath, slug),
@@ -56,6 +77,31 @@ func (a *apiKeys) Create(ctx context.Context, k *APIKey) (*APIKey, error) {
 	return key, nil
 }
 
+// deleteCreated deletes any key with the same name and environment as k
+// which was created no earlier than since.
+func (a *apiKeys) deleteCreated(ctx context.Context, k *APIKey, since time.Time) error {
+	if k.Name == nil || k.Environment == nil {
+		return errors.New("unable to look up an API key without a name and environment")
+	}
+	pager, err := a.List(ctx, FilterName(*k.Name), FilterEnvironment(k.Environment.ID))
+	if err != nil {
+		return err
+	}
+	keys, err := pager.Collect(ctx, 0)
+	if err != nil {
+		return err
+	}
+	for _, key := range keys {
+		if key.Timestamps == nil || !limits.CreatedSince(key.Timestamps.CreatedAt, since) {
+			continue
+		}
+		if err := a.Delete(ctx, key.ID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (a *apiKeys) Get(ctx context.Context, id string) (*APIKey, error) {
 	slug, err := a.client.teamSlug(ctx)
 	if err != nil {
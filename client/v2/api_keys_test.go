@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"math"
+	"net/http"
 	"testing"
 	"time"
 
@@ -131,3 +132,83 @@ func TestClient_APIKeys_Pagination(t *testing.T) {
 		assert.True(t, pager.HasNext(), "should have more pages")
 	})
 }
+
+func TestClient_APIKeys_Fake(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	s, c := newFakeTestClient(t)
+	env, err := c.Environments.Create(ctx, &Environment{Name: "test"})
+	require.NoError(t, err)
+
+	t.Run("only returns the secret on creation", func(t *testing.T) {
+		k, err := c.APIKeys.Create(ctx, &APIKey{
+			Name:        helper.ToPtr("test"),
+			KeyType:     "ingest",
+			Environment: &Environment{ID: env.ID},
+		})
+		require.NoError(t, err)
+		assert.NotEmpty(t, k.Secret)
+		assert.Equal(t, env.ID, k.Environment.ID)
+
+		key, err := c.APIKeys.Get(ctx, k.ID)
+		require.NoError(t, err)
+		assert.Empty(t, key.Secret)
+	})
+
+	t.Run("is not duplicated when the response is lost", func(t *testing.T) {
+		s.LoseResponses("POST /2/teams/{team}/api-keys", 1)
+
+		k, err := c.APIKeys.Create(ctx, &APIKey{
+			Name:        helper.ToPtr("lost"),
+			KeyType:     "ingest",
+			Environment: &Environment{ID: env.ID},
+		})
+		require.NoError(t, err)
+		assert.NotEmpty(t, k.Secret, "should have the secret of the key")
+
+		pager, err := c.APIKeys.List(ctx, FilterName("lost"), FilterEnvironment(env.ID))
+		require.NoError(t, err)
+		keys, err := pager.Collect(ctx, 0)
+		require.NoError(t, err)
+		require.Len(t, keys, 1, "should leave only the one key behind")
+		assert.Equal(t, k.ID, keys[0].ID)
+	})
+
+	t.Run("lists filtered by type, environment and state", func(t *testing.T) {
+		other, err := c.Environments.Create(ctx, &Environment{Name: "other"})
+		require.NoError(t, err)
+		for _, k := range []*APIKey{
+			{Name: helper.ToPtr("config"), KeyType: "configuration", Environment: &Environment{ID: other.ID}},
+			{Name: helper.ToPtr("ingest"), KeyType: "ingest", Environment: &Environment{ID: other.ID}},
+			{Name: helper.ToPtr("off"), KeyType: "ingest", Environment: &Environment{ID: other.ID}, Disabled: helper.ToPtr(true)},
+		} {
+			_, err := c.APIKeys.Create(ctx, k)
+			require.NoError(t, err)
+		}
+
+		pager, err := c.APIKeys.List(ctx,
+			FilterType("ingest"),
+			FilterEnvironment(other.ID),
+			FilterDisabled(false),
+		)
+		require.NoError(t, err)
+		keys, err := pager.Collect(ctx, 0)
+		require.NoError(t, err)
+		require.Len(t, keys, 1)
+		assert.Equal(t, "ingest", *keys[0].Name)
+	})
+
+	t.Run("reports the source of validation failures", func(t *testing.T) {
+		_, err := c.APIKeys.Create(ctx, &APIKey{
+			KeyType:     "ingest",
+			Environment: &Environment{ID: "nope"},
+		})
+		var de hnyclient.DetailedError
+		require.ErrorAs(t, err, &de)
+		assert.Equal(t, http.StatusUnprocessableEntity, de.Status)
+		if assert.Len(t, de.Details, 1) {
+			assert.Equal(t, "/data/relationships/environment", de.Details[0].Field)
+		}
+	})
+}
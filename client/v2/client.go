@@ -6,18 +6,20 @@ import (
 	"errors"
 	"fmt"
 	"io"
-	"log"
 	"net/http"
 	"net/url"
 	"os"
 	"sync"
 	"time"
 
+	"github.com/hashicorp/go-cleanhttp"
 	"github.com/hashicorp/go-retryablehttp"
 	"github.com/hashicorp/jsonapi"
 
 	hnyclient "github.com/honeycombio/terraform-provider-honeycombio/client"
 	"github.com/honeycombio/terraform-provider-honeycombio/client/internal/limits"
+	"github.com/honeycombio/terraform-provider-honeycombio/client/internal/logging"
+	"github.com/honeycombio/terraform-provider-honeycombio/client/internal/tracing"
 )
 
 const (
@@ -36,6 +38,26 @@ type Config struct {
 	Debug        bool
 	HTTPClient   *http.Client
 	UserAgent    string
+	// Optionally limit the number of requests made per second.
+	//
+	// Regardless of this setting, requests are slowed down as the rate limit
+	// budget reported by the API runs low.
+	MaxRequestsPerSecond float64
+	// Optionally share a RateLimiter with other clients, such as the v1
+	// client, so that all of their requests draw from the same budget.
+	// When set, MaxRequestsPerSecond is ignored in favour of the
+	// RateLimiter's own ceiling.
+	RateLimiter *hnyclient.RateLimiter
+	// Optionally override the number of times a failed request is retried,
+	// and the bounds of the wait between attempts. Default to the same as
+	// the v1 client. A RetryMax of zero disables retries.
+	RetryMax     *int
+	RetryWaitMin time.Duration
+	RetryWaitMax time.Duration
+	// Optionally override the number of results fetched per page when
+	// listing, for Pagers not given a PageSize. Defaults to 20, and may be
+	// at most MaxPageSize.
+	PageSize int
 }
 
 type Client struct {
@@ -43,7 +65,8 @@ type Client struct {
 	Headers   http.Header
 	UserAgent string
 
-	http *retryablehttp.Client
+	http     *retryablehttp.Client
+	pageSize int
 
 	// authInfo is populated on first use by teamSlug. It caches the team slug
 	// needed to construct v2 API paths so we don't do a blocking round-trip
@@ -105,34 +128,61 @@ func NewClientWithConfig(config *Config) (*Client, error) {
 			return nil, errors.New("missing API Key ID and Secret pair")
 		}
 	}
+	if config.HTTPClient == nil {
+		config.HTTPClient = cleanhttp.DefaultPooledClient()
+	}
+	if config.RetryMax == nil {
+		config.RetryMax = hnyclient.ToPtr(hnyclient.DefaultRetryMax)
+	}
+	if *config.RetryMax < 0 {
+		return nil, errors.New("the number of retries must not be negative")
+	}
+	if config.RetryWaitMin <= 0 {
+		config.RetryWaitMin = hnyclient.DefaultRetryWaitMin
+	}
+	if config.RetryWaitMax <= 0 {
+		config.RetryWaitMax = hnyclient.DefaultRetryWaitMax
+	}
+	if config.RetryWaitMin > config.RetryWaitMax {
+		return nil, errors.New("the minimum wait between retries must not be greater than the maximum")
+	}
+	if config.PageSize <= 0 {
+		config.PageSize = defaultPageSize
+	}
+	if config.PageSize > MaxPageSize {
+		return nil, fmt.Errorf("PageSize must be at most %d", MaxPageSize)
+	}
 	token := config.APIKeyID + ":" + config.APIKeySecret
 
 	client := &Client{
 		UserAgent: config.UserAgent,
 		BaseURL:   baseURL,
+		pageSize:  config.PageSize,
 		Headers: http.Header{
 			"Authorization": {"Bearer " + token},
 			"Content-Type":  {jsonapi.MediaType},
 			"User-Agent":    {config.UserAgent},
 		},
 	}
+	limiter := config.RateLimiter
+	if limiter == nil {
+		limiter = hnyclient.NewRateLimiter(config.MaxRequestsPerSecond)
+	}
+	httpClient := limits.NewHTTPClient(config.HTTPClient, limiter)
+	// every attempt is traced, including the time spent held back by the limiter
+	httpClient = tracing.NewHTTPClient(httpClient)
+	if config.Debug {
+		// if enabled we log all requests and responses, with credentials redacted
+		httpClient = logging.NewHTTPClient(httpClient)
+	}
 	client.http = &retryablehttp.Client{
 		Backoff:      limits.RetryHTTPBackoff,
 		CheckRetry:   limits.RetryHTTPCheck,
 		ErrorHandler: retryablehttp.PassthroughErrorHandler,
-		HTTPClient:   config.HTTPClient,
-		RetryWaitMin: 200 * time.Millisecond,
-		RetryWaitMax: time.Minute,
-		RetryMax:     30,
-	}
-
-	if config.Debug {
-		// if enabled we log all requests and responses to sterr
-		client.http.Logger = log.New(os.Stderr, "", log.LstdFlags)
-		client.http.ResponseLogHook = func(l retryablehttp.Logger, resp *http.Response) {
-			l.Printf("[DEBUG] Request: %s %s", resp.Request.Method, resp.Request.URL.String())
-			// TODO: Log request body
-		}
+		HTTPClient:   httpClient,
+		RetryWaitMin: config.RetryWaitMin,
+		RetryWaitMax: config.RetryWaitMax,
+		RetryMax:     *config.RetryMax,
 	}
 
 	// bind API handlers here
@@ -196,10 +246,14 @@ func (c *Client) newRequest(
 		}
 		bodyReader = buf
 	}
+	// each request is a new operation, however many times it is retried
+	ctx = logging.WithOperation(ctx)
+	ctx = tracing.WithOperation(ctx)
 	req, err := retryablehttp.NewRequestWithContext(ctx, method, url, bodyReader)
 	if err != nil {
 		return nil, err
 	}
+	req = req.WithContext(limits.WithRetryPolicy(ctx, limits.RetryPolicyFor(method, req.URL.Path)))
 	for k, h := range c.Headers {
 		req.Header[k] = append(req.Header[k], h...)
 	}
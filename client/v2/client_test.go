@@ -10,6 +10,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	hnyclient "github.com/honeycombio/terraform-provider-honeycombio/client"
+	"github.com/honeycombio/terraform-provider-honeycombio/client/fakeserver"
 	"github.com/honeycombio/terraform-provider-honeycombio/internal/helper"
 	"github.com/honeycombio/terraform-provider-honeycombio/internal/helper/test"
 )
@@ -53,6 +54,16 @@ func TestClient_Config(t *testing.T) {
 		assert.Contains(t, err.Error(), "invalid BaseURL")
 	})
 
+	t.Run("fails to construct a client with too large a page size", func(t *testing.T) {
+		_, err := NewClientWithConfig(&Config{
+			APIKeyID:     "123",
+			APIKeySecret: "456",
+			PageSize:     MaxPageSize + 1,
+		})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "PageSize")
+	})
+
 	t.Run("fails to construct a client without key id and secret pair", func(t *testing.T) {
 		t.Run("with both missing", func(t *testing.T) {
 			// load environment values from a .env, if available
@@ -136,6 +147,70 @@ func TestClient_AuthInfo(t *testing.T) {
 	})
 }
 
+func TestClient_TeamSlug(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	s, c := newFakeTestClient(t)
+
+	for range 3 {
+		_, err := c.Environments.List(ctx)
+		require.NoError(t, err)
+	}
+	assert.Equal(t, 1, s.RequestCount("GET /2/auth"), "team slug should be cached")
+
+	t.Run("does not cache errors", func(t *testing.T) {
+		s, c := newFakeTestClient(t)
+		c.Headers.Set("Authorization", "Bearer foo:bar")
+
+		_, err := c.Environments.List(ctx)
+		var de hnyclient.DetailedError
+		require.ErrorAs(t, err, &de)
+		assert.Equal(t, http.StatusUnauthorized, de.Status)
+
+		id, secret := s.APIKeyPair()
+		c.Headers.Set("Authorization", "Bearer "+id+":"+secret)
+		_, err = c.Environments.List(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 2, s.RequestCount("GET /2/auth"))
+	})
+}
+
+func TestClient_ErrorClassification(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	t.Run("unauthorized", func(t *testing.T) {
+		_, c := newFakeTestClient(t)
+		c.Headers.Set("Authorization", "Bearer foo:bar")
+
+		_, err := c.AuthInfo(ctx)
+		require.ErrorIs(t, err, hnyclient.ErrUnauthorized)
+	})
+
+	t.Run("forbidden", func(t *testing.T) {
+		_, c := newFakeTestClient(t, fakeserver.WithScopes("environments:read"))
+
+		_, err := c.APIKeys.Get(ctx, "hcxik_doesnotexist")
+		require.ErrorIs(t, err, hnyclient.ErrForbidden)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		_, c := newFakeTestClient(t)
+
+		_, err := c.Environments.Get(ctx, "hcaen_doesnotexist")
+		require.ErrorIs(t, err, hnyclient.ErrNotFound)
+	})
+
+	t.Run("validation", func(t *testing.T) {
+		_, c := newFakeTestClient(t)
+
+		_, err := c.Environments.Create(ctx, &Environment{})
+		require.ErrorIs(t, err, hnyclient.ErrValidation)
+	})
+}
+
 func newTestClient(t *testing.T) *Client {
 	t.Helper()
 
@@ -150,6 +225,26 @@ func newTestClient(t *testing.T) *Client {
 	return c
 }
 
+// newFakeTestClient returns a Client configured to use a new fake server,
+// which is closed when the test completes.
+func newFakeTestClient(t *testing.T, opts ...fakeserver.Option) (*fakeserver.Server, *Client) {
+	t.Helper()
+
+	s := fakeserver.New(opts...)
+	t.Cleanup(s.Close)
+
+	id, secret := s.APIKeyPair()
+	c, err := NewClientWithConfig(&Config{
+		APIKeyID:     id,
+		APIKeySecret: secret,
+		BaseURL:      s.URL,
+		UserAgent:    testUserAgent,
+	})
+	require.NoError(t, err, "failed to create test client")
+
+	return s, c
+}
+
 // newTestEnvironment creates a new Environment with a random name and description
 // for testing purposes.
 // The Environment is automatically deleted when the test completes.
@@ -139,3 +139,21 @@ func TestClient_Environments_Pagination(t *testing.T) {
 		assert.True(t, pager.HasNext(), "should have more pages")
 	})
 }
+
+func TestClient_Environments_Validation(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	_, c := newFakeTestClient(t)
+
+	_, err := c.Environments.Create(ctx, &Environment{
+		Color: helper.ToPtr("chartreuse"),
+	})
+	var de hnyclient.DetailedError
+	require.ErrorAs(t, err, &de)
+	assert.Equal(t, http.StatusUnprocessableEntity, de.Status)
+	if assert.Len(t, de.Details, 2) {
+		assert.Equal(t, "/data/attributes/name", de.Details[0].Field)
+		assert.Equal(t, "/data/attributes/color", de.Details[1].Field)
+	}
+}
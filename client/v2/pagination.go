@@ -5,6 +5,7 @@ import (
 	"context"
 	"encoding/json"
 	"io"
+	"iter"
 	"net/http"
 	"reflect"
 
@@ -20,18 +21,68 @@ type PaginationLinks struct {
 
 type ListOptions struct {
 	// PageSize is the number of results to return per page.
-	// Default is 20, max is 100.
+	// Default is the client's configured PageSize, max is 100.
 	PageSize int `url:"page[size],omitempty"`
+	// Filter limits the results to those matching every filter set.
+	Filter ListFilter `url:"filter,omitempty"`
+	// Sort orders the results by an attribute, such as "name", ascending
+	// or, when prefixed with "-", descending.
+	Sort string `url:"sort,omitempty"`
+}
+
+// ListFilter are the filter[...] parameters of a list request. Each is an
+// exact match, and only some are supported by each endpoint:
+//
+//   - environments can be filtered by Name
+//   - API keys can be filtered by Name, Type, Environment and Disabled
+type ListFilter struct {
+	Name        string `url:"name,omitempty"`
+	Type        string `url:"type,omitempty"`
+	Environment string `url:"environment,omitempty"`
+	Disabled    *bool  `url:"disabled,omitempty"`
 }
 
 type ListOption func(*ListOptions)
 
-const defaultPageSize = 20
+const (
+	defaultPageSize = 20
+	// MaxPageSize is the largest page size the API allows.
+	MaxPageSize = 100
+)
 
 func PageSize(size int) ListOption {
 	return func(po *ListOptions) { po.PageSize = size }
 }
 
+// FilterName limits the results to those with the name.
+func FilterName(name string) ListOption {
+	return func(po *ListOptions) { po.Filter.Name = name }
+}
+
+// FilterType limits the results to those of the type, such as an API key's
+// "ingest" or "configuration".
+func FilterType(t string) ListOption {
+	return func(po *ListOptions) { po.Filter.Type = t }
+}
+
+// FilterEnvironment limits the results to those belonging to the
+// environment with the ID.
+func FilterEnvironment(id string) ListOption {
+	return func(po *ListOptions) { po.Filter.Environment = id }
+}
+
+// FilterDisabled limits the results to those which are, or are not,
+// disabled.
+func FilterDisabled(disabled bool) ListOption {
+	return func(po *ListOptions) { po.Filter.Disabled = &disabled }
+}
+
+// Sort orders the results by the attribute, descending if it is prefixed
+// with "-".
+func Sort(attr string) ListOption {
+	return func(po *ListOptions) { po.Sort = attr }
+}
+
 type Pager[T any] struct {
 	client *Client
 	next   *string
@@ -47,6 +98,9 @@ func NewPager[T any](
 	for _, o := range os {
 		o(&opts)
 	}
+	if opts.PageSize == 0 {
+		opts.PageSize = c.pageSize
+	}
 	if opts.PageSize == 0 {
 		opts.PageSize = defaultPageSize
 	}
@@ -113,6 +167,82 @@ func (p *Pager[T]) Next(ctx context.Context) ([]*T, error) {
 	return items, nil
 }
 
+// Items returns an iterator over the results of every remaining page,
+// fetching each page as it is needed. If fetching a page fails, the error
+// is yielded and the iteration ends.
+//
+// While the results of a page are being consumed, the next page is
+// prefetched in the background. The Pager is consumed by the iteration
+// and must not otherwise be used while it is in progress.
+func (p *Pager[T]) Items(ctx context.Context) iter.Seq2[*T, error] {
+	return func(yield func(*T, error) bool) {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		var prefetch chan pageResult[T]
+		defer func() {
+			// wait for any prefetch abandoned by stopping early, so the
+			// Pager is not updated after the iteration has ended
+			if prefetch != nil {
+				cancel()
+				<-prefetch
+			}
+		}()
+
+		page, err := p.Next(ctx)
+		for {
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			if p.HasNext() {
+				prefetch = make(chan pageResult[T], 1)
+				go func() {
+					items, err := p.Next(ctx)
+					prefetch <- pageResult[T]{items: items, err: err}
+				}()
+			}
+			for _, item := range page {
+				if !yield(item, nil) {
+					return
+				}
+			}
+			if prefetch == nil {
+				return
+			}
+			next := <-prefetch
+			prefetch = nil
+			page, err = next.items, next.err
+		}
+	}
+}
+
+// Collect returns the results of every remaining page, stopping once limit
+// results have been collected. A limit of zero or less collects every
+// result.
+func (p *Pager[T]) Collect(ctx context.Context, limit int) ([]*T, error) {
+	items := make([]*T, 0)
+	if limit > 0 && limit < p.opts.PageSize {
+		items = make([]*T, 0, limit)
+	}
+	for item, err := range p.Items(ctx) {
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+		if limit > 0 && len(items) >= limit {
+			break
+		}
+	}
+	return items, nil
+}
+
+// pageResult is the outcome of prefetching a page.
+type pageResult[T any] struct {
+	items []*T
+	err   error
+}
+
 func parsePagination(r *http.Response) (*PaginationLinks, error) {
 	body, err := io.ReadAll(r.Body)
 	if err != nil {
@@ -1,11 +1,15 @@
 package v2
 
 import (
+	"context"
 	"fmt"
+	"net/http"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	hnyclient "github.com/honeycombio/terraform-provider-honeycombio/client"
 )
 
 func TestClient_Pagination(t *testing.T) {
@@ -35,3 +39,191 @@ func TestClient_Pagination(t *testing.T) {
 		assert.True(t, p.HasNext())
 	})
 }
+
+func TestClient_Pagination_ListOptions(t *testing.T) {
+	t.Parallel()
+
+	_, c := newFakeTestClient(t)
+	pagerUrl := "/2/teams/myteam/api-keys"
+
+	testCases := map[string]struct {
+		opts  []ListOption
+		query string
+	}{
+		"without filters": {
+			query: "page%5Bsize%5D=20",
+		},
+		"with filters and sort": {
+			opts: []ListOption{
+				FilterName("my key"),
+				FilterType("ingest"),
+				FilterEnvironment("hcaen_123"),
+				FilterDisabled(false),
+				Sort("-name"),
+			},
+			query: "filter%5Bdisabled%5D=false&filter%5Benvironment%5D=hcaen_123&filter%5Bname%5D=my+key&filter%5Btype%5D=ingest&page%5Bsize%5D=20&sort=-name",
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			p, err := NewPager[APIKey](c, pagerUrl, tc.opts...)
+			require.NoError(t, err)
+			assert.Equal(t, pagerUrl+"?"+tc.query, *p.next)
+		})
+	}
+}
+
+func TestClient_Pagination_MultiplePages(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	s, c := newFakeTestClient(t)
+	const listEnvs = "GET /2/teams/{team}/environments"
+
+	numEnvs := 2*defaultPageSize + 5
+	for i := range numEnvs {
+		_, err := c.Environments.Create(ctx, &Environment{
+			Name: fmt.Sprintf("test.%d", i),
+		})
+		require.NoError(t, err)
+	}
+
+	t.Run("follows next links until exhausted", func(t *testing.T) {
+		pager, err := c.Environments.List(ctx)
+		require.NoError(t, err)
+
+		var pages []int
+		envs := make([]*Environment, 0)
+		for pager.HasNext() {
+			items, err := pager.Next(ctx)
+			require.NoError(t, err)
+			pages = append(pages, len(items))
+			envs = append(envs, items...)
+		}
+		assert.Equal(t, []int{defaultPageSize, defaultPageSize, 5}, pages)
+		require.Len(t, envs, numEnvs)
+		for i, e := range envs {
+			assert.Equal(t, fmt.Sprintf("test.%d", i), e.Name, "items should be returned in order")
+		}
+	})
+
+	t.Run("returns everything in a single page when it fits", func(t *testing.T) {
+		pager, err := c.Environments.List(ctx, PageSize(100))
+		require.NoError(t, err)
+
+		items, err := pager.Next(ctx)
+		require.NoError(t, err)
+		assert.Len(t, items, numEnvs)
+		assert.False(t, pager.HasNext())
+	})
+
+	t.Run("iterates over the items of every page", func(t *testing.T) {
+		pager, err := c.Environments.List(ctx)
+		require.NoError(t, err)
+
+		var names []string
+		for e, err := range pager.Items(ctx) {
+			require.NoError(t, err)
+			names = append(names, e.Name)
+		}
+		require.Len(t, names, numEnvs)
+		for i, name := range names {
+			assert.Equal(t, fmt.Sprintf("test.%d", i), name, "items should be returned in order")
+		}
+		assert.False(t, pager.HasNext())
+	})
+
+	t.Run("stops iterating early", func(t *testing.T) {
+		pager, err := c.Environments.List(ctx)
+		require.NoError(t, err)
+
+		before := s.RequestCount(listEnvs)
+		var seen int
+		for _, err := range pager.Items(ctx) {
+			require.NoError(t, err)
+			seen++
+			if seen == 3 {
+				break
+			}
+		}
+		assert.Equal(t, 3, seen)
+		// the first page, and perhaps the second before its prefetch was
+		// cancelled, but not the third
+		assert.LessOrEqual(t, s.RequestCount(listEnvs), before+2)
+	})
+
+	t.Run("collects up to a limit", func(t *testing.T) {
+		pager, err := c.Environments.List(ctx)
+		require.NoError(t, err)
+
+		envs, err := pager.Collect(ctx, defaultPageSize+1)
+		require.NoError(t, err)
+		assert.Len(t, envs, defaultPageSize+1)
+
+		pager, err = c.Environments.List(ctx)
+		require.NoError(t, err)
+		envs, err = pager.Collect(ctx, 0)
+		require.NoError(t, err)
+		assert.Len(t, envs, numEnvs)
+	})
+
+	t.Run("uses the page size of the client", func(t *testing.T) {
+		id, secret := s.APIKeyPair()
+		sized, err := NewClientWithConfig(&Config{
+			APIKeyID:     id,
+			APIKeySecret: secret,
+			BaseURL:      s.URL,
+			PageSize:     MaxPageSize,
+		})
+		require.NoError(t, err)
+
+		pager, err := sized.Environments.List(ctx)
+		require.NoError(t, err)
+		items, err := pager.Next(ctx)
+		require.NoError(t, err)
+		assert.Len(t, items, numEnvs)
+		assert.False(t, pager.HasNext())
+	})
+
+	t.Run("yields the error of a failed page", func(t *testing.T) {
+		pager, err := c.Environments.List(ctx, PageSize(MaxPageSize+1))
+		require.NoError(t, err)
+
+		var errs int
+		for e, err := range pager.Items(ctx) {
+			assert.Nil(t, e)
+			require.Error(t, err)
+			errs++
+		}
+		assert.Equal(t, 1, errs)
+	})
+
+	t.Run("filters and sorts on the server", func(t *testing.T) {
+		pager, err := c.Environments.List(ctx, FilterName("test.7"))
+		require.NoError(t, err)
+		envs, err := pager.Collect(ctx, 0)
+		require.NoError(t, err)
+		require.Len(t, envs, 1)
+		assert.Equal(t, "test.7", envs[0].Name)
+
+		pager, err = c.Environments.List(ctx, Sort("-name"), PageSize(MaxPageSize))
+		require.NoError(t, err)
+		envs, err = pager.Collect(ctx, 0)
+		require.NoError(t, err)
+		require.Len(t, envs, numEnvs)
+		assert.Equal(t, "test.9", envs[0].Name)
+	})
+
+	t.Run("rejects an invalid page size", func(t *testing.T) {
+		pager, err := c.Environments.List(ctx, PageSize(101))
+		require.NoError(t, err)
+
+		_, err = pager.Next(ctx)
+		var de hnyclient.DetailedError
+		require.ErrorAs(t, err, &de)
+		assert.Equal(t, http.StatusBadRequest, de.Status)
+		if assert.Len(t, de.Details, 1) {
+			assert.Equal(t, "parameter page[size]", de.Details[0].Field)
+		}
+	})
+}
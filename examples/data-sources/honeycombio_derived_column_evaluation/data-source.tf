@@ -0,0 +1,42 @@
+locals {
+  sli_expression = "IF(!EXISTS($trace.parent_id), !EXISTS($error) AND $duration_ms <= 300)"
+}
+
+# Evaluate the SLI against a handful of representative events
+data "honeycombio_derived_column_evaluation" "sli" {
+  expression = local.sli_expression
+  events = [
+    jsonencode({ duration_ms = 120 }),
+    jsonencode({ duration_ms = 120, error = "timeout" }),
+    jsonencode({ duration_ms = 450 }),
+    jsonencode({ duration_ms = 450, "trace.parent_id" = "abc123" }),
+  ]
+}
+
+# Warn if the SLI no longer classifies the events as expected
+check "sli_truth_table" {
+  assert {
+    condition     = data.honeycombio_derived_column_evaluation.sli.results[0].value == "true"
+    error_message = "A fast request without an error should succeed."
+  }
+
+  assert {
+    condition     = data.honeycombio_derived_column_evaluation.sli.results[1].value == "false"
+    error_message = "A request with an error should fail."
+  }
+
+  assert {
+    condition     = data.honeycombio_derived_column_evaluation.sli.results[2].value == "false"
+    error_message = "A slow request should fail."
+  }
+
+  assert {
+    condition     = data.honeycombio_derived_column_evaluation.sli.results[3].type == "null"
+    error_message = "A child span should not be counted."
+  }
+}
+
+resource "honeycombio_derived_column" "sli" {
+  alias      = "sli.api_latency"
+  expression = local.sli_expression
+}
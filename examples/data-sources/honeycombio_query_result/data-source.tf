@@ -14,7 +14,7 @@ data "honeycombio_query_result" "example" {
 output "event_count" {
   value = format(
     "There have been %d events in the last %d seconds.",
-    data.honeycombio_query_result.example.results[0]["COUNT"],
+    data.honeycombio_query_result.example.result_values[0]["COUNT"],
     data.honeycombio_query_specification.example.time_range
   )
 }
@@ -0,0 +1,25 @@
+resource "honeycombio_api_key" "prod_ingest" {
+  name = "Production Ingest"
+  type = "ingest"
+
+  environment_id = var.environment_id
+
+  permissions {
+    create_datasets = true
+  }
+
+  # replace the key every 30 days, keeping the replaced key
+  # working for another day while its users switch over
+  rotation {
+    rotate_after = "720h"
+    overlap      = "24h"
+  }
+}
+
+output "ingest_key" {
+  value = honeycombio_api_key.prod_ingest.key
+}
+
+output "previous_ingest_key" {
+  value = honeycombio_api_key.prod_ingest.previous_key
+}
@@ -3,7 +3,11 @@ package honeycombio
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"maps"
+	"slices"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -16,6 +20,10 @@ func dataSourceHoneycombioQueryResult() *schema.Resource {
 	return &schema.Resource{
 		ReadContext: dataSourceHoneycombioQueryResultRead,
 
+		Timeouts: &schema.ResourceTimeout{
+			Read: schema.DefaultTimeout(20 * time.Minute),
+		},
+
 		Schema: map[string]*schema.Schema{
 			"dataset": {
 				Type:        schema.TypeString,
@@ -48,6 +56,81 @@ func dataSourceHoneycombioQueryResult() *schema.Resource {
 					Type: schema.TypeMap,
 				},
 			},
+			"result_values": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The values of the calculations and formulas of each row of `results`, as numbers.",
+				Elem: &schema.Schema{
+					Type: schema.TypeMap,
+					Elem: &schema.Schema{
+						Type: schema.TypeFloat,
+					},
+				},
+			},
+			"result_groups": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The value of each breakdown of each row of `results`, keyed by column. Groups with no value for a breakdown omit it.",
+				Elem: &schema.Schema{
+					Type: schema.TypeMap,
+					Elem: &schema.Schema{
+						Type: schema.TypeString,
+					},
+				},
+			},
+			"result_compare_values": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The values of the calculations and formulas of each row of `results` over the comparison time period, as numbers. Only set for queries with a `compare_time_offset`.",
+				Elem: &schema.Schema{
+					Type: schema.TypeMap,
+					Elem: &schema.Schema{
+						Type: schema.TypeFloat,
+					},
+				},
+			},
+			"result_heatmaps": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The buckets of each `HEATMAP` calculation of each row of `results`: the `result_index` of the row, the `calculation`, and its `bucket`s with their inclusive `min`, exclusive `max` and `count`.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"result_index": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The index of the row of `results` the heatmap belongs to.",
+						},
+						"calculation": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The name of the calculation, as it is keyed in `results`.",
+						},
+						"bucket": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"min": {
+										Type:        schema.TypeFloat,
+										Computed:    true,
+										Description: "The inclusive lower bound of the bucket.",
+									},
+									"max": {
+										Type:        schema.TypeFloat,
+										Computed:    true,
+										Description: "The exclusive upper bound of the bucket.",
+									},
+									"count": {
+										Type:        schema.TypeInt,
+										Computed:    true,
+										Description: "The number of events in the bucket.",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
 		},
 	}
 }
@@ -74,7 +157,16 @@ func dataSourceHoneycombioQueryResultRead(ctx context.Context, d *schema.Resourc
 		return diagFromErr(err)
 	}
 	err = client.QueryResults.Get(ctx, dataset, queryResult)
-	if err != nil {
+	var stillRunning *honeycombio.QueryStillRunningError
+	if errors.As(err, &stillRunning) {
+		return diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  "Query result did not complete in time",
+			Detail: fmt.Sprintf("Query result %q was still running after %s. "+
+				"Consider increasing the read timeout with the \"timeouts\" block.",
+				stillRunning.ID, stillRunning.Waited.Round(time.Second)),
+		}}
+	} else if err != nil {
 		return diagFromErr(err)
 	}
 
@@ -91,6 +183,12 @@ func dataSourceHoneycombioQueryResultRead(ctx context.Context, d *schema.Resourc
 		results[i] = result
 	}
 
+	typed, err := queryResult.Data.Decode(query)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	resultValues, groups, compareValues, heatmaps := flattenQueryResultRows(typed.Results, query.CompareTimeOffsetSeconds != nil)
+
 	d.SetId(queryResult.ID)
 	queryJSON, err := query.Encode()
 	if err != nil {
@@ -101,6 +199,59 @@ func dataSourceHoneycombioQueryResultRead(ctx context.Context, d *schema.Resourc
 	d.Set("query_url", queryResult.Links.Url)
 	d.Set("graph_image_url", queryResult.Links.GraphUrl)
 	d.Set("results", results)
+	d.Set("result_values", resultValues)
+	d.Set("result_groups", groups)
+	d.Set("result_compare_values", compareValues)
+	d.Set("result_heatmaps", heatmaps)
 
 	return nil
 }
+
+// flattenQueryResultRows returns the values, groups, comparison values and
+// heatmaps of the rows of a typed query result, for the attributes of the
+// same names. Comparison values are only returned if compare is true.
+func flattenQueryResultRows(rows []honeycombio.QueryResultRow, compare bool) (values, groups, compareValues, heatmaps []map[string]any) {
+	values = make([]map[string]any, len(rows))
+	groups = make([]map[string]any, len(rows))
+	if compare {
+		compareValues = make([]map[string]any, len(rows))
+	}
+	heatmaps = make([]map[string]any, 0)
+	for i, row := range rows {
+		values[i] = make(map[string]any, len(row.Values))
+		for k, v := range row.Values {
+			values[i][k] = v
+		}
+
+		groups[i] = make(map[string]any, len(row.Group))
+		for k, v := range row.Group {
+			if v != nil {
+				groups[i][k] = fmt.Sprint(v)
+			}
+		}
+
+		if compare {
+			compareValues[i] = make(map[string]any, len(row.Compare))
+			for k, v := range row.Compare {
+				compareValues[i][k] = v
+			}
+		}
+
+		for _, calc := range slices.Sorted(maps.Keys(row.Heatmaps)) {
+			buckets := make([]map[string]any, len(row.Heatmaps[calc]))
+			for j, b := range row.Heatmaps[calc] {
+				buckets[j] = map[string]any{
+					"min":   b.Min,
+					"max":   b.Max,
+					"count": int(b.Count),
+				}
+			}
+			heatmaps = append(heatmaps, map[string]any{
+				"result_index": i,
+				"calculation":  calc,
+				"bucket":       buckets,
+			})
+		}
+	}
+	return values, groups, compareValues, heatmaps
+}
@@ -5,6 +5,9 @@ import (
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/stretchr/testify/assert"
+
+	honeycombio "github.com/honeycombio/terraform-provider-honeycombio/client"
 )
 
 func TestAccDataSourceHoneycombioQueryResult_basic(t *testing.T) {
@@ -44,11 +47,57 @@ output "results" {
       ]
     )
   )
+}
+
+output "result_values" {
+  value = join(", ",
+    flatten(
+      [
+        for result in data.honeycombio_query_result.test.result_values : [for k, v in result : "${k}"]
+      ]
+    )
+  )
 }`, dataset, dataset),
 				Check: resource.ComposeTestCheckFunc(
 					resource.TestCheckOutput("results", "COUNT"),
+					resource.TestCheckOutput("result_values", "COUNT"),
 				),
 			},
 		},
 	})
 }
+
+func TestFlattenQueryResultRows(t *testing.T) {
+	t.Parallel()
+
+	rows := []honeycombio.QueryResultRow{
+		{
+			Group:    map[string]any{"service.name": "api", "http.status_code": float64(500), "error": nil},
+			Values:   map[string]float64{"COUNT": 10},
+			Compare:  map[string]float64{"COUNT": 8},
+			Heatmaps: map[string][]honeycombio.HeatmapBucket{"HEATMAP(duration_ms)": {{Min: 0, Max: 100, Count: 10}}},
+		},
+		{
+			Group:  map[string]any{"service.name": "web"},
+			Values: map[string]float64{"COUNT": 3},
+		},
+	}
+
+	values, groups, compareValues, heatmaps := flattenQueryResultRows(rows, true)
+	assert.Equal(t, []map[string]any{{"COUNT": float64(10)}, {"COUNT": float64(3)}}, values)
+	assert.Equal(t, []map[string]any{
+		{"service.name": "api", "http.status_code": "500"},
+		{"service.name": "web"},
+	}, groups)
+	assert.Equal(t, []map[string]any{{"COUNT": float64(8)}, {}}, compareValues, "should keep a row per result")
+	assert.Equal(t, []map[string]any{
+		{
+			"result_index": 0,
+			"calculation":  "HEATMAP(duration_ms)",
+			"bucket":       []map[string]any{{"min": float64(0), "max": float64(100), "count": 10}},
+		},
+	}, heatmaps)
+
+	_, _, compareValues, _ = flattenQueryResultRows(rows, false)
+	assert.Empty(t, compareValues)
+}
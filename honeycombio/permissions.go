@@ -0,0 +1,30 @@
+package honeycombio
+
+import (
+	"context"
+	"errors"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/honeycombio/terraform-provider-honeycombio/internal/helper"
+)
+
+// requireAccess returns a schema.CustomizeDiffFunc which fails the plan if
+// the API key is known to lack the access needed to manage the resource,
+// so that it is reported at plan time rather than partway through an apply.
+//
+// If the auth metadata could not be fetched when the provider was
+// configured the check always passes, leaving it to the API to refuse.
+func requireAccess(access helper.APIKeyAccess) schema.CustomizeDiffFunc {
+	return func(_ context.Context, d *schema.ResourceDiff, meta any) error {
+		c, ok := meta.(*configuredClient)
+		if !ok || c == nil || c.auth == nil || access.Granted(*c.auth) {
+			return nil
+		}
+		if d.Id() != "" && len(d.GetChangedKeysPrefix("")) == 0 {
+			// nothing to change, so nothing to be refused
+			return nil
+		}
+		return errors.New(access.Missing())
+	}
+}
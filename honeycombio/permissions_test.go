@@ -0,0 +1,51 @@
+package honeycombio
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	honeycombio "github.com/honeycombio/terraform-provider-honeycombio/client"
+)
+
+func TestRequireAccess(t *testing.T) {
+	t.Parallel()
+
+	granted := &honeycombio.AuthMetadata{}
+	granted.APIKeyAccess.Markers = true
+
+	tests := []struct {
+		name    string
+		meta    any
+		state   *terraform.InstanceState
+		wantErr bool
+	}{
+		{name: "granted", meta: &configuredClient{auth: granted}},
+		{name: "not granted", meta: &configuredClient{auth: &honeycombio.AuthMetadata{}}, wantErr: true},
+		{name: "unknown", meta: &configuredClient{}},
+		{name: "not configured", meta: nil},
+		{
+			name: "no changes",
+			meta: &configuredClient{auth: &honeycombio.AuthMetadata{}},
+			state: &terraform.InstanceState{
+				ID:         "abc123",
+				Attributes: map[string]string{"id": "abc123", "message": "deploy"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := terraform.NewResourceConfigRaw(map[string]any{"message": "deploy"})
+			_, err := newMarker().Diff(context.Background(), tt.state, config, tt.meta)
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), `"Manage Markers" permission`)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
@@ -3,10 +3,15 @@ package honeycombio
 import (
 	"context"
 	"errors"
+	"fmt"
+	"net/http"
 	"os"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 
 	honeycombio "github.com/honeycombio/terraform-provider-honeycombio/client"
 	"github.com/honeycombio/terraform-provider-honeycombio/internal/features"
@@ -50,6 +55,62 @@ func Provider(version string) *schema.Provider {
 				Optional:    true,
 				Description: "Enable the API client's debug logs. By default, a `TF_LOG` setting of debug or higher will enable this.",
 			},
+			"ca_cert_file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Path to a PEM-encoded bundle of CA certificates to trust, in addition to the system's, when connecting to the Honeycomb API. Useful when traffic passes through a proxy intercepting TLS. It can also be set via the `HONEYCOMB_CA_CERT_FILE` environment variable.",
+			},
+			"client_cert_file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Path to a PEM-encoded client certificate to present when connecting to the Honeycomb API, for mutual TLS. Must be set with `client_key_file`. It can also be set via the `HONEYCOMB_CLIENT_CERT_FILE` environment variable.",
+			},
+			"client_key_file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Path to the PEM-encoded private key of the client certificate. Must be set with `client_cert_file`. It can also be set via the `HONEYCOMB_CLIENT_KEY_FILE` environment variable.",
+			},
+			"proxy_url": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "URL of a proxy to send requests to the Honeycomb API through. By default, the proxy is taken from the `HTTPS_PROXY` and `NO_PROXY` environment variables. It can also be set via the `HONEYCOMB_PROXY_URL` environment variable.",
+			},
+			"tls_min_version": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "The minimum TLS version to accept when connecting to the Honeycomb API, one of `1.2` or `1.3`. It can also be set via the `HONEYCOMB_TLS_MIN_VERSION` environment variable.",
+				ValidateFunc: validation.StringInSlice(honeycombio.TLSVersions(), false),
+			},
+			"max_requests_per_second": {
+				Type:         schema.TypeFloat,
+				Optional:     true,
+				Description:  "The maximum number of requests per second the provider will make to the Honeycomb API. Regardless of this setting, the provider slows down as the rate limit reported by the API is approached.",
+				ValidateFunc: validation.FloatAtLeast(0),
+			},
+			"retry_max": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Description:  "The maximum number of times a failed request to the Honeycomb API is retried. Defaults to `30`, and `0` disables retries. Creating a trigger, marker, column, derived column or API key is only retried after a server error once the provider has checked the failed attempt did not create anything.",
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"retry_wait_min": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "The minimum time to wait before retrying a failed request, when the API does not say how long to wait. After a server error, the wait is between `500ms` and `950ms` times the number of attempts, kept within `retry_wait_min` and `retry_wait_max`. Defaults to `200ms`.",
+				ValidateFunc: validateDuration,
+			},
+			"retry_wait_max": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "The maximum time to wait before retrying a failed request, when the API does not say how long to wait. Defaults to `1m`.",
+				ValidateFunc: validateDuration,
+			},
+			"page_size": { // unused in the provider but required to be set for the MuxServer
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Description:  "The number of results to fetch per request when listing resources from the Management API, such as environments. Defaults to `20`, and may be at most `100`.",
+				ValidateFunc: validation.IntBetween(1, 100),
+			},
 			"features": features.GetPluginSDKFeaturesSchema(),
 		},
 		DataSourcesMap: map[string]*schema.Resource{
@@ -62,7 +123,6 @@ func Provider(version string) *schema.Provider {
 		},
 		ResourcesMap: map[string]*schema.Resource{
 			"honeycombio_dataset_definition":         newDatasetDefinition(),
-			"honeycombio_derived_column":             newDerivedColumn(),
 			"honeycombio_marker":                     newMarker(),
 			"honeycombio_marker_setting":             newMarkerSetting(),
 			"honeycombio_email_recipient":            newEmailRecipient(),
@@ -88,20 +148,59 @@ func Provider(version string) *schema.Provider {
 			debug = v.(bool)
 		}
 
+		transport := honeycombio.TransportConfigFromEnv()
+		if v, ok := d.GetOk("ca_cert_file"); ok {
+			transport.CACertFile = v.(string)
+		}
+		if v, ok := d.GetOk("client_cert_file"); ok {
+			transport.ClientCertFile = v.(string)
+		}
+		if v, ok := d.GetOk("client_key_file"); ok {
+			transport.ClientKeyFile = v.(string)
+		}
+		if v, ok := d.GetOk("proxy_url"); ok {
+			transport.ProxyURL = v.(string)
+		}
+		if v, ok := d.GetOk("tls_min_version"); ok {
+			transport.TLSMinVersion = v.(string)
+		}
+
 		// if the API key is set, use it to create the client
 		// we now rely on the Framework version of the provider to validate the configuration
 		if apiKey != "" {
+			var httpClient *http.Client
+			if !transport.IsZero() {
+				var err error
+				if httpClient, err = transport.NewHTTPClient(); err != nil {
+					return nil, diag.FromErr(err)
+				}
+			}
 			config := &honeycombio.Config{
 				APIKey:    apiKey,
 				APIUrl:    d.Get("api_url").(string),
 				UserAgent: provider.UserAgent("terraform-provider-honeycombio", version),
 				Debug:     debug,
+
+				HTTPClient: httpClient,
+
+				MaxRequestsPerSecond: d.Get("max_requests_per_second").(float64),
+			}
+			// GetOk can't tell an unset retry_max from one set to zero
+			if !d.GetRawConfig().GetAttr("retry_max").IsNull() {
+				config.RetryMax = honeycombio.ToPtr(d.Get("retry_max").(int))
+			}
+			// durations have already been validated
+			if v, ok := d.GetOk("retry_wait_min"); ok {
+				config.RetryWaitMin, _ = time.ParseDuration(v.(string))
+			}
+			if v, ok := d.GetOk("retry_wait_max"); ok {
+				config.RetryWaitMax, _ = time.ParseDuration(v.(string))
 			}
 			c, err := honeycombio.NewClientWithConfig(config)
 			if err != nil {
 				return nil, diag.FromErr(err)
 			}
-			return c, nil
+			return newConfiguredClient(ctx, c), nil
 		}
 
 		return nil, nil
@@ -110,13 +209,56 @@ func Provider(version string) *schema.Provider {
 	return provider
 }
 
+// validateDuration is a schema.SchemaValidateFunc ensuring the value is a
+// positive duration, as accepted by time.ParseDuration.
+func validateDuration(v any, key string) (warns []string, errs []error) {
+	d, err := time.ParseDuration(v.(string))
+	if err != nil {
+		errs = append(errs, fmt.Errorf("%q: %w", key, err))
+	} else if d <= 0 {
+		errs = append(errs, fmt.Errorf("%q: must be a positive duration", key))
+	}
+	return
+}
+
+// configuredClient is the v1 API client along with the auth metadata of
+// its API key, used to check the key's permissions at plan time.
+type configuredClient struct {
+	client *honeycombio.Client
+	// nil if the metadata could not be fetched
+	auth *honeycombio.AuthMetadata
+}
+
+// authMetadataTimeout bounds how long fetching the auth metadata may hold up
+// configuring the provider.
+const authMetadataTimeout = 10 * time.Second
+
+// newConfiguredClient fetches and caches the auth metadata of the client's
+// API key.
+//
+// This is best effort: should it fail, the permission checks are skipped
+// and any problem with the key is reported by the API as it's used.
+func newConfiguredClient(ctx context.Context, c *honeycombio.Client) *configuredClient {
+	ctx, cancel := context.WithTimeout(ctx, authMetadataTimeout)
+	defer cancel()
+
+	cc := &configuredClient{client: c}
+	auth, err := c.Auth.List(ctx)
+	if err != nil {
+		tflog.Debug(ctx, "Unable to fetch v1 API key auth metadata", map[string]any{"error": err.Error()})
+	} else {
+		cc.auth = &auth
+	}
+	return cc
+}
+
 func getConfiguredClient(meta any) (*honeycombio.Client, error) {
-	client, ok := meta.(*honeycombio.Client)
-	if !ok || client == nil {
+	cc, ok := meta.(*configuredClient)
+	if !ok || cc == nil || cc.client == nil {
 		//nolint:staticcheck
 		return nil, errors.New("No v1 API client configured for this provider. " +
 			"Set the `api_key` attribute in the provider's configuration, " +
 			"or set the HONEYCOMB_API_KEY environment variable.")
 	}
-	return client, nil
+	return cc.client, nil
 }
@@ -11,6 +11,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 
 	honeycombio "github.com/honeycombio/terraform-provider-honeycombio/client"
+	"github.com/honeycombio/terraform-provider-honeycombio/internal/helper"
 	"github.com/honeycombio/terraform-provider-honeycombio/internal/helper/hashcode"
 )
 
@@ -20,6 +21,7 @@ func newDatasetDefinition() *schema.Resource {
 		ReadContext:   resourceDatasetDefinitionRead,
 		UpdateContext: resourceDatasetDefinitionUpdate,
 		DeleteContext: resourceDatasetDefinitionDelete,
+		CustomizeDiff: requireAccess(helper.AccessColumns),
 		Importer:      nil,
 
 		Schema: map[string]*schema.Schema{
@@ -60,17 +62,12 @@ func resourceDatasetDefinitionRead(ctx context.Context, d *schema.ResourceData,
 
 	dataset := d.Get("dataset").(string)
 
-	var detailedErr honeycombio.DetailedError
 	dd, err := client.DatasetDefinitions.Get(ctx, dataset)
-	if errors.As(err, &detailedErr) {
-		if detailedErr.IsNotFound() {
-			d.SetId("")
-			return nil
-		} else {
-			return diagFromDetailedErr(detailedErr)
-		}
+	if errors.Is(err, honeycombio.ErrNotFound) {
+		d.SetId("")
+		return nil
 	} else if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	name := d.Get("name").(string)
@@ -9,6 +9,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 
 	honeycombio "github.com/honeycombio/terraform-provider-honeycombio/client"
+	"github.com/honeycombio/terraform-provider-honeycombio/internal/helper"
 )
 
 func newEmailRecipient() *schema.Resource {
@@ -17,6 +18,7 @@ func newEmailRecipient() *schema.Resource {
 		ReadContext:   resourceEmailRecipientRead,
 		UpdateContext: resourceEmailRecipientUpdate,
 		DeleteContext: resourceEmailRecipientDelete,
+		CustomizeDiff: requireAccess(helper.AccessRecipients),
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -9,6 +9,7 @@ import (
 
 	honeycombio "github.com/honeycombio/terraform-provider-honeycombio/client"
 	"github.com/honeycombio/terraform-provider-honeycombio/honeycombio/internal/verify"
+	"github.com/honeycombio/terraform-provider-honeycombio/internal/helper"
 )
 
 func newMarker() *schema.Resource {
@@ -17,6 +18,7 @@ func newMarker() *schema.Resource {
 		ReadContext:   resourceMarkerRead,
 		UpdateContext: nil,
 		DeleteContext: schema.NoopContext,
+		CustomizeDiff: requireAccess(helper.AccessMarkers),
 
 		Schema: map[string]*schema.Schema{
 			"message": {
@@ -78,17 +80,12 @@ func resourceMarkerRead(ctx context.Context, d *schema.ResourceData, meta any) d
 
 	dataset := getDatasetOrAll(d)
 
-	var detailedErr honeycombio.DetailedError
 	marker, err := client.Markers.Get(ctx, dataset, d.Id())
-	if errors.As(err, &detailedErr) {
-		if detailedErr.IsNotFound() {
-			d.SetId("")
-			return nil
-		} else {
-			return diagFromDetailedErr(detailedErr)
-		}
+	if errors.Is(err, honeycombio.ErrNotFound) {
+		d.SetId("")
+		return nil
 	} else if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	d.SetId(marker.ID)
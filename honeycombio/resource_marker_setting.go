@@ -12,6 +12,7 @@ import (
 
 	honeycombio "github.com/honeycombio/terraform-provider-honeycombio/client"
 	"github.com/honeycombio/terraform-provider-honeycombio/honeycombio/internal/verify"
+	"github.com/honeycombio/terraform-provider-honeycombio/internal/helper"
 )
 
 func newMarkerSetting() *schema.Resource {
@@ -20,6 +21,7 @@ func newMarkerSetting() *schema.Resource {
 		ReadContext:   resourceMarkerSettingRead,
 		UpdateContext: resourceMarkerSettingUpdate,
 		DeleteContext: resourceMarkerSettingDelete,
+		CustomizeDiff: requireAccess(helper.AccessMarkers),
 
 		Schema: map[string]*schema.Schema{
 			"type": {
@@ -89,17 +91,12 @@ func resourceMarkerSettingRead(ctx context.Context, d *schema.ResourceData, meta
 
 	dataset := getDatasetOrAll(d)
 
-	var detailedErr honeycombio.DetailedError
 	markerSetting, err := client.MarkerSettings.Get(ctx, dataset, d.Id())
-	if errors.As(err, &detailedErr) {
-		if detailedErr.IsNotFound() {
-			d.SetId("")
-			return nil
-		} else {
-			return diagFromDetailedErr(detailedErr)
-		}
+	if errors.Is(err, honeycombio.ErrNotFound) {
+		d.SetId("")
+		return nil
 	} else if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	d.SetId(markerSetting.ID)
@@ -8,6 +8,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 
 	honeycombio "github.com/honeycombio/terraform-provider-honeycombio/client"
+	"github.com/honeycombio/terraform-provider-honeycombio/internal/helper"
 )
 
 // Deprecated: MSTeams Recipient is deprecated, and does not allow creation of new recipients.
@@ -17,6 +18,7 @@ func newMSTeamsRecipient() *schema.Resource {
 		ReadContext:   resourceMSTeamsRecipientRead,
 		UpdateContext: resourceMSTeamsRecipientUpdate,
 		DeleteContext: resourceMSTeamsRecipientDelete,
+		CustomizeDiff: requireAccess(helper.AccessRecipients),
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -8,6 +8,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 
 	honeycombio "github.com/honeycombio/terraform-provider-honeycombio/client"
+	"github.com/honeycombio/terraform-provider-honeycombio/internal/helper"
 )
 
 func newMSTeamsWorkflowRecipient() *schema.Resource {
@@ -16,6 +17,7 @@ func newMSTeamsWorkflowRecipient() *schema.Resource {
 		ReadContext:   resourceMSTeamsWorkflowRecipientRead,
 		UpdateContext: resourceMSTeamsWorkflowRecipientUpdate,
 		DeleteContext: resourceMSTeamsWorkflowRecipientDelete,
+		CustomizeDiff: requireAccess(helper.AccessRecipients),
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -8,6 +8,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 
 	honeycombio "github.com/honeycombio/terraform-provider-honeycombio/client"
+	"github.com/honeycombio/terraform-provider-honeycombio/internal/helper"
 )
 
 func newPDRecipient() *schema.Resource {
@@ -16,6 +17,7 @@ func newPDRecipient() *schema.Resource {
 		ReadContext:   resourcePDRecipientRead,
 		UpdateContext: resourcePDRecipientUpdate,
 		DeleteContext: resourcePDRecipientDelete,
+		CustomizeDiff: requireAccess(helper.AccessRecipients),
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -9,6 +9,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 
 	honeycombio "github.com/honeycombio/terraform-provider-honeycombio/client"
+	"github.com/honeycombio/terraform-provider-honeycombio/internal/helper"
 )
 
 var channelRegex = regexp.MustCompile(`^#.*|^@.*|^(C|D|G|U)[A-Z0-9]{6,}$`)
@@ -19,6 +20,7 @@ func newSlackRecipient() *schema.Resource {
 		ReadContext:   resourceSlackRecipientRead,
 		UpdateContext: resourceSlackRecipientUpdate,
 		DeleteContext: resourceSlackRecipientDelete,
+		CustomizeDiff: requireAccess(helper.AccessRecipients),
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -10,6 +10,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 
 	honeycombio "github.com/honeycombio/terraform-provider-honeycombio/client"
+	"github.com/honeycombio/terraform-provider-honeycombio/internal/helper"
 )
 
 // getDatasetOrAll returns the dataset from the resource data.
@@ -75,17 +76,12 @@ func readRecipient(ctx context.Context, d *schema.ResourceData, meta any, t hone
 		return diagFromErr(err)
 	}
 
-	var detailedErr honeycombio.DetailedError
 	r, err := client.Recipients.Get(ctx, d.Id())
-	if errors.As(err, &detailedErr) {
-		if detailedErr.IsNotFound() {
-			d.SetId("")
-			return nil
-		} else {
-			return diagFromDetailedErr(detailedErr)
-		}
+	if errors.Is(err, honeycombio.ErrNotFound) {
+		d.SetId("")
+		return nil
 	} else if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	d.SetId(r.ID)
@@ -258,6 +254,13 @@ func diagFromDetailedErr(err honeycombio.DetailedError) diag.Diagnostics {
 			Detail:   err.Title,
 		})
 	}
+	if errors.Is(err, honeycombio.ErrForbidden) {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Insufficient API key permissions",
+			Detail:   helper.ForbiddenHint,
+		})
+	}
 
 	return diags
 }
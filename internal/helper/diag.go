@@ -40,11 +40,11 @@ func AddDiagnosticOnError(diag *diag.Diagnostics, summary string, err error) boo
 		return false
 	}
 
-	var detailedErr *hnyclient.DetailedError
+	var detailedErr hnyclient.DetailedError
 	if errors.As(err, &detailedErr) {
 		diag.Append(DetailedErrorDiagnostic{
 			summary: "Error " + summary,
-			e:       detailedErr,
+			e:       &detailedErr,
 		})
 	} else {
 		diag.AddError("Error "+summary, err.Error())
@@ -53,6 +53,20 @@ func AddDiagnosticOnError(diag *diag.Diagnostics, summary string, err error) boo
 }
 
 func (d DetailedErrorDiagnostic) Detail() string {
+	detail := d.detail()
+	if errors.Is(*d.e, hnyclient.ErrForbidden) {
+		detail += "\n\n" + ForbiddenHint
+	}
+	return detail
+}
+
+// ForbiddenHint is added to the detail of HTTP 403 diagnostics, as the API's
+// own messages don't always make clear that the API key is at fault.
+const ForbiddenHint = "The API key used by the provider does not have the " +
+	"permission or scope required for this operation. " +
+	"Grant the key the required access, or configure the provider with a key that has it."
+
+func (d DetailedErrorDiagnostic) detail() string {
 	if len(d.e.Details) > 0 {
 		var response string
 		for i, dt := range d.e.Details {
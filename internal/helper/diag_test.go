@@ -0,0 +1,57 @@
+package helper
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	hnyclient "github.com/honeycombio/terraform-provider-honeycombio/client"
+)
+
+func TestDiag_AddDiagnosticOnError(t *testing.T) {
+	t.Run("nil error adds nothing", func(t *testing.T) {
+		var diags diag.Diagnostics
+		assert.False(t, AddDiagnosticOnError(&diags, "Reading Thing", nil))
+		assert.Empty(t, diags)
+	})
+
+	t.Run("wrapped DetailedError becomes a DetailedErrorDiagnostic", func(t *testing.T) {
+		var diags diag.Diagnostics
+		err := fmt.Errorf("wrapped: %w", hnyclient.DetailedError{
+			Status:  http.StatusNotFound,
+			Title:   "The requested resource cannot be found.",
+			Message: "Thing not found",
+		})
+
+		require.True(t, AddDiagnosticOnError(&diags, "Reading Thing", err))
+		require.Len(t, diags, 1)
+		assert.IsType(t, DetailedErrorDiagnostic{}, diags[0])
+		assert.Equal(t, "Error Reading Thing (HTTP 404) - The requested resource cannot be found.", diags[0].Summary())
+		assert.Equal(t, "Thing not found", diags[0].Detail())
+	})
+
+	t.Run("forbidden errors explain the API key is missing access", func(t *testing.T) {
+		var diags diag.Diagnostics
+		err := hnyclient.DetailedError{
+			Status:  http.StatusForbidden,
+			Message: "API key is missing the environments:write scope",
+		}
+
+		require.True(t, AddDiagnosticOnError(&diags, "Creating Environment", err))
+		require.Len(t, diags, 1)
+		assert.Equal(t, "API key is missing the environments:write scope\n\n"+ForbiddenHint, diags[0].Detail())
+	})
+
+	t.Run("other errors are added as is", func(t *testing.T) {
+		var diags diag.Diagnostics
+
+		require.True(t, AddDiagnosticOnError(&diags, "Reading Thing", fmt.Errorf("boom")))
+		require.Len(t, diags, 1)
+		assert.Equal(t, "Error Reading Thing", diags[0].Summary())
+		assert.Equal(t, "boom", diags[0].Detail())
+	})
+}
@@ -0,0 +1,551 @@
+package expression
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Event is an event an expression is evaluated against, as decoded from
+// JSON. Nested objects can be referred to by a column of their path joined
+// by dots, such as $http.status_code.
+type Event map[string]any
+
+// ParseEvent decodes the JSON object as an Event, keeping integers distinct
+// from floats.
+func ParseEvent(data string) (Event, error) {
+	d := json.NewDecoder(strings.NewReader(data))
+	d.UseNumber()
+
+	var e Event
+	if err := d.Decode(&e); err != nil {
+		return nil, err
+	}
+	if e == nil {
+		return nil, errors.New("the event must be a JSON object")
+	}
+	return e, nil
+}
+
+// Eval evaluates the expression against the event, returning its value:
+// nil, a bool, an int64, a float64 or a string.
+//
+// The evaluator follows the documented behaviour of the functions, but is
+// not the one used by Honeycomb, so results may differ in edge cases. The
+// functions which depend on Honeycomb's processing of the event, such as
+// INGEST_TIMESTAMP, BUCKET and the timeseries functions, are not supported.
+func Eval(n Node, e Event) (any, error) {
+	switch n := n.(type) {
+	case *Literal:
+		if n.Text != "" {
+			return nil, fmt.Errorf("%s is out of range of a 64-bit integer", n.Text)
+		}
+		return n.Value, nil
+	case *Column:
+		return e.value(n.Name), nil
+	case *Call:
+		return e.call(n)
+	default:
+		return nil, fmt.Errorf("unknown node %T", n)
+	}
+}
+
+// value returns the value of the column, or nil if the event doesn't have
+// it.
+func (e Event) value(column string) any {
+	if v, ok := e[column]; ok {
+		return normalize(v)
+	}
+
+	// look the column up as the path of nested objects
+	for i := strings.IndexByte(column, '.'); i >= 0; i = nextDot(column, i) {
+		nested, ok := e[column[:i]].(map[string]any)
+		if !ok {
+			continue
+		}
+		if v := Event(nested).value(column[i+1:]); v != nil {
+			return v
+		}
+	}
+	return nil
+}
+
+func nextDot(s string, i int) int {
+	j := strings.IndexByte(s[i+1:], '.')
+	if j < 0 {
+		return -1
+	}
+	return i + 1 + j
+}
+
+// normalize converts a value decoded from JSON to one of the types of the
+// expression language. Arrays and objects are kept as their JSON encoding,
+// as Honeycomb does for those it does not unpack.
+func normalize(v any) any {
+	switch v := v.(type) {
+	case nil, bool, int64, float64, string:
+		return v
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return i
+		}
+		f, _ := v.Float64()
+		return f
+	case int:
+		return int64(v)
+	default:
+		b, err := json.Marshal(v)
+		if err != nil {
+			return nil
+		}
+		return string(b)
+	}
+}
+
+func (e Event) call(c *Call) (any, error) {
+	// the conditional functions only evaluate the arguments they need
+	switch c.Func {
+	case "IF":
+		return e.evalIf(c.Args)
+	case "SWITCH":
+		return e.evalSwitch(c.Args)
+	}
+
+	f, ok := functions[c.Func]
+	if !ok {
+		return nil, fmt.Errorf("%s is not supported by the evaluator", c.Func)
+	}
+	if len(c.Args) < f.minArgs || (f.maxArgs >= 0 && len(c.Args) > f.maxArgs) {
+		return nil, fmt.Errorf("%s: wrong number of arguments: %d", c.Func, len(c.Args))
+	}
+
+	args := make([]any, len(c.Args))
+	for i, a := range c.Args {
+		v, err := Eval(a, e)
+		if err != nil {
+			return nil, err
+		}
+		args[i] = v
+	}
+
+	v, err := f.eval(e, args)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", c.Func, err)
+	}
+	return v, nil
+}
+
+// evalIf evaluates IF(condition, value, [condition, value, ...], [else]).
+func (e Event) evalIf(args []Node) (any, error) {
+	if len(args) < 2 {
+		return nil, fmt.Errorf("IF: wrong number of arguments: %d", len(args))
+	}
+
+	for i := 0; i+1 < len(args); i += 2 {
+		cond, err := Eval(args[i], e)
+		if err != nil {
+			return nil, err
+		}
+		if truthy(cond) {
+			return Eval(args[i+1], e)
+		}
+	}
+	if len(args)%2 == 1 {
+		return Eval(args[len(args)-1], e)
+	}
+	return nil, nil
+}
+
+// evalSwitch evaluates SWITCH(value, case, result, [case, result, ...],
+// [default]).
+func (e Event) evalSwitch(args []Node) (any, error) {
+	if len(args) < 3 {
+		return nil, fmt.Errorf("SWITCH: wrong number of arguments: %d", len(args))
+	}
+
+	v, err := Eval(args[0], e)
+	if err != nil {
+		return nil, err
+	}
+	for i := 1; i+1 < len(args); i += 2 {
+		c, err := Eval(args[i], e)
+		if err != nil {
+			return nil, err
+		}
+		if equal(v, c) {
+			return Eval(args[i+1], e)
+		}
+	}
+	if len(args)%2 == 0 {
+		return Eval(args[len(args)-1], e)
+	}
+	return nil, nil
+}
+
+type function struct {
+	minArgs int
+	// maxArgs is -1 for functions which take any number of arguments
+	maxArgs int
+	eval    func(e Event, args []any) (any, error)
+}
+
+var functions map[string]function
+
+func init() {
+	functions = map[string]function{
+		"COALESCE": {1, -1, func(_ Event, args []any) (any, error) {
+			for _, a := range args {
+				if a != nil && a != "" {
+					return a, nil
+				}
+			}
+			return nil, nil
+		}},
+
+		"NOT": {1, 1, func(_ Event, args []any) (any, error) { return !truthy(args[0]), nil }},
+		"AND": {1, -1, func(_ Event, args []any) (any, error) {
+			for _, a := range args {
+				if !truthy(a) {
+					return false, nil
+				}
+			}
+			return true, nil
+		}},
+		"OR": {1, -1, func(_ Event, args []any) (any, error) {
+			for _, a := range args {
+				if truthy(a) {
+					return true, nil
+				}
+			}
+			return false, nil
+		}},
+		"EXISTS": {1, 1, func(_ Event, args []any) (any, error) { return args[0] != nil, nil }},
+
+		"LT":     compareFunc(func(c int) bool { return c < 0 }),
+		"LTE":    compareFunc(func(c int) bool { return c <= 0 }),
+		"GT":     compareFunc(func(c int) bool { return c > 0 }),
+		"GTE":    compareFunc(func(c int) bool { return c >= 0 }),
+		"EQUALS": {2, -1, evalIn},
+		"IN":     {2, -1, evalIn},
+		"!=": {2, -1, func(e Event, args []any) (any, error) {
+			in, err := evalIn(e, args)
+			return !in.(bool), err
+		}},
+
+		"MIN": {1, -1, func(_ Event, args []any) (any, error) { return extreme(args, -1) }},
+		"MAX": {1, -1, func(_ Event, args []any) (any, error) { return extreme(args, 1) }},
+
+		// all arithmetic functions return floats
+		"SUM": arithmeticFunc(1, -1, func(a, b float64) (float64, bool) { return a + b, true }),
+		"SUB": arithmeticFunc(2, 2, func(a, b float64) (float64, bool) { return a - b, true }),
+		"MUL": arithmeticFunc(1, -1, func(a, b float64) (float64, bool) { return a * b, true }),
+		"DIV": arithmeticFunc(2, 2, func(a, b float64) (float64, bool) { return a / b, b != 0 }),
+		"MOD": arithmeticFunc(2, 2, func(a, b float64) (float64, bool) { return math.Mod(a, b), b != 0 }),
+		"LOG10": {1, 1, func(_ Event, args []any) (any, error) {
+			f, ok := toFloat(args[0])
+			if !ok || f <= 0 {
+				return nil, nil
+			}
+			return math.Log10(f), nil
+		}},
+
+		"INT": {1, 1, func(_ Event, args []any) (any, error) {
+			f, ok := toFloat(args[0])
+			if !ok {
+				return nil, nil
+			}
+			return int64(f), nil
+		}},
+		"FLOAT": {1, 1, func(_ Event, args []any) (any, error) {
+			f, ok := toFloat(args[0])
+			if !ok {
+				return nil, nil
+			}
+			return f, nil
+		}},
+		"BOOL": {1, 1, func(_ Event, args []any) (any, error) {
+			if s, ok := args[0].(string); ok {
+				if b, err := strconv.ParseBool(s); err == nil {
+					return b, nil
+				}
+			}
+			return truthy(args[0]), nil
+		}},
+		"STRING": {1, 1, func(_ Event, args []any) (any, error) {
+			if args[0] == nil {
+				return nil, nil
+			}
+			return toString(args[0]), nil
+		}},
+
+		"CONCAT": {1, -1, func(_ Event, args []any) (any, error) {
+			var b strings.Builder
+			for _, a := range args {
+				if a != nil {
+					b.WriteString(toString(a))
+				}
+			}
+			return b.String(), nil
+		}},
+		"STARTS_WITH": stringPredicateFunc(strings.HasPrefix),
+		"ENDS_WITH":   stringPredicateFunc(strings.HasSuffix),
+		"CONTAINS":    stringPredicateFunc(strings.Contains),
+		"TO_LOWER": {1, 1, func(_ Event, args []any) (any, error) {
+			if args[0] == nil {
+				return nil, nil
+			}
+			return strings.ToLower(toString(args[0])), nil
+		}},
+		"LENGTH": {1, 2, func(_ Event, args []any) (any, error) {
+			if args[0] == nil {
+				return nil, nil
+			}
+			s := toString(args[0])
+			unit := "bytes"
+			if len(args) == 2 {
+				unit = toString(args[1])
+			}
+			switch unit {
+			case "bytes":
+				return int64(len(s)), nil
+			case "chars":
+				return int64(utf8.RuneCountInString(s)), nil
+			default:
+				return nil, fmt.Errorf("unknown unit %q, expected \"bytes\" or \"chars\"", unit)
+			}
+		}},
+
+		"REG_MATCH": regexpFunc(func(re *regexp.Regexp, s string) any { return re.MatchString(s) }),
+		"REG_VALUE": regexpFunc(func(re *regexp.Regexp, s string) any {
+			m := re.FindStringSubmatch(s)
+			switch {
+			case m == nil:
+				return nil
+			case len(m) > 1:
+				return m[1]
+			default:
+				return m[0]
+			}
+		}),
+		"REG_COUNT": regexpFunc(func(re *regexp.Regexp, s string) any {
+			return int64(len(re.FindAllStringIndex(s, -1)))
+		}),
+
+		"UNIX_TIMESTAMP": {1, 1, func(_ Event, args []any) (any, error) {
+			return toTimestamp(args[0]), nil
+		}},
+		"EVENT_TIMESTAMP": {0, 0, func(e Event, _ []any) (any, error) {
+			// the timestamp of an event sent to Honeycomb is not part of its
+			// data, so it is read from the "timestamp" field of the event
+			return toTimestamp(e.value("timestamp")), nil
+		}},
+	}
+}
+
+func compareFunc(ok func(int) bool) function {
+	return function{2, 2, func(_ Event, args []any) (any, error) {
+		c, comparable := compare(args[0], args[1])
+		return comparable && ok(c), nil
+	}}
+}
+
+func arithmeticFunc(minArgs, maxArgs int, op func(a, b float64) (float64, bool)) function {
+	return function{minArgs, maxArgs, func(_ Event, args []any) (any, error) {
+		result, ok := toFloat(args[0])
+		if !ok {
+			return nil, nil
+		}
+		for _, a := range args[1:] {
+			f, ok := toFloat(a)
+			if !ok {
+				return nil, nil
+			}
+			if result, ok = op(result, f); !ok {
+				return nil, nil
+			}
+		}
+		return result, nil
+	}}
+}
+
+func stringPredicateFunc(pred func(s, substr string) bool) function {
+	return function{2, 2, func(_ Event, args []any) (any, error) {
+		if args[0] == nil || args[1] == nil {
+			return false, nil
+		}
+		return pred(toString(args[0]), toString(args[1])), nil
+	}}
+}
+
+func regexpFunc(f func(re *regexp.Regexp, s string) any) function {
+	return function{2, 2, func(_ Event, args []any) (any, error) {
+		re, err := regexp.Compile(toString(args[1]))
+		if err != nil {
+			return nil, err
+		}
+		if args[0] == nil {
+			return nil, nil
+		}
+		return f(re, toString(args[0])), nil
+	}}
+}
+
+func evalIn(_ Event, args []any) (any, error) {
+	for _, a := range args[1:] {
+		if equal(args[0], a) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// extreme returns the smallest (sign -1) or largest (sign 1) of the values,
+// ignoring nulls.
+func extreme(args []any, sign int) (any, error) {
+	var result any
+	for _, a := range args {
+		if a == nil {
+			continue
+		}
+		if result == nil {
+			result = a
+			continue
+		}
+		c, ok := compare(a, result)
+		if !ok {
+			return nil, fmt.Errorf("can not compare %s and %s", toString(a), toString(result))
+		}
+		if c*sign > 0 {
+			result = a
+		}
+	}
+	return result, nil
+}
+
+// truthy returns whether the value is considered true by conditions: null,
+// false, zero and the empty string are not.
+func truthy(v any) bool {
+	switch v := v.(type) {
+	case nil:
+		return false
+	case bool:
+		return v
+	case int64:
+		return v != 0
+	case float64:
+		return v != 0
+	case string:
+		return v != ""
+	default:
+		return true
+	}
+}
+
+func equal(a, b any) bool {
+	fa, aNum := a.(float64)
+	if i, ok := a.(int64); ok {
+		fa, aNum = float64(i), true
+	}
+	fb, bNum := b.(float64)
+	if i, ok := b.(int64); ok {
+		fb, bNum = float64(i), true
+	}
+	if aNum && bNum {
+		return fa == fb
+	}
+	return a == b
+}
+
+// compare compares two numbers or two strings, returning false if the
+// values can not be compared.
+func compare(a, b any) (int, bool) {
+	if sa, ok := a.(string); ok {
+		sb, ok := b.(string)
+		if !ok {
+			return 0, false
+		}
+		return strings.Compare(sa, sb), true
+	}
+
+	fa, aOK := toNumber(a)
+	fb, bOK := toNumber(b)
+	if !aOK || !bOK {
+		return 0, false
+	}
+	switch {
+	case fa < fb:
+		return -1, true
+	case fa > fb:
+		return 1, true
+	default:
+		return 0, true
+	}
+}
+
+// toNumber returns the value of an int or float.
+func toNumber(v any) (float64, bool) {
+	switch v := v.(type) {
+	case int64:
+		return float64(v), true
+	case float64:
+		return v, true
+	default:
+		return 0, false
+	}
+}
+
+// toFloat converts the value to a float, as the arithmetic and cast
+// functions do: strings are parsed, and booleans are 1 or 0.
+func toFloat(v any) (float64, bool) {
+	switch v := v.(type) {
+	case bool:
+		if v {
+			return 1, true
+		}
+		return 0, true
+	case string:
+		f, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+		return f, err == nil
+	default:
+		return toNumber(v)
+	}
+}
+
+func toString(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case bool:
+		return strconv.FormatBool(v)
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// toTimestamp converts an RFC3339 time, or a number of seconds, to seconds
+// since the Unix epoch.
+func toTimestamp(v any) any {
+	if s, ok := v.(string); ok {
+		t, err := time.Parse(time.RFC3339Nano, s)
+		if err != nil {
+			return nil
+		}
+		return float64(t.UnixNano()) / float64(time.Second)
+	}
+	if f, ok := toNumber(v); ok {
+		return f
+	}
+	return nil
+}
@@ -0,0 +1,120 @@
+package expression_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/honeycombio/terraform-provider-honeycombio/internal/helper/expression"
+)
+
+func TestEval(t *testing.T) {
+	t.Parallel()
+
+	const event = `{
+		"duration_ms": 250,
+		"error": null,
+		"service.name": "api",
+		"http": {"status_code": 503, "route": "/users/:id"},
+		"message": "GET /users/42 took 250ms",
+		"ratio": 0.5,
+		"timestamp": "2024-01-01T00:00:00Z"
+	}`
+
+	testCases := map[string]struct {
+		expr string
+		want any
+	}{
+		"column":           {expr: `$duration_ms`, want: int64(250)},
+		"float column":     {expr: `$ratio`, want: 0.5},
+		"missing column":   {expr: `$missing`, want: nil},
+		"dotted column":    {expr: `$service.name`, want: "api"},
+		"nested column":    {expr: `$http.status_code`, want: int64(503)},
+		"object column":    {expr: `$http`, want: `{"route":"/users/:id","status_code":503}`},
+		"if":               {expr: `IF(LTE($duration_ms, 300), "fast", "slow")`, want: "fast"},
+		"if without else":  {expr: `IF(GT($duration_ms, 300), "slow")`, want: nil},
+		"if chain":         {expr: `IF(LT($duration_ms, 100), 1, LT($duration_ms, 500), 2, 3)`, want: int64(2)},
+		"switch":           {expr: `SWITCH($service.name, "web", 1, "api", 2, 0)`, want: int64(2)},
+		"switch default":   {expr: `SWITCH($service.name, "web", 1, 0)`, want: int64(0)},
+		"coalesce":         {expr: `COALESCE($missing, "", $service.name)`, want: "api"},
+		"sli":              {expr: `!EXISTS($error) AND $duration_ms <= 300`, want: true},
+		"or":               {expr: `OR($missing, $http.status_code >= 500)`, want: true},
+		"equals":           {expr: `$http.status_code = 503.0`, want: true},
+		"in":               {expr: `IN($http.status_code, 500, 502, 504)`, want: false},
+		"not equal":        {expr: `$service.name != "web"`, want: true},
+		"compare null":     {expr: `LT($missing, 1)`, want: false},
+		"compare strings":  {expr: `LT("a", "b")`, want: true},
+		"min":              {expr: `MIN($missing, 3, $duration_ms, 1.5)`, want: 1.5},
+		"max":              {expr: `MAX($duration_ms, 3)`, want: int64(250)},
+		"arithmetic":       {expr: `$duration_ms / 1000 + 1`, want: 1.25},
+		"arithmetic null":  {expr: `SUM($duration_ms, $missing)`, want: nil},
+		"divide by zero":   {expr: `DIV(1, 0)`, want: nil},
+		"mod":              {expr: `$duration_ms % 100`, want: float64(50)},
+		"log10":            {expr: `LOG10(1000)`, want: float64(3)},
+		"int":              {expr: `INT("3.7")`, want: int64(3)},
+		"float":            {expr: `FLOAT($duration_ms)`, want: float64(250)},
+		"bool":             {expr: `BOOL("false")`, want: false},
+		"string":           {expr: `STRING($ratio)`, want: "0.5"},
+		"concat":           {expr: `CONCAT($service.name, ":", $missing, $http.status_code)`, want: "api:503"},
+		"starts with":      {expr: `STARTS_WITH($message, "GET")`, want: true},
+		"ends with":        {expr: `ENDS_WITH($message, "GET")`, want: false},
+		"contains":         {expr: `CONTAINS($http.route, "users")`, want: true},
+		"to lower":         {expr: `TO_LOWER("GET")`, want: "get"},
+		"length":           {expr: `LENGTH("héllo")`, want: int64(6)},
+		"length chars":     {expr: `LENGTH("héllo", "chars")`, want: int64(5)},
+		"reg match":        {expr: `REG_MATCH($message, "^GET ")`, want: true},
+		"reg value":        {expr: "REG_VALUE($message, `took (\\d+)ms`)", want: "250"},
+		"reg value none":   {expr: `REG_VALUE($message, "POST")`, want: nil},
+		"reg count":        {expr: "REG_COUNT($message, `\\d+`)", want: int64(2)},
+		"unix timestamp":   {expr: `UNIX_TIMESTAMP("2024-01-01T00:00:01.5Z")`, want: 1704067201.5},
+		"event timestamp":  {expr: `EVENT_TIMESTAMP()`, want: float64(1704067200)},
+		"unused if branch": {expr: `IF(true, 1, INGEST_TIMESTAMP())`, want: int64(1)},
+	}
+
+	e, err := expression.ParseEvent(event)
+	require.NoError(t, err)
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			n, err := expression.Parse(tc.expr)
+			require.NoError(t, err)
+			got, err := expression.Eval(n, e)
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestEval_Errors(t *testing.T) {
+	t.Parallel()
+
+	for _, expr := range []string{
+		`INGEST_TIMESTAMP()`,
+		`BUCKET($duration_ms, 100)`,
+		`REG_MATCH($message, "(")`,
+		`LENGTH("a", "words")`,
+		`NOT(1, 2)`,
+	} {
+		n, err := expression.Parse(expr)
+		require.NoError(t, err)
+		_, err = expression.Eval(n, expression.Event{})
+		assert.Error(t, err, "expected %q to fail", expr)
+	}
+}
+
+func TestParseEvent(t *testing.T) {
+	t.Parallel()
+
+	e, err := expression.ParseEvent(`{"a": 1, "b": 1.5}`)
+	require.NoError(t, err)
+	assert.Equal(t, expression.Event{"a": json.Number("1"), "b": json.Number("1.5")}, e)
+
+	for _, data := range []string{``, `null`, `[1]`, `{"a": 1`} {
+		_, err := expression.ParseEvent(data)
+		assert.Error(t, err, "expected %q to be invalid", data)
+	}
+}
@@ -0,0 +1,234 @@
+// Package expression parses derived column and calculated field expressions
+// into a syntax tree which is independent of how the expression was
+// formatted.
+package expression
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/antlr4-go/antlr/v4"
+	dcparser "github.com/honeycombio/honeycomb-derived-column-validator/pkg/parser"
+)
+
+// Node is a node of the syntax tree of an expression: a Call, Column or
+// Literal.
+//
+// The String form of a node is canonical: two nodes are equal if their
+// String forms are.
+type Node interface {
+	String() string
+}
+
+// Call is a call of a function, such as IF($a, 1, 2). Infix operators are
+// written as a call of their function, so `$a < 1` is a call of LT.
+type Call struct {
+	Func string
+	Args []Node
+}
+
+// Column is a reference to a column, such as $duration_ms.
+type Column struct {
+	Name string
+}
+
+// Literal is a literal value: nil, a bool, an int64, a float64 or a string.
+type Literal struct {
+	Value any
+	// Text is the integer as written if it is out of the range of an int64,
+	// in which case Value is nil. The validator rejects such integers, so
+	// this only keeps the node faithful to the expression should it not.
+	Text string
+}
+
+// operators maps the infix operators to the function they are written as.
+//
+// `!=` has no function, so is kept as an operator.
+var operators = map[string]string{
+	"!":   "NOT",
+	"*":   "MUL",
+	"/":   "DIV",
+	"%":   "MOD",
+	"+":   "SUM",
+	"-":   "SUB",
+	"<":   "LT",
+	"<=":  "LTE",
+	">":   "GT",
+	">=":  "GTE",
+	"=":   "EQUALS",
+	"!=":  "!=",
+	"AND": "AND",
+	"OR":  "OR",
+}
+
+// Parse parses the expression, returning an error if it is invalid.
+//
+// The expression is validated by the derived column validator, and the
+// syntax tree is built from its parse tree: whitespace and redundant
+// parentheses are dropped, operators are written as functions, and columns
+// and strings are unquoted.
+func Parse(expr string) (Node, error) {
+	if _, err := dcparser.ANTLRParse(expr, false); err != nil {
+		return nil, err
+	}
+
+	lexer := dcparser.NewHCDCLexer(antlr.NewInputStream(expr))
+	lexer.RemoveErrorListeners()
+	p := dcparser.NewHCDCParser(antlr.NewCommonTokenStream(lexer, antlr.TokenDefaultChannel))
+	p.RemoveErrorListeners()
+
+	return buildExpr(p.Derived().Expr()), nil
+}
+
+// Equivalent returns true if both expressions are valid and their syntax
+// trees are the same, such as when they differ only in formatting.
+func Equivalent(a, b string) bool {
+	if a == b {
+		return true
+	}
+
+	na, err := Parse(a)
+	if err != nil {
+		return false
+	}
+	nb, err := Parse(b)
+	if err != nil {
+		return false
+	}
+	return na.String() == nb.String()
+}
+
+func buildExpr(ctx dcparser.IExprContext) Node {
+	c := ctx.(*dcparser.ExprContext)
+
+	if op := c.GetOp(); op != nil {
+		call := &Call{Func: operators[op.GetText()]}
+		for _, e := range c.AllExpr() {
+			call.Args = append(call.Args, buildExpr(e))
+		}
+		return call
+	}
+
+	switch {
+	case c.Fun() != nil:
+		return buildFun(c.Fun().(*dcparser.FunContext))
+	case c.Column() != nil:
+		return buildColumn(c.Column().(*dcparser.ColumnContext))
+	case c.Literal() != nil:
+		return buildLiteral(c.Literal().(*dcparser.LiteralContext))
+	default:
+		// a parenthesized expression
+		return buildExpr(c.Expr(0))
+	}
+}
+
+func buildFun(c *dcparser.FunContext) Node {
+	call := &Call{Func: c.Funcname().GetText()}
+	for params := c.Params(); params != nil; params = params.(*dcparser.ParamsContext).Params() {
+		call.Args = append(call.Args, buildExpr(params.(*dcparser.ParamsContext).Expr()))
+	}
+	return call
+}
+
+func buildColumn(c *dcparser.ColumnContext) Node {
+	switch {
+	case c.STRING() != nil:
+		return &Column{Name: unquote(c.STRING().GetText())}
+	case c.RAWSTRING() != nil:
+		return &Column{Name: unquote(c.RAWSTRING().GetText())}
+	default:
+		return &Column{Name: strings.TrimPrefix(c.COLUMN().GetText(), "$")}
+	}
+}
+
+func buildLiteral(c *dcparser.LiteralContext) Node {
+	switch {
+	case c.INT() != nil:
+		text := c.INT().GetText()
+		if c.OP_MATH_MINUS() != nil {
+			text = "-" + text
+		}
+		v, err := strconv.ParseInt(text, 10, 64)
+		if err != nil {
+			return &Literal{Text: text}
+		}
+		return &Literal{Value: v}
+	case c.FLOAT() != nil:
+		v, _ := strconv.ParseFloat(c.FLOAT().GetText(), 64)
+		if c.OP_MATH_MINUS() != nil {
+			v = -v
+		}
+		return &Literal{Value: v}
+	case c.TRUE() != nil:
+		return &Literal{Value: true}
+	case c.FALSE() != nil:
+		return &Literal{Value: false}
+	case c.NULL() != nil:
+		return &Literal{Value: nil}
+	case c.STRING() != nil:
+		return &Literal{Value: unquote(c.STRING().GetText())}
+	default:
+		return &Literal{Value: unquote(c.RAWSTRING().GetText())}
+	}
+}
+
+// unquote unquotes a string or raw string token, which the validator has
+// already checked.
+func unquote(s string) string {
+	if strings.HasPrefix(s, "`") {
+		return s[1 : len(s)-1]
+	}
+	v, _ := strconv.Unquote(s)
+	return v
+}
+
+func (c *Call) String() string {
+	args := make([]string, len(c.Args))
+	for i, a := range c.Args {
+		args[i] = a.String()
+	}
+
+	if c.Func == "!=" {
+		return "(" + strings.Join(args, " != ") + ")"
+	}
+	return c.Func + "(" + strings.Join(args, ", ") + ")"
+}
+
+func (c *Column) String() string {
+	if c.Name == "" {
+		return `$""`
+	}
+	for _, r := range c.Name {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) && !strings.ContainsRune("_./:=+?-", r) {
+			return "$" + strconv.Quote(c.Name)
+		}
+	}
+	return "$" + c.Name
+}
+
+func (l *Literal) String() string {
+	if l.Text != "" {
+		return l.Text
+	}
+	switch v := l.Value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return strconv.FormatBool(v)
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case float64:
+		// keep floats distinct from ints of the same value
+		s := strconv.FormatFloat(v, 'g', -1, 64)
+		if !strings.ContainsAny(s, ".eEn") {
+			s += ".0"
+		}
+		return s
+	case string:
+		return strconv.Quote(v)
+	default:
+		return fmt.Sprint(v)
+	}
+}
@@ -0,0 +1,156 @@
+package expression_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/honeycombio/terraform-provider-honeycombio/internal/helper/expression"
+)
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		expr string
+		want string
+	}{
+		"function": {
+			expr: `IF(EXISTS($trace.parent_id), 1, 0)`,
+			want: `IF(EXISTS($trace.parent_id), 1, 0)`,
+		},
+		"whitespace and line breaks": {
+			expr: "IF(\n  EXISTS( $trace.parent_id ),\n  1,\n  0,\n)",
+			want: `IF(EXISTS($trace.parent_id), 1, 0)`,
+		},
+		"infix operators": {
+			expr: `!EXISTS($error) AND $duration_ms <= 300`,
+			want: `AND(NOT(EXISTS($error)), LTE($duration_ms, 300))`,
+		},
+		"parentheses": {
+			expr: `(($a + 1)) * 2`,
+			want: `MUL(SUM($a, 1), 2)`,
+		},
+		"not equal": {
+			expr: `$status != "ok"`,
+			want: `($status != "ok")`,
+		},
+		"quoted columns": {
+			expr: "CONCAT($\"service.name\", $`http route`)",
+			want: `CONCAT($service.name, $"http route")`,
+		},
+		"raw strings": {
+			expr: "CONCAT(`a`, \"b\")",
+			want: `CONCAT("a", "b")`,
+		},
+		"literals": {
+			expr: `COALESCE(-1, 1.0, .5, true, false, null)`,
+			want: `COALESCE(-1, 1.0, 0.5, true, false, null)`,
+		},
+		"largest integers": {
+			expr: `SUM(-9223372036854775807, 9223372036854775807)`,
+			want: `SUM(-9223372036854775807, 9223372036854775807)`,
+		},
+		"bare column": {
+			expr: `$duration_ms`,
+			want: `$duration_ms`,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			n, err := expression.Parse(tc.expr)
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, n.String())
+		})
+	}
+}
+
+func TestLiteral_OutOfRange(t *testing.T) {
+	t.Parallel()
+
+	l := &expression.Literal{Text: "9223372036854775808"}
+	assert.Equal(t, "9223372036854775808", l.String())
+	_, err := expression.Eval(l, expression.Event{})
+	assert.Error(t, err)
+}
+
+func TestParse_Invalid(t *testing.T) {
+	t.Parallel()
+
+	for _, expr := range []string{
+		``,
+		`BOOL(1`,
+		`FOOBAR(1)`,
+		`IF(EXISTS($a), 1, 0),`,
+	} {
+		_, err := expression.Parse(expr)
+		assert.Error(t, err, "expected %q to be invalid", expr)
+	}
+}
+
+func TestEquivalent(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		a, b string
+		want bool
+	}{
+		"identical": {
+			a:    `BOOL(1)`,
+			b:    `BOOL(1)`,
+			want: true,
+		},
+		"reformatted": {
+			a:    `IF(AND(NOT(EXISTS($trace.parent_id)),EXISTS($duration_ms)),LTE($duration_ms,300))`,
+			b:    "IF(\n  AND(\n    NOT(EXISTS($trace.parent_id)),\n    EXISTS($duration_ms)\n  ),\n  LTE($duration_ms, 300)\n)\n",
+			want: true,
+		},
+		"quoting style": {
+			a:    "EQUALS($`service.name`, `api`)",
+			b:    `EQUALS($service.name, "api")`,
+			want: true,
+		},
+		"operator and function": {
+			a:    `$duration_ms <= 300`,
+			b:    `LTE($duration_ms, 300)`,
+			want: true,
+		},
+		"different column": {
+			a:    `LTE($duration_ms, 300)`,
+			b:    `LTE($duration, 300)`,
+			want: false,
+		},
+		"different literal": {
+			a:    `LTE($duration_ms, 300)`,
+			b:    `LTE($duration_ms, 301)`,
+			want: false,
+		},
+		"int and float": {
+			a:    `LTE($duration_ms, 300)`,
+			b:    `LTE($duration_ms, 300.0)`,
+			want: false,
+		},
+		"different grouping": {
+			a:    `$a + $b + $c`,
+			b:    `$a + ($b + $c)`,
+			want: false,
+		},
+		"invalid": {
+			a:    `BOOL(1`,
+			b:    `BOOL(1 )`,
+			want: false,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tc.want, expression.Equivalent(tc.a, tc.b))
+		})
+	}
+}
@@ -0,0 +1,200 @@
+package helper
+
+import (
+	"errors"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+
+	hnyclient "github.com/honeycombio/terraform-provider-honeycombio/client"
+)
+
+// FieldPaths maps the fields named in the details of API errors to the
+// paths of the resource attributes they were set from, allowing validation
+// errors to be reported against the offending attribute.
+//
+// Keys are API field names in dotted form with any list indexes written
+// as `[]`, such as "panels[].query_panel.query_id". JSON:API source pointers
+// are matched by their location beneath the resource object's attributes or
+// relationships: "/data/attributes/permissions/send_events" is matched by
+// "permissions.send_events".
+//
+// Values are attribute paths in the same form, where each `[]` is replaced by
+// the next list index of the API field and `[N]` is a fixed list index,
+// such as "panel[].query_panel[0].query_id".
+//
+// Fields without an entry of their own are mapped by their closest parent
+// field which has one.
+type FieldPaths map[string]string
+
+// fieldTokenRegex matches the names and list indexes of a dotted field name.
+var fieldTokenRegex = regexp.MustCompile(`[^.\[\]]+|\[\d*\]`)
+
+// jsonPointerUnescaper reverses the escaping of JSON Pointer (RFC6901) tokens.
+var jsonPointerUnescaper = strings.NewReplacer("~1", "/", "~0", "~")
+
+// PathFor returns the attribute path the API field maps to.
+func (f FieldPaths) PathFor(field string) (path.Path, bool) {
+	names, indexes := parseErrorField(field)
+	if len(names) == 0 {
+		return path.Empty(), false
+	}
+
+	// walk back up the field until we find a mapped parent
+	for i := len(names); i > 0; i-- {
+		if tmpl, ok := f[strings.Join(names[:i], "")]; ok {
+			var n int
+			for _, name := range names[:i] {
+				if name == "[]" {
+					n++
+				}
+			}
+			return expandPathTemplate(tmpl, indexes[:n])
+		}
+	}
+
+	return path.Empty(), false
+}
+
+// AddDiagnosticOnError is a helper function which will take an error
+// and a context-specific summary of the action.
+//
+// It behaves like the package's AddDiagnosticOnError, except that the
+// details of a client.DetailedError naming a mapped field are each added
+// as an attribute error against the attribute the field maps to, as are
+// the problems found by client-side validation.
+func (f FieldPaths) AddDiagnosticOnError(diags *diag.Diagnostics, summary string, err error) bool {
+	if err == nil {
+		return false
+	}
+
+	var fieldErrs hnyclient.FieldErrors
+	if errors.As(err, &fieldErrs) {
+		for _, fe := range fieldErrs {
+			if p, ok := f.PathFor(fe.Field); ok {
+				diags.AddAttributeError(p, "Error "+summary, fe.Message)
+			} else {
+				diags.AddError("Error "+summary, fe.Error())
+			}
+		}
+		return true
+	}
+
+	var detailedErr hnyclient.DetailedError
+	if !errors.As(err, &detailedErr) {
+		return AddDiagnosticOnError(diags, summary, err)
+	}
+
+	d := DetailedErrorDiagnostic{
+		summary: "Error " + summary,
+		e:       &detailedErr,
+	}
+	var unmapped []hnyclient.ErrorTypeDetail
+	for _, dt := range detailedErr.Details {
+		p, ok := f.PathFor(dt.Field)
+		if !ok {
+			unmapped = append(unmapped, dt)
+			continue
+		}
+
+		detail := dt.Description
+		if detail == "" {
+			detail = dt.Code
+		}
+		diags.AddAttributeError(p, d.Summary(), detail)
+	}
+
+	if len(unmapped) == len(detailedErr.Details) {
+		// nothing could be mapped, so report the error as a whole
+		diags.Append(d)
+		return true
+	}
+	if len(unmapped) > 0 {
+		detailedErr.Details = unmapped
+		diags.Append(d)
+	}
+
+	return true
+}
+
+// parseErrorField splits the field named by an API error into a sequence of
+// names, with list indexes replaced by `[]`, and the list indexes.
+func parseErrorField(field string) ([]string, []int) {
+	var tokens []string
+	if pointer, ok := strings.CutPrefix(field, "/"); ok {
+		for _, prefix := range []string{"data/attributes/", "data/relationships/"} {
+			if rest, found := strings.CutPrefix(pointer, prefix); found {
+				pointer = rest
+				break
+			}
+		}
+		for _, t := range strings.Split(pointer, "/") {
+			t = jsonPointerUnescaper.Replace(t)
+			if _, err := strconv.Atoi(t); err == nil {
+				t = "[" + t + "]"
+			}
+			tokens = append(tokens, t)
+		}
+	} else {
+		tokens = fieldTokenRegex.FindAllString(field, -1)
+	}
+
+	names := make([]string, 0, len(tokens))
+	indexes := make([]int, 0)
+	for _, t := range tokens {
+		if t == "" {
+			continue
+		}
+		if strings.HasPrefix(t, "[") {
+			i, err := strconv.Atoi(strings.Trim(t, "[]"))
+			if err != nil {
+				continue
+			}
+			names = append(names, "[]")
+			indexes = append(indexes, i)
+			continue
+		}
+		if len(names) > 0 {
+			t = "." + t
+		}
+		names = append(names, t)
+	}
+
+	return names, indexes
+}
+
+// expandPathTemplate builds the path described by a FieldPaths value,
+// filling each `[]` with the next of the indexes.
+//
+// If the indexes run out, the path stops at the list itself.
+func expandPathTemplate(tmpl string, indexes []int) (path.Path, bool) {
+	tokens := fieldTokenRegex.FindAllString(tmpl, -1)
+	if len(tokens) == 0 || strings.HasPrefix(tokens[0], "[") {
+		return path.Empty(), false
+	}
+
+	p := path.Root(tokens[0])
+	for _, t := range tokens[1:] {
+		switch {
+		case t == "[]":
+			if len(indexes) == 0 {
+				return p, true
+			}
+			p = p.AtListIndex(indexes[0])
+			indexes = indexes[1:]
+		case strings.HasPrefix(t, "["):
+			i, err := strconv.Atoi(strings.Trim(t, "[]"))
+			if err != nil {
+				return path.Empty(), false
+			}
+			p = p.AtListIndex(i)
+		default:
+			p = p.AtName(t)
+		}
+	}
+
+	return p, true
+}
@@ -0,0 +1,177 @@
+package helper
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	hnyclient "github.com/honeycombio/terraform-provider-honeycombio/client"
+)
+
+var testFieldPaths = FieldPaths{
+	"name":                             "name",
+	"query":                            "query_json",
+	"threshold.op":                     "threshold[0].op",
+	"panels":                           "panel",
+	"panels[].query_panel.query_id":    "panel[].query_panel[0].query_id",
+	"panels[].charts[].chart_type":     "panel[].chart[].chart_type",
+	"permissions":                      "permissions[0]",
+	"permissions.manage_privateBoards": "permissions[0].manage_private_boards",
+	"environment":                      "environment_id",
+}
+
+func TestFieldPaths_PathFor(t *testing.T) {
+	testCases := []struct {
+		field    string
+		expected path.Path
+		ok       bool
+	}{
+		{
+			field:    "name",
+			expected: path.Root("name"),
+			ok:       true,
+		},
+		{
+			field:    "threshold.op",
+			expected: path.Root("threshold").AtListIndex(0).AtName("op"),
+			ok:       true,
+		},
+		{
+			field:    "query.calculations[1].column",
+			expected: path.Root("query_json"),
+			ok:       true,
+		},
+		{
+			field:    "panels[2].query_panel.query_id",
+			expected: path.Root("panel").AtListIndex(2).AtName("query_panel").AtListIndex(0).AtName("query_id"),
+			ok:       true,
+		},
+		{
+			field:    "panels[1].charts[3].chart_type",
+			expected: path.Root("panel").AtListIndex(1).AtName("chart").AtListIndex(3).AtName("chart_type"),
+			ok:       true,
+		},
+		{
+			field:    "panels[4].slo_panel.slo_id",
+			expected: path.Root("panel"),
+			ok:       true,
+		},
+		{
+			field:    "/data/attributes/permissions",
+			expected: path.Root("permissions").AtListIndex(0),
+			ok:       true,
+		},
+		{
+			field:    "/data/attributes/permissions/manage_privateBoards",
+			expected: path.Root("permissions").AtListIndex(0).AtName("manage_private_boards"),
+			ok:       true,
+		},
+		{
+			field:    "/data/relationships/environment/data/id",
+			expected: path.Root("environment_id"),
+			ok:       true,
+		},
+		{field: "description"},
+		{field: "parameter page[size]"},
+		{field: "Authorization header"},
+		{field: ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.field, func(t *testing.T) {
+			p, ok := testFieldPaths.PathFor(tc.field)
+			assert.Equal(t, tc.ok, ok)
+			if tc.ok {
+				assert.Equal(t, tc.expected, p)
+			}
+		})
+	}
+}
+
+func TestFieldPaths_AddDiagnosticOnError(t *testing.T) {
+	t.Run("mapped details become attribute errors", func(t *testing.T) {
+		var diags diag.Diagnostics
+		err := hnyclient.DetailedError{
+			Status: http.StatusUnprocessableEntity,
+			Title:  "The provided input is invalid.",
+			Details: []hnyclient.ErrorTypeDetail{
+				{Code: "missing", Field: "name", Description: "cannot be blank"},
+				{Code: "invalid", Field: "panels[0].query_panel.query_id", Description: "query not found"},
+			},
+		}
+
+		require.True(t, testFieldPaths.AddDiagnosticOnError(&diags, "Creating Thing", err))
+		require.Len(t, diags, 2)
+		for i, expected := range []struct {
+			path   path.Path
+			detail string
+		}{
+			{path.Root("name"), "cannot be blank"},
+			{path.Root("panel").AtListIndex(0).AtName("query_panel").AtListIndex(0).AtName("query_id"), "query not found"},
+		} {
+			d, ok := diags[i].(diag.DiagnosticWithPath)
+			require.True(t, ok, "expected an attribute diagnostic")
+			assert.Equal(t, expected.path, d.Path())
+			assert.Equal(t, "Error Creating Thing (HTTP 422) - The provided input is invalid.", d.Summary())
+			assert.Equal(t, expected.detail, d.Detail())
+		}
+	})
+
+	t.Run("unmapped details are reported together", func(t *testing.T) {
+		var diags diag.Diagnostics
+		err := hnyclient.DetailedError{
+			Status: http.StatusUnprocessableEntity,
+			Details: []hnyclient.ErrorTypeDetail{
+				{Code: "missing", Field: "name", Description: "cannot be blank"},
+				{Code: "invalid", Field: "unknown", Description: "is invalid"},
+			},
+		}
+
+		require.True(t, testFieldPaths.AddDiagnosticOnError(&diags, "Creating Thing", err))
+		require.Len(t, diags, 2)
+		assert.Implements(t, (*diag.DiagnosticWithPath)(nil), diags[0])
+		require.IsType(t, DetailedErrorDiagnostic{}, diags[1])
+		assert.Equal(t, "invalid - is invalid", diags[1].Detail())
+	})
+
+	t.Run("errors without details are reported as is", func(t *testing.T) {
+		var diags diag.Diagnostics
+		err := hnyclient.DetailedError{
+			Status:  http.StatusConflict,
+			Message: "already exists",
+		}
+
+		require.True(t, testFieldPaths.AddDiagnosticOnError(&diags, "Creating Thing", err))
+		require.Len(t, diags, 1)
+		assert.IsType(t, DetailedErrorDiagnostic{}, diags[0])
+		assert.Equal(t, "already exists", diags[0].Detail())
+	})
+
+	t.Run("field errors become attribute errors", func(t *testing.T) {
+		var diags diag.Diagnostics
+		err := hnyclient.FieldErrors{
+			{Field: "threshold.op", Message: "invalid operator"},
+			{Field: "unknown", Message: "is invalid"},
+		}
+
+		require.True(t, testFieldPaths.AddDiagnosticOnError(&diags, "validating Thing", err))
+		require.Len(t, diags, 2)
+		d, ok := diags[0].(diag.DiagnosticWithPath)
+		require.True(t, ok, "expected an attribute diagnostic")
+		assert.Equal(t, path.Root("threshold").AtListIndex(0).AtName("op"), d.Path())
+		assert.Equal(t, "Error validating Thing", d.Summary())
+		assert.Equal(t, "invalid operator", d.Detail())
+		assert.NotImplements(t, (*diag.DiagnosticWithPath)(nil), diags[1])
+		assert.Equal(t, "unknown: is invalid", diags[1].Detail())
+	})
+
+	t.Run("nil error adds nothing", func(t *testing.T) {
+		var diags diag.Diagnostics
+		assert.False(t, testFieldPaths.AddDiagnosticOnError(&diags, "Creating Thing", nil))
+		assert.Empty(t, diags)
+	})
+}
@@ -2,6 +2,7 @@ package filter
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
@@ -47,6 +48,28 @@ func (m *DetailFilterModel) newFilter() (*DetailFilter, error) {
 	return NewDetailFilter(field, operator, value, regex)
 }
 
+// ExactMatch returns the value the group requires the field to equal, if
+// any of its filters is an exact match on the field. It allows the filter
+// to be pushed down to an API able to filter by the field itself.
+//
+// The group must still be matched against the results, as the other filters
+// are not accounted for.
+func (g *FilterGroup) ExactMatch(field string) (string, bool) {
+	if g == nil {
+		return "", false
+	}
+	for _, f := range g.Filters {
+		if f.ValueRegex != nil || !strings.EqualFold(f.Field, field) {
+			continue
+		}
+		switch f.Operator {
+		case "equals", "=", "eq", "":
+			return f.Value, true
+		}
+	}
+	return "", false
+}
+
 // Match determines if all filters in the group match the resource
 // TODO: Implement OR logic if needed in the future
 func (g *FilterGroup) Match(resource any) bool {
@@ -142,3 +142,37 @@ func mustCreateFilter(t *testing.T, field, operator, value, regex string) Detail
 	}
 
 }
+
+func TestFilterGroup_ExactMatch(t *testing.T) {
+	mustFilter := func(field, operator, value, regex string) *DetailFilter {
+		f, err := NewDetailFilter(field, operator, value, regex)
+		require.NoError(t, err)
+		return f
+	}
+
+	tests := []struct {
+		name  string
+		group *FilterGroup
+		value string
+		ok    bool
+	}{
+		{"nil group", nil, "", false},
+		{"no filters", &FilterGroup{}, "", false},
+		{"equals", &FilterGroup{Filters: []*DetailFilter{mustFilter("name", "equals", "prod", "")}}, "prod", true},
+		{"default operator", &FilterGroup{Filters: []*DetailFilter{mustFilter("Name", "", "prod", "")}}, "prod", true},
+		{"other field", &FilterGroup{Filters: []*DetailFilter{mustFilter("description", "equals", "prod", "")}}, "", false},
+		{"other operator", &FilterGroup{Filters: []*DetailFilter{mustFilter("name", "contains", "prod", "")}}, "", false},
+		{"regex", &FilterGroup{Filters: []*DetailFilter{mustFilter("name", "", "", "^prod")}}, "", false},
+		{"among others", &FilterGroup{Filters: []*DetailFilter{
+			mustFilter("name", "starts-with", "pr", ""),
+			mustFilter("name", "eq", "prod", ""),
+		}}, "prod", true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			value, ok := tc.group.ExactMatch("name")
+			assert.Equal(t, tc.ok, ok)
+			assert.Equal(t, tc.value, value)
+		})
+	}
+}
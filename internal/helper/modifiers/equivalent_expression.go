@@ -0,0 +1,45 @@
+package modifiers
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+
+	"github.com/honeycombio/terraform-provider-honeycombio/internal/helper/expression"
+)
+
+type equivalentExpression struct{}
+
+var _ planmodifier.String = &equivalentExpression{}
+
+func (m equivalentExpression) Description(_ context.Context) string {
+	return "Avoids unnecessary plans if two expressions only differ in formatting."
+}
+
+func (m equivalentExpression) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (m equivalentExpression) PlanModifyString(ctx context.Context, req planmodifier.StringRequest, resp *planmodifier.StringResponse) {
+	// Do nothing on resource destroy.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+	// Do nothing if the plan or state is not yet known.
+	if req.PlanValue.IsUnknown() || req.PlanValue.IsNull() || req.StateValue.IsNull() || req.StateValue.IsUnknown() {
+		return
+	}
+
+	if expression.Equivalent(req.PlanValue.ValueString(), req.StateValue.ValueString()) {
+		// If the expressions have the same syntax tree, suppress the diff
+		// by setting the plan value to value already in state.
+		resp.PlanValue = req.StateValue
+	}
+}
+
+// EquivalentExpression avoids unnecessary plans if two derived column or
+// calculated field expressions only differ in formatting, such as whitespace,
+// line breaks or quoting style.
+func EquivalentExpression() planmodifier.String {
+	return equivalentExpression{}
+}
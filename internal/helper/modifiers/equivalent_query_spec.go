@@ -7,6 +7,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 
 	"github.com/honeycombio/terraform-provider-honeycombio/client"
+	"github.com/honeycombio/terraform-provider-honeycombio/internal/helper/expression"
 )
 
 type equivalentQuerySpec struct{}
@@ -43,6 +44,17 @@ func (m equivalentQuerySpec) PlanModifyString(ctx context.Context, req planmodif
 		return
 	}
 
+	// calculated fields whose expressions only differ in formatting are
+	// compared as if they were written the same
+	for i, cf := range configQs.CalculatedFields {
+		for _, stateCf := range stateQs.CalculatedFields {
+			if cf.Name == stateCf.Name && expression.Equivalent(cf.Expression, stateCf.Expression) {
+				configQs.CalculatedFields[i].Expression = stateCf.Expression
+				break
+			}
+		}
+	}
+
 	if stateQs.EquivalentTo(configQs) {
 		// If the query specifications are equivalent, suppress the diff
 		// by setting the plan value to value already in state.
@@ -0,0 +1,70 @@
+package modifiers
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+
+	"github.com/honeycombio/terraform-provider-honeycombio/client"
+)
+
+type querySpecChanges struct{}
+
+var _ planmodifier.String = &querySpecChanges{}
+
+func (m querySpecChanges) Description(_ context.Context) string {
+	return "Describes the changes to the query specification in a warning."
+}
+
+func (m querySpecChanges) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (m querySpecChanges) PlanModifyString(ctx context.Context, req planmodifier.StringRequest, resp *planmodifier.StringResponse) {
+	// Do nothing on resource destroy.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+	// Do nothing if the plan or state is not yet known, or nothing has changed.
+	if resp.PlanValue.IsUnknown() || resp.PlanValue.IsNull() || req.StateValue.IsNull() || req.StateValue.IsUnknown() {
+		return
+	}
+	if resp.PlanValue.Equal(req.StateValue) {
+		return
+	}
+
+	// the JSON is validated elsewhere, so there's nothing to describe if it's invalid
+	var planQs, stateQs client.QuerySpec
+	if err := json.Unmarshal([]byte(resp.PlanValue.ValueString()), &planQs); err != nil {
+		return
+	}
+	if err := json.Unmarshal([]byte(req.StateValue.ValueString()), &stateQs); err != nil {
+		return
+	}
+
+	if detail := DescribeQuerySpecChanges(stateQs.Diff(planQs)); detail != "" {
+		resp.Diagnostics.AddAttributeWarning(req.Path, "Query specification changes", detail)
+	}
+}
+
+// QuerySpecChanges adds a warning describing how the query specification
+// will change, in place of a diff of its JSON.
+//
+// It must follow EquivalentQuerySpec in the list of plan modifiers.
+func QuerySpecChanges() planmodifier.String {
+	return querySpecChanges{}
+}
+
+// DescribeQuerySpecChanges returns the changes as a list suitable for the
+// detail of a diagnostic, or an empty string if there are none.
+func DescribeQuerySpecChanges(changes []client.QuerySpecChange) string {
+	if len(changes) == 0 {
+		return ""
+	}
+	detail := "The query specification will change as follows:\n"
+	for _, c := range changes {
+		detail += "\n  - " + c.String()
+	}
+	return detail
+}
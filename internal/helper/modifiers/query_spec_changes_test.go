@@ -0,0 +1,109 @@
+package modifiers_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/honeycombio/terraform-provider-honeycombio/client"
+	"github.com/honeycombio/terraform-provider-honeycombio/internal/helper/modifiers"
+)
+
+func Test_QuerySpecChanges(t *testing.T) {
+	t.Parallel()
+
+	planned := tftypes.NewValue(tftypes.Object{}, map[string]tftypes.Value{})
+	destroyed := tftypes.NewValue(tftypes.Object{}, nil)
+
+	type testCase struct {
+		plan        tftypes.Value
+		state       types.String
+		planned     types.String
+		wantWarning string
+	}
+	tests := map[string]testCase{
+		"destroy": {
+			plan:    destroyed,
+			state:   types.StringValue(`{"time_range":7200}`),
+			planned: types.StringNull(),
+		},
+		"create": {
+			plan:    planned,
+			state:   types.StringNull(),
+			planned: types.StringValue(`{"time_range":3600}`),
+		},
+		"unknown plan": {
+			plan:    planned,
+			state:   types.StringValue(`{"time_range":7200}`),
+			planned: types.StringUnknown(),
+		},
+		"unchanged": {
+			plan:    planned,
+			state:   types.StringValue(`{"time_range":3600}`),
+			planned: types.StringValue(`{"time_range":3600}`),
+		},
+		"equivalent": {
+			plan:    planned,
+			state:   types.StringValue(`{"calculations":[{"op":"COUNT"}]}`),
+			planned: types.StringValue(`{"calculations":[{"op":"COUNT"}],"time_range":7200}`),
+		},
+		"invalid JSON": {
+			plan:    planned,
+			state:   types.StringValue(`{"time_range":3600}`),
+			planned: types.StringValue(`{"time_range":`),
+		},
+		"changed": {
+			plan:        planned,
+			state:       types.StringValue(`{"time_range":3600}`),
+			planned:     types.StringValue(`{"time_range":7200,"breakdowns":["service.name"]}`),
+			wantWarning: "breakdowns: added service.name",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			req := planmodifier.StringRequest{
+				Path:       path.Root("query_json"),
+				Plan:       tfsdk.Plan{Raw: test.plan},
+				StateValue: test.state,
+				PlanValue:  test.planned,
+			}
+			resp := &planmodifier.StringResponse{PlanValue: test.planned}
+			modifiers.QuerySpecChanges().PlanModifyString(context.Background(), req, resp)
+
+			assert.False(t, resp.Diagnostics.HasError(), "unexpected error: %s", resp.Diagnostics)
+			assert.Equal(t, test.planned, resp.PlanValue, "the plan should not be modified")
+			if test.wantWarning == "" {
+				assert.Empty(t, resp.Diagnostics.Warnings())
+				return
+			}
+			if assert.Len(t, resp.Diagnostics.Warnings(), 1) {
+				assert.Contains(t, resp.Diagnostics.Warnings()[0].Detail(), test.wantWarning)
+			}
+		})
+	}
+}
+
+func Test_DescribeQuerySpecChanges(t *testing.T) {
+	t.Parallel()
+
+	assert.Empty(t, modifiers.DescribeQuerySpecChanges(nil))
+
+	assert.Equal(t,
+		"The query specification will change as follows:\n"+
+			"\n  - breakdowns: added service.name"+
+			"\n  - time_range: changed from 3600 to 7200",
+		modifiers.DescribeQuerySpecChanges([]client.QuerySpecChange{
+			{Field: "breakdowns", Description: "added service.name"},
+			{Field: "time_range", Description: "changed from 3600 to 7200"},
+		}),
+	)
+}
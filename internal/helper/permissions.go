@@ -0,0 +1,50 @@
+package helper
+
+import (
+	"github.com/honeycombio/terraform-provider-honeycombio/client"
+)
+
+// APIKeyAccess is an authorization granted to a v1 API key.
+type APIKeyAccess struct {
+	// Name is the name of the permission in the Honeycomb UI.
+	Name    string
+	Granted func(client.AuthMetadata) bool
+}
+
+var (
+	AccessBoards = APIKeyAccess{
+		Name:    "Manage Public Boards",
+		Granted: func(a client.AuthMetadata) bool { return a.APIKeyAccess.Boards },
+	}
+	AccessColumns = APIKeyAccess{
+		Name:    "Manage Queries and Columns",
+		Granted: func(a client.AuthMetadata) bool { return a.APIKeyAccess.Columns },
+	}
+	AccessCreateDatasets = APIKeyAccess{
+		Name:    "Create Datasets",
+		Granted: func(a client.AuthMetadata) bool { return a.APIKeyAccess.CreateDatasets },
+	}
+	AccessMarkers = APIKeyAccess{
+		Name:    "Manage Markers",
+		Granted: func(a client.AuthMetadata) bool { return a.APIKeyAccess.Markers },
+	}
+	AccessRecipients = APIKeyAccess{
+		Name:    "Manage Recipients",
+		Granted: func(a client.AuthMetadata) bool { return a.APIKeyAccess.Recipients },
+	}
+	AccessSLOs = APIKeyAccess{
+		Name:    "Manage SLOs",
+		Granted: func(a client.AuthMetadata) bool { return a.APIKeyAccess.SLOs },
+	}
+	AccessTriggers = APIKeyAccess{
+		Name:    "Manage Triggers",
+		Granted: func(a client.AuthMetadata) bool { return a.APIKeyAccess.Triggers },
+	}
+)
+
+// Missing describes the access missing from the configured API key, for
+// the detail of an error.
+func (a APIKeyAccess) Missing() string {
+	return "The configured API key does not have the \"" + a.Name + "\" permission needed to manage this resource. " +
+		"Grant it to the key in the Environment's API key settings, or configure a key which has it."
+}
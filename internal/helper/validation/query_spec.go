@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 
 	"github.com/hashicorp/terraform-plugin-framework-validators/helpers/validatordiag"
@@ -38,6 +39,25 @@ func (v querySpecValidator) ValidateString(ctx context.Context, request validato
 			v.Description(ctx),
 			fmt.Sprintf("%q: %s", request.ConfigValue.ValueString(), err.Error()),
 		))
+		return
+	}
+
+	appendQuerySpecFieldErrors(ctx, v, request, response, q.Validate())
+}
+
+// appendQuerySpecFieldErrors adds a diagnostic for each of the problems
+// found by QuerySpec.Validate.
+func appendQuerySpecFieldErrors(ctx context.Context, v validator.Describer, request validator.StringRequest, response *validator.StringResponse, err error) {
+	var fieldErrs client.FieldErrors
+	if !errors.As(err, &fieldErrs) {
+		return
+	}
+	for _, fe := range fieldErrs {
+		response.Diagnostics.Append(validatordiag.InvalidAttributeValueDiagnostic(
+			request.Path,
+			v.Description(ctx),
+			fe.Error(),
+		))
 	}
 }
 
@@ -49,6 +49,14 @@ func Test_QuerySpecValidator(t *testing.T) {
 			val:         types.StringValue(`{"foo": "bar"}`),
 			expectError: true,
 		},
+		"invalid granularity": {
+			val:         types.StringValue(`{"calculations": [{"op": "COUNT"}], "time_range": 120, "granularity": 121}`),
+			expectError: true,
+		},
+		"invalid order": {
+			val:         types.StringValue(`{"calculations": [{"op": "COUNT"}], "orders": [{"column": "duration_ms"}]}`),
+			expectError: true,
+		},
 	}
 
 	for name, test := range tests {
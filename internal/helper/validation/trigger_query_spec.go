@@ -1,6 +1,7 @@
 package validation
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
@@ -31,7 +32,9 @@ func (v triggerQuerySpecValidator) ValidateString(ctx context.Context, request v
 	}
 
 	var q client.QuerySpec
-	if err := json.Unmarshal([]byte(request.ConfigValue.ValueString()), &q); err != nil {
+	dec := json.NewDecoder(bytes.NewReader([]byte(request.ConfigValue.ValueString())))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&q); err != nil {
 		response.Diagnostics.Append(validatordiag.InvalidAttributeValueDiagnostic(
 			request.Path,
 			v.Description(ctx),
@@ -40,6 +43,9 @@ func (v triggerQuerySpecValidator) ValidateString(ctx context.Context, request v
 		return
 	}
 
+	// Trigger queries must first be valid queries
+	appendQuerySpecFieldErrors(ctx, v, request, response, q.Validate())
+
 	// Reject HEATMAP and CONCURRENCY calculations
 	for _, calc := range q.Calculations {
 		if calc.Op == client.CalculationOpHeatmap {
@@ -69,6 +69,16 @@ func Test_TriggerQuerySpecValidator(t *testing.T) {
 			expectError:      true,
 			expectedErrorMsg: "value must be a valid Trigger Query Specification",
 		},
+		"invalid unknown field": {
+			val:              types.StringValue(`{"calculations": [{"op": "COUNT"}], "foo": "bar"}`),
+			expectError:      true,
+			expectedErrorMsg: `unknown field "foo"`,
+		},
+		"invalid having without matching calculation": {
+			val:              types.StringValue(`{"calculations": [{"op": "COUNT"}], "havings": [{"calculate_op": "P99", "column": "duration_ms", "op": ">", "value": 5}]}`),
+			expectError:      true,
+			expectedErrorMsg: "havings[0].calculate_op: P99 missing matching calculation",
+		},
 		"invalid HEATMAP calculation": {
 			val:              types.StringValue(`{"calculations": [{"op": "HEATMAP", "column": "duration_ms"}]}`),
 			expectError:      true,
@@ -0,0 +1,49 @@
+package validation
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/helpers/validatordiag"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+var _ validator.String = isValidDurationValidator{}
+
+type isValidDurationValidator struct{}
+
+func (v isValidDurationValidator) Description(_ context.Context) string {
+	return "value must be a positive duration, such as \"500ms\" or \"1m\""
+}
+
+func (v isValidDurationValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v isValidDurationValidator) ValidateString(ctx context.Context, request validator.StringRequest, response *validator.StringResponse) {
+	if request.ConfigValue.IsNull() || request.ConfigValue.IsUnknown() {
+		return
+	}
+
+	d, err := time.ParseDuration(request.ConfigValue.ValueString())
+	if err == nil && d <= 0 {
+		err = fmt.Errorf("must be greater than zero")
+	}
+	if err != nil {
+		response.Diagnostics.Append(validatordiag.InvalidAttributeValueDiagnostic(
+			request.Path,
+			v.Description(ctx),
+			fmt.Sprintf("%q: %s", request.ConfigValue.ValueString(), err.Error()),
+		))
+	}
+}
+
+// IsValidDuration returns an AttributeValidator which ensures that any
+// configured attribute value is a positive duration, as accepted by
+// time.ParseDuration.
+//
+// Null (unconfigured) and unknown (known after apply) values are skipped.
+func IsValidDuration() validator.String {
+	return isValidDurationValidator{}
+}
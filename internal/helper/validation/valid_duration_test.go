@@ -0,0 +1,64 @@
+package validation_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/honeycombio/terraform-provider-honeycombio/internal/helper/validation"
+)
+
+func Test_IsValidDurationValidator(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		val         types.String
+		expectError bool
+	}
+	tests := map[string]testCase{
+		"unknown": {
+			val: types.StringUnknown(),
+		},
+		"null": {
+			val: types.StringNull(),
+		},
+		"valid duration": {
+			val: types.StringValue("1m30s"),
+		},
+		"invalid duration": {
+			val:         types.StringValue("90"),
+			expectError: true,
+		},
+		"zero duration": {
+			val:         types.StringValue("0s"),
+			expectError: true,
+		},
+		"negative duration": {
+			val:         types.StringValue("-1m"),
+			expectError: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			request := validator.StringRequest{
+				Path:           path.Root("test"),
+				PathExpression: path.MatchRoot("test"),
+				ConfigValue:    test.val,
+			}
+			response := validator.StringResponse{}
+			validation.IsValidDuration().ValidateString(context.Background(), request, &response)
+
+			assert.Equal(t,
+				test.expectError,
+				response.Diagnostics.HasError(),
+				"unexpected error: %s", response.Diagnostics,
+			)
+		})
+	}
+}
@@ -6,15 +6,26 @@ import (
 )
 
 type APIKeyResourceModel struct {
-	ID               types.String `tfsdk:"id"`
-	Name             types.String `tfsdk:"name"`
-	Type             types.String `tfsdk:"type"`
-	EnvironmentID    types.String `tfsdk:"environment_id"`
-	VisibleToMembers types.Bool   `tfsdk:"visible_to_members"`
-	Disabled         types.Bool   `tfsdk:"disabled"`
-	Permissions      types.List   `tfsdk:"permissions"` // APIKeyPermissionModel
-	Secret           types.String `tfsdk:"secret"`
-	Key              types.String `tfsdk:"key"`
+	ID                types.String          `tfsdk:"id"`
+	Name              types.String          `tfsdk:"name"`
+	Type              types.String          `tfsdk:"type"`
+	EnvironmentID     types.String          `tfsdk:"environment_id"`
+	VisibleToMembers  types.Bool            `tfsdk:"visible_to_members"`
+	Disabled          types.Bool            `tfsdk:"disabled"`
+	Permissions       types.List            `tfsdk:"permissions"` // APIKeyPermissionModel
+	Rotation          []APIKeyRotationModel `tfsdk:"rotation"`
+	Secret            types.String          `tfsdk:"secret"`
+	Key               types.String          `tfsdk:"key"`
+	RotatedAt         types.String          `tfsdk:"rotated_at"`
+	PreviousID        types.String          `tfsdk:"previous_id"`
+	PreviousSecret    types.String          `tfsdk:"previous_secret"`
+	PreviousKey       types.String          `tfsdk:"previous_key"`
+	PreviousExpiresAt types.String          `tfsdk:"previous_expires_at"`
+}
+
+type APIKeyRotationModel struct {
+	RotateAfter types.String `tfsdk:"rotate_after"`
+	Overlap     types.String `tfsdk:"overlap"`
 }
 
 type APIKeyPermissionModel struct {
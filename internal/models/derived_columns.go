@@ -0,0 +1,11 @@
+package models
+
+import "github.com/hashicorp/terraform-plugin-framework/types"
+
+type DerivedColumnResourceModel struct {
+	ID          types.String `tfsdk:"id"`
+	Dataset     types.String `tfsdk:"dataset"`
+	Alias       types.String `tfsdk:"alias"`
+	Expression  types.String `tfsdk:"expression"`
+	Description types.String `tfsdk:"description"`
+}
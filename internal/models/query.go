@@ -24,6 +24,7 @@ type QuerySpecificationModel struct {
 	Filters           []QuerySpecificationFilterModel          `tfsdk:"filter"`
 	Havings           []QuerySpecificationHavingModel          `tfsdk:"having"`
 	Orders            []QuerySpecificationOrderModel           `tfsdk:"order"`
+	QueryText         types.String                             `tfsdk:"query_text"`
 	Json              types.String                             `tfsdk:"json"` // Computed JSON query specification output
 }
 
@@ -3,6 +3,8 @@ package provider
 import (
 	"context"
 	"errors"
+	"fmt"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework-validators/boolvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
@@ -33,18 +35,43 @@ import (
 // won't give us the secret portion of the key which is arguably the whole reason
 // for the resource.
 var (
-	_ resource.Resource              = &apiKeyResource{}
-	_ resource.ResourceWithConfigure = &apiKeyResource{}
+	_ resource.Resource                   = &apiKeyResource{}
+	_ resource.ResourceWithConfigure      = &apiKeyResource{}
+	_ resource.ResourceWithModifyPlan     = &apiKeyResource{}
+	_ resource.ResourceWithValidateConfig = &apiKeyResource{}
 )
 
 type apiKeyResource struct {
-	client *v2client.Client
+	client      *v2client.Client
+	permissions permissionCheck
 }
 
 func NewAPIKeyResource() resource.Resource {
 	return &apiKeyResource{}
 }
 
+// apiKeyFieldPaths maps the fields named in the API's validation errors
+// to the resource's attributes.
+var apiKeyFieldPaths = helper.FieldPaths{
+	"name":                             "name",
+	"key_type":                         "type",
+	"disabled":                         "disabled",
+	"environment":                      "environment_id",
+	"permissions":                      "permissions[0]",
+	"permissions.visible_team_members": "visible_to_members",
+	"permissions.send_events":          "permissions[0].send_events",
+	"permissions.create_datasets":      "permissions[0].create_datasets",
+	"permissions.manage_columns":       "permissions[0].manage_queries",
+	"permissions.run_queries":          "permissions[0].run_queries",
+	"permissions.read_service_maps":    "permissions[0].read_service_maps",
+	"permissions.manage_boards":        "permissions[0].manage_public_boards",
+	"permissions.manage_privateBoards": "permissions[0].manage_private_boards",
+	"permissions.manage_slos":          "permissions[0].manage_slos",
+	"permissions.manage_triggers":      "permissions[0].manage_triggers",
+	"permissions.manage_recipients":    "permissions[0].manage_recipients",
+	"permissions.manage_markers":       "permissions[0].manage_markers",
+}
+
 func (*apiKeyResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_api_key"
 }
@@ -61,6 +88,80 @@ func (r *apiKeyResource) Configure(_ context.Context, req resource.ConfigureRequ
 		return
 	}
 	r.client = c
+	r.permissions = w.RequireScope(scopeAPIKeysWrite)
+}
+
+func (r *apiKeyResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() || req.State.Raw.IsNull() {
+		// creating or destroying the key
+		checkPermissions(req, resp, r.permissions)
+		return
+	}
+
+	var plan, state models.APIKeyResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// the current and previous keys only change when the key is rotated
+	plan.Key = state.Key
+	plan.Secret = state.Secret
+	plan.RotatedAt = state.RotatedAt
+	plan.PreviousID = state.PreviousID
+	plan.PreviousKey = state.PreviousKey
+	plan.PreviousSecret = state.PreviousSecret
+	plan.PreviousExpiresAt = state.PreviousExpiresAt
+
+	rotate, expirePrevious := apiKeyRotationDue(state, plan.Rotation, time.Now())
+	switch {
+	case rotate:
+		plan.ID = types.StringUnknown()
+		plan.Key = types.StringUnknown()
+		plan.Secret = types.StringUnknown()
+		plan.RotatedAt = types.StringUnknown()
+		plan.PreviousID = types.StringUnknown()
+		plan.PreviousKey = types.StringUnknown()
+		plan.PreviousSecret = types.StringUnknown()
+		plan.PreviousExpiresAt = types.StringUnknown()
+	case expirePrevious:
+		clearPreviousAPIKey(&plan)
+	}
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, plan)...)
+
+	if (rotate || expirePrevious) && r.permissions != nil {
+		// the plan may not have differed from the state before the
+		// rotation was planned, so always check
+		r.permissions(&resp.Diagnostics)
+		return
+	}
+	checkPermissions(req, resp, r.permissions)
+}
+
+func (r *apiKeyResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config models.APIKeyResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() || len(config.Rotation) == 0 {
+		return
+	}
+
+	rotateAfter, err := time.ParseDuration(config.Rotation[0].RotateAfter.ValueString())
+	if err != nil {
+		// unknown or invalid, which is reported by the attribute's validator
+		return
+	}
+	overlap, err := time.ParseDuration(config.Rotation[0].Overlap.ValueString())
+	if err != nil {
+		return
+	}
+	if overlap >= rotateAfter {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("rotation").AtListIndex(0).AtName("overlap"),
+			"Invalid Attribute Value",
+			fmt.Sprintf("overlap (%s) must be shorter than rotate_after (%s)", overlap, rotateAfter),
+		)
+	}
 }
 
 func (*apiKeyResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
@@ -126,8 +227,54 @@ func (*apiKeyResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 				Sensitive:           true,
 				MarkdownDescription: "The secret portion of the API Key.",
 			},
+			"rotated_at": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The time the current API key was created, either initially or by a rotation, in RFC3339 format.",
+			},
+			"previous_id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The ID of the API key replaced by the last rotation, until its overlap has expired.",
+			},
+			"previous_key": schema.StringAttribute{
+				Computed:            true,
+				Sensitive:           true,
+				MarkdownDescription: "The API key replaced by the last rotation formatted for use based on its type, until its overlap has expired.",
+			},
+			"previous_secret": schema.StringAttribute{
+				Computed:            true,
+				Sensitive:           true,
+				MarkdownDescription: "The secret portion of the API key replaced by the last rotation, until its overlap has expired.",
+			},
+			"previous_expires_at": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The time after which the API key replaced by the last rotation will be disabled and deleted, in RFC3339 format.",
+			},
 		},
 		Blocks: map[string]schema.Block{
+			"rotation": schema.ListNestedBlock{
+				MarkdownDescription: "A configuration block to periodically replace the API key with a new one.",
+				Validators: []validator.List{
+					listvalidator.SizeAtMost(1),
+				},
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"rotate_after": schema.StringAttribute{
+							Required:            true,
+							MarkdownDescription: "How long after its creation the API key is replaced by a new one, as a duration such as `720h`.",
+							Validators: []validator.String{
+								validation.IsValidDuration(),
+							},
+						},
+						"overlap": schema.StringAttribute{
+							Required:            true,
+							MarkdownDescription: "How long the replaced API key keeps working after a rotation, as a duration such as `24h`. Must be shorter than `rotate_after`.",
+							Validators: []validator.String{
+								validation.IsValidDuration(),
+							},
+						},
+					},
+				},
+			},
 			"permissions": schema.ListNestedBlock{
 				MarkdownDescription: "A configuration block setting what actions the API key can perform.",
 				Validators: []validator.List{
@@ -320,21 +467,8 @@ func (r *apiKeyResource) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 
-	apiPermissions := expandAPIKeyPermissions(ctx, plan.Permissions, &resp.Diagnostics)
-	if apiPermissions != nil {
-		apiPermissions.VisibleToMembers = plan.VisibleToMembers.ValueBool()
-	}
-
-	newKey := &v2client.APIKey{
-		Name:        plan.Name.ValueStringPointer(),
-		KeyType:     plan.Type.ValueString(),
-		Environment: &v2client.Environment{ID: plan.EnvironmentID.ValueString()},
-		Disabled:    plan.Disabled.ValueBoolPointer(),
-		Permissions: apiPermissions,
-	}
-
-	key, err := r.client.APIKeys.Create(ctx, newKey)
-	if helper.AddDiagnosticOnError(&resp.Diagnostics, "Creating Honeycomb API Key", err) {
+	key := r.createAPIKey(ctx, plan, "Creating Honeycomb API Key", &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
@@ -346,6 +480,10 @@ func (r *apiKeyResource) Create(ctx context.Context, req resource.CreateRequest,
 	state.Disabled = types.BoolValue(*key.Disabled)
 	state.Secret = types.StringValue(key.Secret)
 	state.VisibleToMembers = types.BoolValue(key.Permissions.VisibleToMembers)
+	state.Rotation = plan.Rotation
+	state.Key = flattenAPIKeyValue(key, &resp.Diagnostics)
+	state.RotatedAt = types.StringValue(apiKeyCreatedAt(key).Format(time.RFC3339))
+	clearPreviousAPIKey(&state)
 
 	if !plan.Permissions.IsNull() {
 		state.Permissions = flattenAPIKeyPermissions(ctx, key.Permissions, &resp.Diagnostics)
@@ -353,18 +491,6 @@ func (r *apiKeyResource) Create(ctx context.Context, req resource.CreateRequest,
 		state.Permissions = types.ListNull(types.ObjectType{AttrTypes: models.APIKeyPermissionsAttrType})
 	}
 
-	switch key.KeyType {
-	case "ingest":
-		state.Key = types.StringValue(key.ID + key.Secret)
-	case "configuration":
-		state.Key = types.StringValue(key.Secret)
-	default:
-		resp.Diagnostics.AddError(
-			"Unknown API Key Type",
-			"API Key Type "+key.KeyType+" is not supported. Supported types are: ingest",
-		)
-	}
-
 	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
 }
 
@@ -375,26 +501,12 @@ func (r *apiKeyResource) Read(ctx context.Context, req resource.ReadRequest, res
 		return
 	}
 
-	var detailedErr client.DetailedError
 	key, err := r.client.APIKeys.Get(ctx, state.ID.ValueString())
-	if errors.As(err, &detailedErr) {
-		if detailedErr.IsNotFound() {
-			// if not found consider it deleted -- so just remove it from state
-			resp.State.RemoveResource(ctx)
-			return
-		} else {
-			resp.Diagnostics.Append(helper.NewDetailedErrorDiagnostic(
-				"Error Reading Honeycomb API Key",
-				&detailedErr,
-			))
-		}
-	} else if err != nil {
-		resp.Diagnostics.AddError(
-			"Error Reading Honeycomb API Key",
-			"Unexpected error reading API Key ID "+state.ID.ValueString()+": "+err.Error(),
-		)
-	}
-	if resp.Diagnostics.HasError() {
+	if errors.Is(err, client.ErrNotFound) {
+		// if not found consider it deleted -- so just remove it from state
+		resp.State.RemoveResource(ctx)
+		return
+	} else if helper.AddDiagnosticOnError(&resp.Diagnostics, "Reading Honeycomb API Key", err) {
 		return
 	}
 
@@ -404,6 +516,9 @@ func (r *apiKeyResource) Read(ctx context.Context, req resource.ReadRequest, res
 	state.Disabled = types.BoolValue(*key.Disabled)
 	state.EnvironmentID = types.StringValue(key.Environment.ID)
 	state.VisibleToMembers = types.BoolValue(key.Permissions.VisibleToMembers)
+	if state.RotatedAt.IsNull() && key.Timestamps != nil {
+		state.RotatedAt = types.StringValue(apiKeyCreatedAt(key).Format(time.RFC3339))
+	}
 
 	if !state.Permissions.IsNull() {
 		state.Permissions = flattenAPIKeyPermissions(ctx, key.Permissions, &resp.Diagnostics)
@@ -411,6 +526,16 @@ func (r *apiKeyResource) Read(ctx context.Context, req resource.ReadRequest, res
 		state.Permissions = types.ListNull(types.ObjectType{AttrTypes: models.APIKeyPermissionsAttrType})
 	}
 
+	if !state.PreviousID.IsNull() {
+		_, err := r.client.APIKeys.Get(ctx, state.PreviousID.ValueString())
+		if errors.Is(err, client.ErrNotFound) {
+			// the previous key has been removed outside of Terraform
+			clearPreviousAPIKey(&state)
+		} else if helper.AddDiagnosticOnError(&resp.Diagnostics, "Reading Honeycomb API Key", err) {
+			return
+		}
+	}
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
 }
 
@@ -422,22 +547,57 @@ func (r *apiKeyResource) Update(ctx context.Context, req resource.UpdateRequest,
 		return
 	}
 
-	updateRequest := &v2client.APIKey{
-		ID:       plan.ID.ValueString(),
-		Name:     plan.Name.ValueStringPointer(),
-		Disabled: plan.Disabled.ValueBoolPointer(),
+	if !state.PreviousID.IsNull() && (plan.ID.IsUnknown() || plan.PreviousID.IsNull()) {
+		// the previous key's overlap has expired, or it is about to be
+		// replaced by the key being rotated
+		err := r.revokeAPIKey(ctx, state.PreviousID.ValueString())
+		if helper.AddDiagnosticOnError(&resp.Diagnostics, "Revoking previous Honeycomb API Key", err) {
+			return
+		}
+		clearPreviousAPIKey(&state)
+		resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
 	}
 
-	_, err := r.client.APIKeys.Update(ctx, updateRequest)
-	if helper.AddDiagnosticOnError(&resp.Diagnostics, "Updating Honeycomb API Key", err) {
-		return
-	}
+	var key *v2client.APIKey
+	if plan.ID.IsUnknown() {
+		// rotating the key: the current key becomes the previous key,
+		// which keeps working until the overlap has passed
+		key = r.createAPIKey(ctx, plan, "Rotating Honeycomb API Key", &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
 
-	key, err := r.client.APIKeys.Get(ctx, plan.ID.ValueString())
-	if helper.AddDiagnosticOnError(&resp.Diagnostics, "Updating Honeycomb API Key", err) {
-		return
+		var overlap time.Duration
+		if len(plan.Rotation) > 0 {
+			overlap, _ = time.ParseDuration(plan.Rotation[0].Overlap.ValueString())
+		}
+		state.PreviousID = state.ID
+		state.PreviousKey = state.Key
+		state.PreviousSecret = state.Secret
+		state.PreviousExpiresAt = types.StringValue(time.Now().Add(overlap).UTC().Format(time.RFC3339))
+
+		state.Secret = types.StringValue(key.Secret)
+		state.Key = flattenAPIKeyValue(key, &resp.Diagnostics)
+		state.RotatedAt = types.StringValue(apiKeyCreatedAt(key).Format(time.RFC3339))
+	} else {
+		updateRequest := &v2client.APIKey{
+			ID:       state.ID.ValueString(),
+			Name:     plan.Name.ValueStringPointer(),
+			Disabled: plan.Disabled.ValueBoolPointer(),
+		}
+
+		_, err := r.client.APIKeys.Update(ctx, updateRequest)
+		if apiKeyFieldPaths.AddDiagnosticOnError(&resp.Diagnostics, "Updating Honeycomb API Key", err) {
+			return
+		}
+
+		key, err = r.client.APIKeys.Get(ctx, state.ID.ValueString())
+		if helper.AddDiagnosticOnError(&resp.Diagnostics, "Updating Honeycomb API Key", err) {
+			return
+		}
 	}
 
+	state.Rotation = plan.Rotation
 	state.ID = types.StringValue(key.ID)
 	state.Name = types.StringValue(*key.Name)
 	state.Type = types.StringValue(key.KeyType)
@@ -461,23 +621,123 @@ func (r *apiKeyResource) Delete(ctx context.Context, req resource.DeleteRequest,
 		return
 	}
 
+	if !state.PreviousID.IsNull() {
+		err := r.client.APIKeys.Delete(ctx, state.PreviousID.ValueString())
+		if !errors.Is(err, client.ErrNotFound) &&
+			helper.AddDiagnosticOnError(&resp.Diagnostics, "Deleting previous Honeycomb API Key", err) {
+			return
+		}
+	}
+
 	err := r.client.APIKeys.Delete(ctx, state.ID.ValueString())
-	var detailedErr client.DetailedError
+	// if not found consider it deleted -- so don't error
+	if !errors.Is(err, client.ErrNotFound) {
+		helper.AddDiagnosticOnError(&resp.Diagnostics, "Deleting Honeycomb API Key", err)
+	}
+}
+
+// createAPIKey creates a new API key as described by the plan.
+func (r *apiKeyResource) createAPIKey(
+	ctx context.Context,
+	plan models.APIKeyResourceModel,
+	summary string,
+	diags *diag.Diagnostics,
+) *v2client.APIKey {
+	apiPermissions := expandAPIKeyPermissions(ctx, plan.Permissions, diags)
+	if apiPermissions != nil {
+		apiPermissions.VisibleToMembers = plan.VisibleToMembers.ValueBool()
+	}
+
+	newKey := &v2client.APIKey{
+		Name:        plan.Name.ValueStringPointer(),
+		KeyType:     plan.Type.ValueString(),
+		Environment: &v2client.Environment{ID: plan.EnvironmentID.ValueString()},
+		Disabled:    plan.Disabled.ValueBoolPointer(),
+		Permissions: apiPermissions,
+	}
+
+	key, err := r.client.APIKeys.Create(ctx, newKey)
+	if apiKeyFieldPaths.AddDiagnosticOnError(diags, summary, err) {
+		return nil
+	}
+	return key
+}
+
+// revokeAPIKey disables and then deletes an API key, so that it stops
+// working even if it cannot be deleted.
+func (r *apiKeyResource) revokeAPIKey(ctx context.Context, id string) error {
+	_, err := r.client.APIKeys.Update(ctx, &v2client.APIKey{
+		ID:       id,
+		Disabled: helper.ToPtr(true),
+	})
+	if err == nil {
+		err = r.client.APIKeys.Delete(ctx, id)
+	}
+	if errors.Is(err, client.ErrNotFound) {
+		// already gone
+		return nil
+	}
+	return err
+}
+
+// apiKeyRotationDue returns whether, at the time now, the key in state is
+// due to be rotated and whether the key replaced by its last rotation has
+// outlived its overlap.
+func apiKeyRotationDue(
+	state models.APIKeyResourceModel,
+	rotation []models.APIKeyRotationModel,
+	now time.Time,
+) (rotate, expirePrevious bool) {
+	if expiresAt, err := time.Parse(time.RFC3339, state.PreviousExpiresAt.ValueString()); err == nil {
+		expirePrevious = !now.Before(expiresAt)
+	}
+
+	if len(rotation) == 0 {
+		return false, expirePrevious
+	}
+	rotatedAt, err := time.Parse(time.RFC3339, state.RotatedAt.ValueString())
 	if err != nil {
-		if errors.As(err, &detailedErr) {
-			// if not found consider it deleted -- so don't error
-			if !detailedErr.IsNotFound() {
-				resp.Diagnostics.Append(helper.NewDetailedErrorDiagnostic(
-					"Error Deleting Honeycomb API Key",
-					&detailedErr,
-				))
-			}
-		} else {
-			resp.Diagnostics.AddError(
-				"Error Deleting Honeycomb API Key",
-				"Could not delete API Key ID "+state.ID.ValueString()+": "+err.Error(),
-			)
-		}
+		return false, expirePrevious
+	}
+	rotateAfter, err := time.ParseDuration(rotation[0].RotateAfter.ValueString())
+	if err != nil {
+		return false, expirePrevious
+	}
+
+	return !now.Before(rotatedAt.Add(rotateAfter)), expirePrevious
+}
+
+// clearPreviousAPIKey removes the key replaced by the last rotation from
+// the model.
+func clearPreviousAPIKey(state *models.APIKeyResourceModel) {
+	state.PreviousID = types.StringNull()
+	state.PreviousKey = types.StringNull()
+	state.PreviousSecret = types.StringNull()
+	state.PreviousExpiresAt = types.StringNull()
+}
+
+// apiKeyCreatedAt returns the time the key was created, falling back to
+// the current time if the API did not return it.
+func apiKeyCreatedAt(key *v2client.APIKey) time.Time {
+	if key.Timestamps != nil && !key.Timestamps.CreatedAt.IsZero() {
+		return key.Timestamps.CreatedAt.UTC()
+	}
+	return time.Now().UTC()
+}
+
+// flattenAPIKeyValue returns the API key formatted for use based on its type.
+func flattenAPIKeyValue(key *v2client.APIKey, diags *diag.Diagnostics) types.String {
+	switch key.KeyType {
+	case "ingest":
+		return types.StringValue(key.ID + key.Secret)
+	case "configuration":
+		return types.StringValue(key.Secret)
+	default:
+		diags.AddError(
+			"Unknown API Key Type",
+			"API Key Type "+key.KeyType+" is not supported. Supported types are: ingest",
+		)
+		return types.StringNull()
 	}
 }
 
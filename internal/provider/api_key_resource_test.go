@@ -4,9 +4,14 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/honeycombio/terraform-provider-honeycombio/internal/models"
 )
 
 func TestAcc_APIKeyResource(t *testing.T) {
@@ -174,6 +179,153 @@ resource "honeycombio_api_key" "test_configuration" {
 	})
 }
 
+func TestAcc_APIKeyResource_Rotation(t *testing.T) {
+	ctx := context.Background()
+	c := testAccV2Client(t)
+	env := testAccEnvironment(ctx, t, c)
+
+	var originalID string
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 testAccPreCheckV2API(t),
+		ProtoV6ProviderFactories: testAccProtoV6MuxServerFactory,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccConfigRotatingAPIKeyTest("10s", "5s", env.ID),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccEnsureAPIKeyExists(t, "honeycombio_api_key.test"),
+					resource.TestCheckResourceAttrSet("honeycombio_api_key.test", "rotated_at"),
+					resource.TestCheckNoResourceAttr("honeycombio_api_key.test", "previous_id"),
+					resource.TestCheckNoResourceAttr("honeycombio_api_key.test", "previous_key"),
+					func(s *terraform.State) error {
+						originalID = s.RootModule().Resources["honeycombio_api_key.test"].Primary.ID
+						return nil
+					},
+				),
+			},
+			{
+				// wait for the key to be due for rotation
+				PreConfig: func() { time.Sleep(11 * time.Second) },
+				Config:    testAccConfigRotatingAPIKeyTest("10s", "5s", env.ID),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccEnsureAPIKeyExists(t, "honeycombio_api_key.test"),
+					func(s *terraform.State) error {
+						attrs := s.RootModule().Resources["honeycombio_api_key.test"].Primary.Attributes
+						if attrs["id"] == originalID {
+							return fmt.Errorf("expected the key to have been rotated")
+						}
+						if attrs["previous_id"] != originalID {
+							return fmt.Errorf("expected previous_id to be %q, got %q", originalID, attrs["previous_id"])
+						}
+						return nil
+					},
+					resource.TestCheckResourceAttrSet("honeycombio_api_key.test", "previous_key"),
+					resource.TestCheckResourceAttrSet("honeycombio_api_key.test", "previous_secret"),
+					resource.TestCheckResourceAttrSet("honeycombio_api_key.test", "previous_expires_at"),
+				),
+			},
+			{
+				// wait for the overlap to expire
+				PreConfig: func() { time.Sleep(6 * time.Second) },
+				Config:    testAccConfigRotatingAPIKeyTest("1h", "5s", env.ID),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccEnsureAPIKeyExists(t, "honeycombio_api_key.test"),
+					resource.TestCheckNoResourceAttr("honeycombio_api_key.test", "previous_id"),
+					resource.TestCheckNoResourceAttr("honeycombio_api_key.test", "previous_key"),
+					resource.TestCheckNoResourceAttr("honeycombio_api_key.test", "previous_expires_at"),
+					func(_ *terraform.State) error {
+						if _, err := c.APIKeys.Get(ctx, originalID); err == nil {
+							return fmt.Errorf("expected the previous key %q to have been deleted", originalID)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func Test_apiKeyRotationDue(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	rotation := []models.APIKeyRotationModel{{
+		RotateAfter: types.StringValue("24h"),
+		Overlap:     types.StringValue("1h"),
+	}}
+
+	tests := []struct {
+		name               string
+		rotatedAt          time.Time
+		previousExpiresAt  time.Time
+		rotation           []models.APIKeyRotationModel
+		wantRotate         bool
+		wantExpirePrevious bool
+	}{
+		{
+			name:      "not yet due",
+			rotatedAt: now.Add(-23 * time.Hour),
+			rotation:  rotation,
+		},
+		{
+			name:       "due",
+			rotatedAt:  now.Add(-24 * time.Hour),
+			rotation:   rotation,
+			wantRotate: true,
+		},
+		{
+			name:      "without rotation",
+			rotatedAt: now.Add(-48 * time.Hour),
+		},
+		{
+			name:              "previous key within its overlap",
+			rotatedAt:         now.Add(-30 * time.Minute),
+			previousExpiresAt: now.Add(30 * time.Minute),
+			rotation:          rotation,
+		},
+		{
+			name:               "previous key past its overlap",
+			rotatedAt:          now.Add(-2 * time.Hour),
+			previousExpiresAt:  now.Add(-time.Hour),
+			rotation:           rotation,
+			wantExpirePrevious: true,
+		},
+		{
+			name:               "previous key expires without rotation",
+			rotatedAt:          now.Add(-2 * time.Hour),
+			previousExpiresAt:  now.Add(-time.Hour),
+			wantExpirePrevious: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			state := models.APIKeyResourceModel{
+				RotatedAt:         types.StringValue(tc.rotatedAt.Format(time.RFC3339)),
+				PreviousExpiresAt: types.StringNull(),
+			}
+			if !tc.previousExpiresAt.IsZero() {
+				state.PreviousExpiresAt = types.StringValue(tc.previousExpiresAt.Format(time.RFC3339))
+			}
+
+			rotate, expirePrevious := apiKeyRotationDue(state, tc.rotation, now)
+			assert.Equal(t, tc.wantRotate, rotate, "rotate")
+			assert.Equal(t, tc.wantExpirePrevious, expirePrevious, "expirePrevious")
+		})
+	}
+}
+
+func testAccConfigRotatingAPIKeyTest(rotateAfter, overlap, envID string) string {
+	return fmt.Sprintf(`
+resource "honeycombio_api_key" "test" {
+  name = "rotating test key"
+  type = "ingest"
+
+  environment_id = "%s"
+
+  rotation {
+    rotate_after = "%s"
+    overlap      = "%s"
+  }
+}`, envID, rotateAfter, overlap)
+}
+
 func testAccConfigIngestAPIKeyTest(name, disabled, envID string) string {
 	return fmt.Sprintf(`
 resource "honeycombio_api_key" "test" {
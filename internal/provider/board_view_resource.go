@@ -28,12 +28,14 @@ import (
 var (
 	_ resource.Resource                   = &boardViewResource{}
 	_ resource.ResourceWithConfigure      = &boardViewResource{}
+	_ resource.ResourceWithModifyPlan     = &boardViewResource{}
 	_ resource.ResourceWithImportState    = &boardViewResource{}
 	_ resource.ResourceWithValidateConfig = &boardViewResource{}
 )
 
 type boardViewResource struct {
-	client *client.Client
+	client      *client.Client
+	permissions permissionCheck
 }
 
 func NewBoardViewResource() resource.Resource {
@@ -56,6 +58,11 @@ func (r *boardViewResource) Configure(_ context.Context, req resource.ConfigureR
 		return
 	}
 	r.client = c
+	r.permissions = w.RequireAccess(helper.AccessBoards)
+}
+
+func (r *boardViewResource) ModifyPlan(_ context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	checkPermissions(req, resp, r.permissions)
 }
 
 func (*boardViewResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
@@ -238,26 +245,12 @@ func (r *boardViewResource) Read(ctx context.Context, req resource.ReadRequest,
 		return
 	}
 
-	var detailedErr client.DetailedError
 	boardView, err := r.client.BoardViews.Get(ctx, state.BoardID.ValueString(), state.ID.ValueString())
-	if errors.As(err, &detailedErr) {
-		if detailedErr.IsNotFound() {
-			// if not found consider it deleted -- remove it from state
-			resp.State.RemoveResource(ctx)
-			return
-		} else {
-			resp.Diagnostics.Append(helper.NewDetailedErrorDiagnostic(
-				"Error Reading Honeycomb Board View",
-				&detailedErr,
-			))
-		}
-	} else if err != nil {
-		resp.Diagnostics.AddError(
-			"Error Reading Honeycomb Board View",
-			"Unexpected error reading Board View ID "+state.ID.ValueString()+": "+err.Error(),
-		)
-	}
-	if resp.Diagnostics.HasError() {
+	if errors.Is(err, client.ErrNotFound) {
+		// if not found consider it deleted -- remove it from state
+		resp.State.RemoveResource(ctx)
+		return
+	} else if helper.AddDiagnosticOnError(&resp.Diagnostics, "Reading Honeycomb Board View", err) {
 		return
 	}
 
@@ -332,23 +325,10 @@ func (r *boardViewResource) Delete(ctx context.Context, req resource.DeleteReque
 		return
 	}
 
-	var detailedErr client.DetailedError
 	err := r.client.BoardViews.Delete(ctx, state.BoardID.ValueString(), state.ID.ValueString())
-	if err != nil {
-		if errors.As(err, &detailedErr) {
-			// if not found consider it deleted -- so don't error
-			if !detailedErr.IsNotFound() {
-				resp.Diagnostics.Append(helper.NewDetailedErrorDiagnostic(
-					"Error Deleting Honeycomb Board View",
-					&detailedErr,
-				))
-			}
-		} else {
-			resp.Diagnostics.AddError(
-				"Error Deleting Honeycomb Board View",
-				"Could not delete Board View ID "+state.ID.ValueString()+": "+err.Error(),
-			)
-		}
+	// if not found consider it deleted -- so don't error
+	if !errors.Is(err, client.ErrNotFound) {
+		helper.AddDiagnosticOnError(&resp.Diagnostics, "Deleting Honeycomb Board View", err)
 	}
 }
 
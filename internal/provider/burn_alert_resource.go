@@ -31,19 +31,34 @@ import (
 var (
 	_ resource.Resource                   = &burnAlertResource{}
 	_ resource.ResourceWithConfigure      = &burnAlertResource{}
+	_ resource.ResourceWithModifyPlan     = &burnAlertResource{}
 	_ resource.ResourceWithImportState    = &burnAlertResource{}
 	_ resource.ResourceWithValidateConfig = &burnAlertResource{}
 )
 
 type burnAlertResource struct {
-	client  *client.Client
-	feature features.FeaturesIntelligence
+	client      *client.Client
+	feature     features.FeaturesIntelligence
+	permissions permissionCheck
 }
 
 func NewBurnAlertResource() resource.Resource {
 	return &burnAlertResource{}
 }
 
+// burnAlertFieldPaths maps the fields named in the API's validation errors
+// to the resource's attributes.
+var burnAlertFieldPaths = helper.FieldPaths{
+	"alert_type":                 "alert_type",
+	"description":                "description",
+	"auto_investigate":           "auto_investigate",
+	"exhaustion_minutes":         "exhaustion_minutes",
+	"budget_rate_window_minutes": "budget_rate_window_minutes",
+	"budget_rate_decrease_threshold_per_million": "budget_rate_decrease_percent",
+	"slo":        "slo_id",
+	"recipients": "recipient",
+}
+
 func (*burnAlertResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_burn_alert"
 }
@@ -60,6 +75,7 @@ func (r *burnAlertResource) Configure(_ context.Context, req resource.ConfigureR
 		return
 	}
 	r.client = c
+	r.permissions = w.RequireAccess(helper.AccessSLOs)
 
 	f, err := w.Features()
 	if err != nil {
@@ -69,6 +85,10 @@ func (r *burnAlertResource) Configure(_ context.Context, req resource.ConfigureR
 	r.feature = f.Intelligence
 }
 
+func (r *burnAlertResource) ModifyPlan(_ context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	checkPermissions(req, resp, r.permissions)
+}
+
 func (*burnAlertResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Description: "Burn Alerts are used to notify you when your error budget will be exhausted within a given time period.",
@@ -309,7 +329,7 @@ func (r *burnAlertResource) Create(ctx context.Context, req resource.CreateReque
 
 	// Create the new burn alert
 	burnAlert, err := r.client.BurnAlerts.Create(ctx, dataset.ValueString(), createRequest)
-	if helper.AddDiagnosticOnError(&resp.Diagnostics, "Creating Honeycomb Burn Alert", err) {
+	if burnAlertFieldPaths.AddDiagnosticOnError(&resp.Diagnostics, "Creating Honeycomb Burn Alert", err) {
 		return
 	}
 
@@ -356,26 +376,12 @@ func (r *burnAlertResource) Read(ctx context.Context, req resource.ReadRequest,
 	dataset := helper.GetDatasetOrAll(state.Dataset)
 
 	// Read the burn alert, using the values from state
-	var detailedErr client.DetailedError
 	burnAlert, err := r.client.BurnAlerts.Get(ctx, dataset.ValueString(), state.ID.ValueString())
-	if errors.As(err, &detailedErr) {
-		if detailedErr.IsNotFound() {
-			// if not found consider it deleted -- so just remove it from state
-			resp.State.RemoveResource(ctx)
-			return
-		} else {
-			resp.Diagnostics.Append(helper.NewDetailedErrorDiagnostic(
-				"Error Reading Honeycomb Burn Alert",
-				&detailedErr,
-			))
-		}
-	} else if err != nil {
-		resp.Diagnostics.AddError(
-			"Error Reading Honeycomb Burn Alert",
-			"Could not read Burn Alert ID "+state.ID.ValueString()+": "+err.Error(),
-		)
-	}
-	if resp.Diagnostics.HasError() {
+	if errors.Is(err, client.ErrNotFound) {
+		// if not found consider it deleted -- so just remove it from state
+		resp.State.RemoveResource(ctx)
+		return
+	} else if helper.AddDiagnosticOnError(&resp.Diagnostics, "Reading Honeycomb Burn Alert", err) {
 		return
 	}
 
@@ -456,7 +462,7 @@ func (r *burnAlertResource) Update(ctx context.Context, req resource.UpdateReque
 
 	// Update the burn alert
 	_, err := r.client.BurnAlerts.Update(ctx, dataset.ValueString(), updateRequest)
-	if helper.AddDiagnosticOnError(&resp.Diagnostics, "Updating Honeycomb Burn Alert", err) {
+	if burnAlertFieldPaths.AddDiagnosticOnError(&resp.Diagnostics, "Updating Honeycomb Burn Alert", err) {
 		return
 	}
 
@@ -510,22 +516,9 @@ func (r *burnAlertResource) Delete(ctx context.Context, req resource.DeleteReque
 	dataset := helper.GetDatasetOrAll(state.Dataset)
 
 	// Delete the burn alert, using the values from state
-	var detailedErr client.DetailedError
 	err := r.client.BurnAlerts.Delete(ctx, dataset.ValueString(), state.ID.ValueString())
-	if err != nil {
-		if errors.As(err, &detailedErr) {
-			// if not found consider it deleted -- so don't error
-			if !detailedErr.IsNotFound() {
-				resp.Diagnostics.Append(helper.NewDetailedErrorDiagnostic(
-					"Error Deleting Honeycomb Burn Alert",
-					&detailedErr,
-				))
-			}
-		} else {
-			resp.Diagnostics.AddError(
-				"Error Deleting Honeycomb Burn Alert",
-				"Could not delete Burn Alert ID "+state.ID.ValueString()+": "+err.Error(),
-			)
-		}
+	// if not found consider it deleted -- so don't error
+	if !errors.Is(err, client.ErrNotFound) {
+		helper.AddDiagnosticOnError(&resp.Diagnostics, "Deleting Honeycomb Burn Alert", err)
 	}
 }
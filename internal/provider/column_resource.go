@@ -27,12 +27,14 @@ import (
 var (
 	_ resource.Resource                = &columnResource{}
 	_ resource.ResourceWithConfigure   = &columnResource{}
+	_ resource.ResourceWithModifyPlan  = &columnResource{}
 	_ resource.ResourceWithImportState = &columnResource{}
 )
 
 type columnResource struct {
-	client  *client.Client
-	feature features.FeaturesColumn
+	client      *client.Client
+	feature     features.FeaturesColumn
+	permissions permissionCheck
 }
 
 func NewColumnResource() resource.Resource {
@@ -55,6 +57,7 @@ func (r *columnResource) Configure(_ context.Context, req resource.ConfigureRequ
 		return
 	}
 	r.client = c
+	r.permissions = w.RequireAccess(helper.AccessColumns)
 
 	features, err := w.Features()
 	if err != nil {
@@ -64,6 +67,10 @@ func (r *columnResource) Configure(_ context.Context, req resource.ConfigureRequ
 	r.feature = features.Column
 }
 
+func (r *columnResource) ModifyPlan(_ context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	checkPermissions(req, resp, r.permissions)
+}
+
 func (*columnResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Description: "Honeycomb Column resource",
@@ -140,39 +147,29 @@ func (r *columnResource) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 
-	var detailedError client.DetailedError
 	column, err := r.client.Columns.Create(ctx, plan.Dataset.ValueString(), r.expandColumn(plan))
-	if errors.As(err, &detailedError) {
-		if detailedError.IsConflict() && r.feature.ImportOnConflict {
-			// If the column already exists and import_on_conflict is true,
-			// we should import the existing column instead of creating a new one.
-			resp.Diagnostics.AddWarning("Importing existing Column on Create",
-				"Column \""+plan.Name.ValueString()+"\" already exists, importing and updating the existing column as "+
-					"'import_on_conflict' is enabled.")
-			column, err = r.client.Columns.GetByKeyName(ctx, plan.Dataset.ValueString(), plan.Name.ValueString())
-			if err != nil {
-				resp.Diagnostics.AddError("Error importing existing Column", err.Error())
-				return
-			}
-
-			// Update the plan with the imported column's ID
-			plan.ID = types.StringValue(column.ID)
-
-			// Update the column with the plan's values
-			column, err = r.client.Columns.Update(ctx, plan.Dataset.ValueString(), r.expandColumn(plan))
-			if err != nil {
-				resp.Diagnostics.AddError("Error updating imported Column", err.Error())
-				return
-			}
-		} else {
-			resp.Diagnostics.Append(helper.NewDetailedErrorDiagnostic(
-				"Error creating Column",
-				&detailedError,
-			))
+	if errors.Is(err, client.ErrConflict) && r.feature.ImportOnConflict {
+		// If the column already exists and import_on_conflict is true,
+		// we should import the existing column instead of creating a new one.
+		resp.Diagnostics.AddWarning("Importing existing Column on Create",
+			"Column \""+plan.Name.ValueString()+"\" already exists, importing and updating the existing column as "+
+				"'import_on_conflict' is enabled.")
+		column, err = r.client.Columns.GetByKeyName(ctx, plan.Dataset.ValueString(), plan.Name.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("Error importing existing Column", err.Error())
+			return
+		}
+
+		// Update the plan with the imported column's ID
+		plan.ID = types.StringValue(column.ID)
+
+		// Update the column with the plan's values
+		column, err = r.client.Columns.Update(ctx, plan.Dataset.ValueString(), r.expandColumn(plan))
+		if err != nil {
+			resp.Diagnostics.AddError("Error updating imported Column", err.Error())
 			return
 		}
-	} else if err != nil {
-		resp.Diagnostics.AddError("Error creating Column", err.Error())
+	} else if helper.AddDiagnosticOnError(&resp.Diagnostics, "creating Column", err) {
 		return
 	}
 
@@ -191,8 +188,7 @@ func (r *columnResource) Read(ctx context.Context, req resource.ReadRequest, res
 
 	column, err := r.client.Columns.GetByKeyName(ctx, state.Dataset.ValueString(), columnName)
 	if err != nil {
-		var detailedError client.DetailedError
-		if errors.As(err, &detailedError) && detailedError.IsNotFound() {
+		if errors.Is(err, client.ErrNotFound) {
 			resp.State.RemoveResource(ctx)
 			return
 		}
@@ -234,7 +230,7 @@ func (r *columnResource) Delete(ctx context.Context, req resource.DeleteRequest,
 	err := r.client.Columns.Delete(ctx, state.Dataset.ValueString(), state.ID.ValueString())
 	if err != nil {
 		var detailedErr client.DetailedError
-		if errors.As(err, &detailedErr) && detailedErr.IsConflict() && strings.Contains(detailedErr.Message, "in use by dataset definition") {
+		if errors.As(err, &detailedErr) && errors.Is(err, client.ErrConflict) && strings.Contains(detailedErr.Message, "in use by dataset definition") {
 			// This column is a dataset definition column (e.g. timestamp, duration).
 			// The API blocks individual deletion while the parent dataset exists, but
 			// dataset deletion cleans up columns automatically. If the dataset is also
@@ -3,7 +3,6 @@ package provider
 import (
 	"context"
 	"errors"
-	"net/http"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework-validators/int32validator"
@@ -34,8 +33,9 @@ var (
 )
 
 type datasetResource struct {
-	client  *client.Client
-	feature features.FeaturesDataset
+	client      *client.Client
+	feature     features.FeaturesDataset
+	permissions permissionCheck
 }
 
 func NewDatasetResource() resource.Resource {
@@ -58,6 +58,7 @@ func (r *datasetResource) Configure(_ context.Context, req resource.ConfigureReq
 		return
 	}
 	r.client = c
+	r.permissions = w.RequireAccess(helper.AccessCreateDatasets)
 
 	features, err := w.Features()
 	if err != nil {
@@ -151,6 +152,8 @@ func (r *datasetResource) ImportState(ctx context.Context, req resource.ImportSt
 }
 
 func (r *datasetResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	checkPermissions(req, resp, r.permissions)
+
 	if req.Plan.Raw.IsNull() {
 		// If the entire plan is null, the resource is planned for destruction -- let's add a warning
 		resp.Diagnostics.AddWarning(
@@ -229,26 +232,12 @@ func (r *datasetResource) Read(ctx context.Context, req resource.ReadRequest, re
 		return
 	}
 
-	var detailedErr client.DetailedError
 	ds, err := r.client.Datasets.Get(ctx, state.ID.ValueString())
-	if errors.As(err, &detailedErr) {
-		if detailedErr.IsNotFound() {
-			// if not found consider it deleted -- so just remove it from state
-			resp.State.RemoveResource(ctx)
-			return
-		} else {
-			resp.Diagnostics.Append(helper.NewDetailedErrorDiagnostic(
-				"Error Reading Honeycomb Dataset",
-				&detailedErr,
-			))
-		}
-	} else if err != nil {
-		resp.Diagnostics.AddError(
-			"Error Reading Honeycomb Dataset",
-			"Unexpected error reading Dataset "+state.ID.ValueString()+": "+err.Error(),
-		)
-	}
-	if resp.Diagnostics.HasError() {
+	if errors.Is(err, client.ErrNotFound) {
+		// if not found consider it deleted -- so just remove it from state
+		resp.State.RemoveResource(ctx)
+		return
+	} else if helper.AddDiagnosticOnError(&resp.Diagnostics, "Reading Honeycomb Dataset", err) {
 		return
 	}
 
@@ -308,26 +297,13 @@ func (r *datasetResource) Delete(ctx context.Context, req resource.DeleteRequest
 	}
 
 	err := r.client.Datasets.Delete(ctx, state.ID.ValueString())
-	var detailedErr client.DetailedError
-	if err != nil {
-		if errors.As(err, &detailedErr) {
-			if detailedErr.Status == http.StatusConflict {
-				resp.Diagnostics.AddError(
-					"Unable to Delete Dataset",
-					"Delete Protection is enabled. "+
-						"You must disable delete protection before it can be deleted.",
-				)
-			} else {
-				resp.Diagnostics.Append(helper.NewDetailedErrorDiagnostic(
-					"Error Deleting Honeycomb Dataset",
-					&detailedErr,
-				))
-			}
-		} else {
-			resp.Diagnostics.AddError(
-				"Error Deleting Honeycomb Dataset",
-				"Could not delete Dataset "+state.ID.ValueString()+": "+err.Error(),
-			)
-		}
+	if errors.Is(err, client.ErrConflict) {
+		resp.Diagnostics.AddError(
+			"Unable to Delete Dataset",
+			"Delete Protection is enabled. "+
+				"You must disable delete protection before it can be deleted.",
+		)
+	} else {
+		helper.AddDiagnosticOnError(&resp.Diagnostics, "Deleting Honeycomb Dataset", err)
 	}
 }
@@ -0,0 +1,161 @@
+package provider
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/honeycombio/terraform-provider-honeycombio/internal/helper/expression"
+	"github.com/honeycombio/terraform-provider-honeycombio/internal/helper/validation"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var _ datasource.DataSource = &derivedColumnEvaluationDataSource{}
+
+func NewDerivedColumnEvaluationDataSource() datasource.DataSource {
+	return &derivedColumnEvaluationDataSource{}
+}
+
+// derivedColumnEvaluationDataSource is the data source implementation.
+//
+// Expressions are evaluated locally, so the data source needs no client.
+type derivedColumnEvaluationDataSource struct{}
+
+type derivedColumnEvaluationDataSourceModel struct {
+	Expression types.String                         `tfsdk:"expression"`
+	Events     []types.String                       `tfsdk:"events"`
+	Results    []derivedColumnEvaluationResultModel `tfsdk:"results"`
+}
+
+type derivedColumnEvaluationResultModel struct {
+	Value types.String `tfsdk:"value"`
+	Type  types.String `tfsdk:"type"`
+	Error types.String `tfsdk:"error"`
+}
+
+func (d *derivedColumnEvaluationDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_derived_column_evaluation"
+}
+
+func (d *derivedColumnEvaluationDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Evaluates a Derived Column expression against a list of sample events, without sending them to Honeycomb. " +
+			"Combined with \"check\" blocks, this can be used to test expressions such as SLIs before they are applied. " +
+			"The evaluation is done by the provider and may differ from Honeycomb's in edge cases.",
+		MarkdownDescription: "Evaluates a Derived Column expression against a list of sample events, without sending them to Honeycomb. " +
+			"Combined with [`check` blocks](https://developer.hashicorp.com/terraform/language/checks), this can be used to test expressions such as SLIs before they are applied. " +
+			"The evaluation is done by the provider and may differ from Honeycomb's in edge cases.",
+		Attributes: map[string]schema.Attribute{
+			"expression": schema.StringAttribute{
+				Description: "The Derived Column expression to evaluate.",
+				Required:    true,
+				Validators: []validator.String{
+					stringvalidator.LengthBetween(1, 4095),
+					validation.IsValidCalculatedField(),
+				},
+			},
+			"events": schema.ListAttribute{
+				Description: "The events to evaluate the expression against, each a JSON object of column names to values. " +
+					"Nested objects can be referred to by their path joined by dots, such as `$http.status_code`.",
+				ElementType: types.StringType,
+				Required:    true,
+				Validators:  []validator.List{listvalidator.SizeAtLeast(1)},
+			},
+			"results": schema.ListNestedAttribute{
+				Description: "The results of evaluating the expression, in the order of the events.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"value": schema.StringAttribute{
+							Description: "The value of the expression as a string, or null if the value is null or the evaluation failed.",
+							Computed:    true,
+						},
+						"type": schema.StringAttribute{
+							Description: "The type of the value: one of `string`, `integer`, `float`, `boolean` or `null`.",
+							Computed:    true,
+						},
+						"error": schema.StringAttribute{
+							Description: "The error evaluating the expression, if any.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *derivedColumnEvaluationDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data derivedColumnEvaluationDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	expr, err := expression.Parse(data.Expression.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("expression"),
+			"Invalid expression",
+			err.Error(),
+		)
+		return
+	}
+
+	data.Results = make([]derivedColumnEvaluationResultModel, len(data.Events))
+	for i, ev := range data.Events {
+		event, err := expression.ParseEvent(ev.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("events").AtListIndex(i),
+				"Invalid event",
+				"The event must be a JSON object: "+err.Error(),
+			)
+			continue
+		}
+		data.Results[i] = evaluateDerivedColumn(expr, event)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func evaluateDerivedColumn(expr expression.Node, event expression.Event) derivedColumnEvaluationResultModel {
+	result := derivedColumnEvaluationResultModel{
+		Value: types.StringNull(),
+		Type:  types.StringValue("null"),
+		Error: types.StringNull(),
+	}
+
+	v, err := expression.Eval(expr, event)
+	if err != nil {
+		result.Error = types.StringValue(err.Error())
+		return result
+	}
+
+	switch v := v.(type) {
+	case string:
+		result.Value = types.StringValue(v)
+		result.Type = types.StringValue("string")
+	case int64:
+		result.Value = types.StringValue(strconv.FormatInt(v, 10))
+		result.Type = types.StringValue("integer")
+	case float64:
+		result.Value = types.StringValue(strconv.FormatFloat(v, 'f', -1, 64))
+		result.Type = types.StringValue("float")
+	case bool:
+		result.Value = types.StringValue(strconv.FormatBool(v))
+		result.Type = types.StringValue("boolean")
+	}
+	return result
+}
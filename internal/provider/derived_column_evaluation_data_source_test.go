@@ -0,0 +1,145 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/honeycombio/terraform-provider-honeycombio/internal/helper/expression"
+)
+
+func TestAcc_DerivedColumnEvaluationDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 testAccPreCheck(t),
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactory,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+data "honeycombio_derived_column_evaluation" "test" {
+  expression = "!EXISTS($error) AND $duration_ms <= 300"
+  events = [
+    jsonencode({ duration_ms = 120 }),
+    jsonencode({ duration_ms = 120, error = "timeout" }),
+    jsonencode({ duration_ms = 450 }),
+  ]
+}
+
+output "fast" {
+  value = data.honeycombio_derived_column_evaluation.test.results[0].value
+}
+
+output "error" {
+  value = data.honeycombio_derived_column_evaluation.test.results[1].value
+}
+
+output "slow" {
+  value = data.honeycombio_derived_column_evaluation.test.results[2].value
+}
+
+output "type" {
+  value = data.honeycombio_derived_column_evaluation.test.results[0].type
+}`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckOutput("fast", "true"),
+					resource.TestCheckOutput("error", "false"),
+					resource.TestCheckOutput("slow", "false"),
+					resource.TestCheckOutput("type", "boolean"),
+				),
+			},
+			{
+				Config: `
+data "honeycombio_derived_column_evaluation" "test" {
+  expression = "INGEST_TIMESTAMP()"
+  events     = [jsonencode({})]
+}`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckNoResourceAttr("data.honeycombio_derived_column_evaluation.test", "results.0.value"),
+					resource.TestCheckResourceAttr("data.honeycombio_derived_column_evaluation.test", "results.0.error", "INGEST_TIMESTAMP is not supported by the evaluator"),
+				),
+			},
+			{
+				Config: `
+data "honeycombio_derived_column_evaluation" "test" {
+  expression = "BOOL(1)"
+  events     = ["[1, 2]"]
+}`,
+				ExpectError: regexp.MustCompile(`Invalid event`),
+			},
+		},
+	})
+}
+
+func Test_evaluateDerivedColumn(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		expr string
+		want derivedColumnEvaluationResultModel
+	}{
+		"string": {
+			expr: `CONCAT($service, "-", $version)`,
+			want: derivedColumnEvaluationResultModel{
+				Value: types.StringValue("api-2"),
+				Type:  types.StringValue("string"),
+				Error: types.StringNull(),
+			},
+		},
+		"integer": {
+			expr: `INT($duration_ms)`,
+			want: derivedColumnEvaluationResultModel{
+				Value: types.StringValue("125"),
+				Type:  types.StringValue("integer"),
+				Error: types.StringNull(),
+			},
+		},
+		"float": {
+			expr: `$duration_ms / 1000`,
+			want: derivedColumnEvaluationResultModel{
+				Value: types.StringValue("0.1255"),
+				Type:  types.StringValue("float"),
+				Error: types.StringNull(),
+			},
+		},
+		"boolean": {
+			expr: `$duration_ms < 300`,
+			want: derivedColumnEvaluationResultModel{
+				Value: types.StringValue("true"),
+				Type:  types.StringValue("boolean"),
+				Error: types.StringNull(),
+			},
+		},
+		"null": {
+			expr: `$missing`,
+			want: derivedColumnEvaluationResultModel{
+				Value: types.StringNull(),
+				Type:  types.StringValue("null"),
+				Error: types.StringNull(),
+			},
+		},
+		"error": {
+			expr: `BUCKET($duration_ms, 100)`,
+			want: derivedColumnEvaluationResultModel{
+				Value: types.StringNull(),
+				Type:  types.StringValue("null"),
+				Error: types.StringValue("BUCKET is not supported by the evaluator"),
+			},
+		},
+	}
+
+	event, err := expression.ParseEvent(`{"service": "api", "version": 2, "duration_ms": 125.5}`)
+	require.NoError(t, err)
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			expr, err := expression.Parse(tc.expr)
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, evaluateDerivedColumn(expr, event))
+		})
+	}
+}
@@ -0,0 +1,263 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/honeycombio/terraform-provider-honeycombio/client"
+	"github.com/honeycombio/terraform-provider-honeycombio/internal/helper"
+	"github.com/honeycombio/terraform-provider-honeycombio/internal/helper/modifiers"
+	"github.com/honeycombio/terraform-provider-honeycombio/internal/helper/validation"
+	"github.com/honeycombio/terraform-provider-honeycombio/internal/models"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                 = &derivedColumnResource{}
+	_ resource.ResourceWithConfigure    = &derivedColumnResource{}
+	_ resource.ResourceWithModifyPlan   = &derivedColumnResource{}
+	_ resource.ResourceWithImportState  = &derivedColumnResource{}
+	_ resource.ResourceWithUpgradeState = &derivedColumnResource{}
+)
+
+type derivedColumnResource struct {
+	client      *client.Client
+	permissions permissionCheck
+}
+
+func NewDerivedColumnResource() resource.Resource {
+	return &derivedColumnResource{}
+}
+
+func (*derivedColumnResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_derived_column"
+}
+
+func (r *derivedColumnResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	w := getClientFromResourceRequest(&req)
+	if w == nil {
+		return
+	}
+
+	c, err := w.V1Client()
+	if err != nil || c == nil {
+		resp.Diagnostics.AddError("Failed to configure client", err.Error())
+		return
+	}
+	r.client = c
+	r.permissions = w.RequireAccess(helper.AccessColumns)
+}
+
+func (r *derivedColumnResource) ModifyPlan(_ context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	checkPermissions(req, resp, r.permissions)
+}
+
+func (*derivedColumnResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Creates a derived column.",
+		// version 1 is the first Framework-based version of the resource
+		Version: 1,
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The ID of the Derived Column.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"dataset": schema.StringAttribute{
+				Description: "The dataset this derived column belongs to. If not set, it will be Environment-wide.",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					modifiers.DatasetDeprecation(false),
+				},
+			},
+			"alias": schema.StringAttribute{
+				Description: "The alias of the derived column. Must be unique within the dataset or environment.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.LengthBetween(1, 255),
+				},
+			},
+			"expression": schema.StringAttribute{
+				Description: "The formula of the derived column. See [Derived Column Syntax](https://docs.honeycomb.io/reference/derived-column-formula/syntax/).",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					modifiers.EquivalentExpression(),
+				},
+				Validators: []validator.String{
+					stringvalidator.LengthBetween(1, 4095),
+					validation.IsValidCalculatedField(),
+				},
+			},
+			"description": schema.StringAttribute{
+				Description: "A description of the derived column.",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString(""),
+				Validators: []validator.String{
+					stringvalidator.LengthBetween(1, 255),
+				},
+			},
+		},
+	}
+}
+
+func (r *derivedColumnResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	dataset, alias, found := strings.Cut(req.ID, "/")
+
+	// if dataset separator not found, we will assume its the bare alias
+	// if thats the case, we need to reassign values since strings.Cut would return (alias, "", false)
+	dsValue := types.StringNull()
+	if !found {
+		alias = dataset
+	} else {
+		dsValue = types.StringValue(dataset)
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &models.DerivedColumnResourceModel{
+		Dataset: dsValue,
+		Alias:   types.StringValue(alias),
+	})...)
+}
+
+func (r *derivedColumnResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan models.DerivedColumnResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	dataset := helper.GetDatasetOrAll(plan.Dataset)
+	dc, err := r.client.DerivedColumns.Create(ctx, dataset.ValueString(), expandDerivedColumn(plan))
+	if helper.AddDiagnosticOnError(&resp.Diagnostics, "Creating Honeycomb Derived Column", err) {
+		return
+	}
+
+	flattenDerivedColumn(&plan, dc)
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+func (r *derivedColumnResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state models.DerivedColumnResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// derived columns are looked up by alias, as that is what they are
+	// imported by
+	dataset := helper.GetDatasetOrAll(state.Dataset)
+	dc, err := r.client.DerivedColumns.GetByAlias(ctx, dataset.ValueString(), state.Alias.ValueString())
+	if errors.Is(err, client.ErrNotFound) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if helper.AddDiagnosticOnError(&resp.Diagnostics, "Reading Honeycomb Derived Column", err) {
+		return
+	}
+
+	flattenDerivedColumn(&state, dc)
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+func (r *derivedColumnResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state models.DerivedColumnResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.ID = state.ID
+	dataset := helper.GetDatasetOrAll(plan.Dataset)
+	dc, err := r.client.DerivedColumns.Update(ctx, dataset.ValueString(), expandDerivedColumn(plan))
+	if helper.AddDiagnosticOnError(&resp.Diagnostics, "Updating Honeycomb Derived Column", err) {
+		return
+	}
+
+	flattenDerivedColumn(&plan, dc)
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+func (r *derivedColumnResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state models.DerivedColumnResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	dataset := helper.GetDatasetOrAll(state.Dataset)
+	err := r.client.DerivedColumns.Delete(ctx, dataset.ValueString(), state.ID.ValueString())
+	helper.AddDiagnosticOnError(&resp.Diagnostics, "Deleting Honeycomb Derived Column", err)
+}
+
+func (*derivedColumnResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		// version 0 is the state written by the SDKv2-based resource
+		0: {
+			PriorSchema: &schema.Schema{
+				Attributes: map[string]schema.Attribute{
+					"id":          schema.StringAttribute{Computed: true},
+					"alias":       schema.StringAttribute{Required: true},
+					"expression":  schema.StringAttribute{Required: true},
+					"description": schema.StringAttribute{Optional: true},
+					"dataset":     schema.StringAttribute{Optional: true},
+				},
+			},
+			StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+				var prior models.DerivedColumnResourceModel
+				resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+				if resp.Diagnostics.HasError() {
+					return
+				}
+
+				resp.Diagnostics.Append(resp.State.Set(ctx, upgradeDerivedColumnStateV0(prior))...)
+			},
+		},
+	}
+}
+
+// upgradeDerivedColumnStateV0 converts the state of the SDKv2-based resource,
+// which stored an unset dataset or description as an empty string, or left
+// them null if they were never read.
+func upgradeDerivedColumnStateV0(prior models.DerivedColumnResourceModel) models.DerivedColumnResourceModel {
+	upgraded := prior
+	if prior.Dataset.ValueString() == "" {
+		upgraded.Dataset = types.StringNull()
+	}
+	if prior.Description.IsNull() {
+		upgraded.Description = types.StringValue("")
+	}
+	return upgraded
+}
+
+func expandDerivedColumn(m models.DerivedColumnResourceModel) *client.DerivedColumn {
+	return &client.DerivedColumn{
+		ID:          m.ID.ValueString(),
+		Alias:       m.Alias.ValueString(),
+		Expression:  m.Expression.ValueString(),
+		Description: m.Description.ValueString(),
+	}
+}
+
+func flattenDerivedColumn(m *models.DerivedColumnResourceModel, dc *client.DerivedColumn) {
+	m.ID = types.StringValue(dc.ID)
+	m.Alias = types.StringValue(dc.Alias)
+	m.Expression = types.StringValue(dc.Expression)
+	m.Description = types.StringValue(dc.Description)
+}
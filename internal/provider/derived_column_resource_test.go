@@ -0,0 +1,376 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+
+	tfresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	fwtypes "github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/honeycombio/terraform-provider-honeycombio/internal/helper/test"
+	"github.com/honeycombio/terraform-provider-honeycombio/internal/models"
+)
+
+func TestAcc_DerivedColumnResource(t *testing.T) {
+	dataset := testAccDataset()
+	alias := test.RandomStringWithPrefix("test.", 10)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 testAccPreCheck(t),
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactory,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+resource "honeycombio_derived_column" "test" {
+  alias       = "%s"
+  expression  = "BOOL(1)"
+  description = "my test description"
+
+  dataset = "%s"
+}`, alias, dataset),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("honeycombio_derived_column.test", "id"),
+					resource.TestCheckResourceAttr("honeycombio_derived_column.test", "alias", alias),
+					resource.TestCheckResourceAttr("honeycombio_derived_column.test", "expression", "BOOL(1)"),
+					resource.TestCheckResourceAttr("honeycombio_derived_column.test", "description", "my test description"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(`
+resource "honeycombio_derived_column" "test" {
+  alias      = "%s"
+  expression = "BOOL(0)"
+
+  dataset = "%s"
+}`, alias, dataset),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("honeycombio_derived_column.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("honeycombio_derived_column.test", "expression", "BOOL(0)"),
+					resource.TestCheckResourceAttr("honeycombio_derived_column.test", "description", ""),
+				),
+			},
+			{
+				// reformatting the expression is not a change
+				Config: fmt.Sprintf(`
+resource "honeycombio_derived_column" "test" {
+  alias      = "%s"
+  expression = <<EOT
+BOOL(
+  0
+)
+EOT
+
+  dataset = "%s"
+}`, alias, dataset),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: false,
+			},
+			{
+				ResourceName:      "honeycombio_derived_column.test",
+				ImportStateId:     fmt.Sprintf("%s/%s", dataset, alias),
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 testAccPreCheck(t),
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactory,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+resource "honeycombio_derived_column" "invalid_column_in_expression" {
+  alias       = "%s"
+  expression  = "LOG10($invalid_column)"
+
+  dataset = "%s"
+}`, test.RandomStringWithPrefix("test.", 10), dataset),
+				ExpectError: regexp.MustCompile(`unknown column`),
+			},
+		},
+	})
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 testAccPreCheck(t),
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactory,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "honeycombio_derived_column" "test" {
+  alias      = "invalid_syntax"
+  expression = "BOOL(1"
+
+  dataset = "foobar"
+}`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`mismatched input '<EOF>'`),
+			},
+			{
+				Config: `
+resource "honeycombio_derived_column" "test" {
+  alias      = "invalid_syntax"
+  expression = "FOOBAR(1)"
+
+  dataset = "foobar"
+}`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`invalid function: FOOBAR`),
+			},
+			{
+				Config: `
+resource "honeycombio_derived_column" "test" {
+  alias      = "invalid_syntax"
+  expression = <<EOF
+IF(AND(NOT(EXISTS($trace.parent_id)),EXISTS($duration_ms)),LTE($duration_ms,300)),
+EOF
+
+  dataset = "foobar"
+}`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`extraneous input ','`),
+			},
+			{
+				Config: `
+resource "honeycombio_derived_column" "test" {
+  alias      = "valid_syntax"
+  expression = <<EOF
+IF(AND(NOT(EXISTS($trace.parent_id)),EXISTS($duration_ms)),LTE($duration_ms,300))
+EOF
+
+  dataset = "foobar"
+}`,
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: `
+resource "honeycombio_derived_column" "test_infix" {
+  alias      = "valid_infix_syntax"
+  expression = <<EOF
+IF(!EXISTS($trace.parent_id) AND EXISTS($duration_ms), $duration_ms <= 300)
+EOF
+
+  dataset = "foobar"
+}`,
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func TestAcc_DerivedColumnResource_EnvironmentWide(t *testing.T) {
+	ctx := context.Background()
+	c := testAccClient(t)
+
+	if c.IsClassic(ctx) {
+		t.Skip("env-wide Derived Columns are not supported in classic")
+	}
+
+	alias := test.RandomStringWithPrefix("test.", 10)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 testAccPreCheck(t),
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactory,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+resource "honeycombio_derived_column" "test" {
+  alias      = "%s"
+  expression = "BOOL(1)"
+}`, alias),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("honeycombio_derived_column.test", "alias", alias),
+					resource.TestCheckNoResourceAttr("honeycombio_derived_column.test", "dataset"),
+				),
+			},
+			{
+				ResourceName:      "honeycombio_derived_column.test",
+				ImportStateId:     alias,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAcc_DerivedColumnResource_AllToUnset(t *testing.T) {
+	ctx := context.Background()
+	c := testAccClient(t)
+
+	if c.IsClassic(ctx) {
+		t.Skip("env-wide Derived Columns are not supported in classic")
+	}
+
+	alias := test.RandomStringWithPrefix("test.", 10)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 testAccPreCheck(t),
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactory,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+resource "honeycombio_derived_column" "test" {
+  alias       = "%s"
+  expression  = "BOOL(1)"
+  dataset     = "__all__"
+}`, alias),
+			},
+			{
+				Config: fmt.Sprintf(`
+resource "honeycombio_derived_column" "test" {
+  alias       = "%s"
+  expression  = "BOOL(1)"
+}`, alias),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: false,
+			},
+		},
+	})
+}
+
+// TestAcc_DerivedColumnResourceUpgradeFromVersion051 is intended to test the
+// migration case from the last SDK-based version of the Derived Column
+// resource to the current Framework-based version.
+//
+// See: https://developer.hashicorp.com/terraform/plugin/framework/migrating/testing#testing-migration
+func TestAcc_DerivedColumnResourceUpgradeFromVersion051(t *testing.T) {
+	dataset := testAccDataset()
+
+	config := fmt.Sprintf(`
+resource "honeycombio_derived_column" "dataset" {
+  alias       = "%s"
+  expression  = "BOOL(1)"
+  description = "My nice derived column"
+  dataset     = "%s"
+}
+
+resource "honeycombio_derived_column" "no_description" {
+  alias      = "%s"
+  expression = "BOOL(0)"
+  dataset    = "%s"
+}`,
+		test.RandomStringWithPrefix("test.", 10), dataset,
+		test.RandomStringWithPrefix("test.", 10), dataset,
+	)
+
+	resource.Test(t, resource.TestCase{
+		Steps: []resource.TestStep{
+			{
+				ExternalProviders: map[string]resource.ExternalProvider{
+					"honeycombio": {
+						VersionConstraint: "0.51.0",
+						Source:            "honeycombio/honeycombio",
+					},
+				},
+				Config: config,
+			},
+			{
+				ProtoV6ProviderFactories: testAccProtoV6MuxServerFactory,
+				Config:                   config,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+			},
+		},
+	})
+}
+
+func Test_derivedColumnResource_UpgradeState(t *testing.T) {
+	ctx := context.Background()
+
+	upgrader := (&derivedColumnResource{}).UpgradeState(ctx)[0]
+	var schemaResp tfresource.SchemaResponse
+	(&derivedColumnResource{}).Schema(ctx, tfresource.SchemaRequest{}, &schemaResp)
+
+	tests := []struct {
+		name  string
+		prior models.DerivedColumnResourceModel
+		want  models.DerivedColumnResourceModel
+	}{
+		{
+			name: "dataset-specific",
+			prior: models.DerivedColumnResourceModel{
+				ID:          fwtypes.StringValue("dc-123"),
+				Dataset:     fwtypes.StringValue("my-dataset"),
+				Alias:       fwtypes.StringValue("is_error"),
+				Expression:  fwtypes.StringValue("EQUALS($status_code, 500)"),
+				Description: fwtypes.StringValue("Whether the request failed"),
+			},
+			want: models.DerivedColumnResourceModel{
+				ID:          fwtypes.StringValue("dc-123"),
+				Dataset:     fwtypes.StringValue("my-dataset"),
+				Alias:       fwtypes.StringValue("is_error"),
+				Expression:  fwtypes.StringValue("EQUALS($status_code, 500)"),
+				Description: fwtypes.StringValue("Whether the request failed"),
+			},
+		},
+		{
+			name: "environment-wide with an empty dataset",
+			prior: models.DerivedColumnResourceModel{
+				ID:          fwtypes.StringValue("dc-123"),
+				Dataset:     fwtypes.StringValue(""),
+				Alias:       fwtypes.StringValue("is_error"),
+				Expression:  fwtypes.StringValue("BOOL(1)"),
+				Description: fwtypes.StringValue(""),
+			},
+			want: models.DerivedColumnResourceModel{
+				ID:          fwtypes.StringValue("dc-123"),
+				Dataset:     fwtypes.StringNull(),
+				Alias:       fwtypes.StringValue("is_error"),
+				Expression:  fwtypes.StringValue("BOOL(1)"),
+				Description: fwtypes.StringValue(""),
+			},
+		},
+		{
+			name: "environment-wide with the __all__ dataset",
+			prior: models.DerivedColumnResourceModel{
+				ID:          fwtypes.StringValue("dc-123"),
+				Dataset:     fwtypes.StringValue("__all__"),
+				Alias:       fwtypes.StringValue("is_error"),
+				Expression:  fwtypes.StringValue("BOOL(1)"),
+				Description: fwtypes.StringNull(),
+			},
+			want: models.DerivedColumnResourceModel{
+				ID:          fwtypes.StringValue("dc-123"),
+				Dataset:     fwtypes.StringValue("__all__"),
+				Alias:       fwtypes.StringValue("is_error"),
+				Expression:  fwtypes.StringValue("BOOL(1)"),
+				Description: fwtypes.StringValue(""),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prior := tfsdk.State{Schema: *upgrader.PriorSchema}
+			diags := prior.Set(ctx, tt.prior)
+			require.False(t, diags.HasError(), "state setup failed: %v", diags)
+
+			resp := &tfresource.UpgradeStateResponse{
+				State: tfsdk.State{Schema: schemaResp.Schema},
+			}
+			upgrader.StateUpgrader(ctx, tfresource.UpgradeStateRequest{State: &prior}, resp)
+			require.False(t, resp.Diagnostics.HasError(), "upgrade failed: %v", resp.Diagnostics)
+
+			var got models.DerivedColumnResourceModel
+			resp.Diagnostics.Append(resp.State.Get(ctx, &got)...)
+			require.False(t, resp.Diagnostics.HasError())
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -130,17 +130,13 @@ func (d *environmentDataSource) Read(ctx context.Context, req datasource.ReadReq
 			}
 		}
 
-		pager, err := d.client.Environments.List(ctx)
+		pager, err := d.client.Environments.List(ctx, environmentListOptions(filterGroup)...)
 		if helper.AddDiagnosticOnError(&resp.Diagnostics, "Listing Environments", err) {
 			return
 		}
-		envs := []*v2client.Environment{}
-		for pager.HasNext() {
-			items, err := pager.Next(ctx)
-			if helper.AddDiagnosticOnError(&resp.Diagnostics, "Listing Environments", err) {
-				return
-			}
-			envs = append(envs, items...)
+		envs, err := pager.Collect(ctx, 0)
+		if helper.AddDiagnosticOnError(&resp.Diagnostics, "Listing Environments", err) {
+			return
 		}
 
 		matched := make([]*v2client.Environment, 0, len(envs))
@@ -195,3 +191,14 @@ func environmentToResourceModel(env *v2client.Environment) *models.EnvironmentRe
 		DeleteProtected: types.BoolPointerValue(env.Settings.DeleteProtected),
 	}
 }
+
+// environmentListOptions returns the options pushing those detail filters
+// the API can apply itself down to it, so fewer environments are listed.
+// The detail filters must still be matched against the results.
+func environmentListOptions(g *filter.FilterGroup) []v2client.ListOption {
+	var opts []v2client.ListOption
+	if name, ok := g.ExactMatch("name"); ok {
+		opts = append(opts, v2client.FilterName(name))
+	}
+	return opts
+}
@@ -3,7 +3,6 @@ package provider
 import (
 	"context"
 	"errors"
-	"net/http"
 
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -31,13 +30,24 @@ var (
 )
 
 type environmentResource struct {
-	client *v2client.Client
+	client      *v2client.Client
+	permissions permissionCheck
 }
 
 func NewEnvironmentResource() resource.Resource {
 	return &environmentResource{}
 }
 
+// environmentFieldPaths maps the fields named in the API's validation errors
+// to the resource's attributes.
+var environmentFieldPaths = helper.FieldPaths{
+	"name":                      "name",
+	"description":               "description",
+	"color":                     "color",
+	"settings":                  "delete_protected",
+	"settings.delete_protected": "delete_protected",
+}
+
 func (*environmentResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_environment"
 }
@@ -54,6 +64,7 @@ func (r *environmentResource) Configure(_ context.Context, req resource.Configur
 		return
 	}
 	r.client = c
+	r.permissions = w.RequireScope(scopeEnvironmentsWrite)
 }
 
 func (*environmentResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
@@ -130,6 +141,8 @@ func (r *environmentResource) ImportState(ctx context.Context, req resource.Impo
 }
 
 func (r *environmentResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	checkPermissions(req, resp, r.permissions)
+
 	if req.Plan.Raw.IsNull() {
 		// If the entire plan is null, the resource is planned for destruction -- let's add a warning
 		resp.Diagnostics.AddWarning(
@@ -152,7 +165,7 @@ func (r *environmentResource) Create(ctx context.Context, req resource.CreateReq
 		Description: plan.Description.ValueStringPointer(),
 		Color:       plan.Color.ValueStringPointer(),
 	})
-	if helper.AddDiagnosticOnError(&resp.Diagnostics, "Creating Environment", err) {
+	if environmentFieldPaths.AddDiagnosticOnError(&resp.Diagnostics, "Creating Environment", err) {
 		return
 	}
 
@@ -174,26 +187,12 @@ func (r *environmentResource) Read(ctx context.Context, req resource.ReadRequest
 		return
 	}
 
-	var detailedErr hny.DetailedError
 	env, err := r.client.Environments.Get(ctx, state.ID.ValueString())
-	if errors.As(err, &detailedErr) {
-		if detailedErr.IsNotFound() {
-			// if not found consider it deleted -- so just remove it from state
-			resp.State.RemoveResource(ctx)
-			return
-		} else {
-			resp.Diagnostics.Append(helper.NewDetailedErrorDiagnostic(
-				"Error Reading Honeycomb Environment",
-				&detailedErr,
-			))
-		}
-	} else if err != nil {
-		resp.Diagnostics.AddError(
-			"Error Reading Honeycomb Environment",
-			"Unexpected error reading Environment ID "+state.ID.ValueString()+": "+err.Error(),
-		)
-	}
-	if resp.Diagnostics.HasError() {
+	if errors.Is(err, hny.ErrNotFound) {
+		// if not found consider it deleted -- so just remove it from state
+		resp.State.RemoveResource(ctx)
+		return
+	} else if helper.AddDiagnosticOnError(&resp.Diagnostics, "Reading Honeycomb Environment", err) {
 		return
 	}
 
@@ -223,7 +222,7 @@ func (r *environmentResource) Update(ctx context.Context, req resource.UpdateReq
 			DeleteProtected: plan.DeleteProtected.ValueBoolPointer(),
 		},
 	})
-	if helper.AddDiagnosticOnError(&resp.Diagnostics, "Updating Honeycomb Environment", err) {
+	if environmentFieldPaths.AddDiagnosticOnError(&resp.Diagnostics, "Updating Honeycomb Environment", err) {
 		return
 	}
 
@@ -250,26 +249,13 @@ func (r *environmentResource) Delete(ctx context.Context, req resource.DeleteReq
 	}
 
 	err := r.client.Environments.Delete(ctx, state.ID.ValueString())
-	var detailedErr hny.DetailedError
-	if err != nil {
-		if errors.As(err, &detailedErr) {
-			if detailedErr.Status == http.StatusConflict {
-				resp.Diagnostics.AddError(
-					"Unable to Delete Environment",
-					"Delete Protection is enabled. "+
-						"You must disable delete protection before it can be deleted.",
-				)
-			} else {
-				resp.Diagnostics.Append(helper.NewDetailedErrorDiagnostic(
-					"Error Deleting Honeycomb Environment",
-					&detailedErr,
-				))
-			}
-		} else {
-			resp.Diagnostics.AddError(
-				"Error Deleting Honeycomb Environment",
-				"Could not delete Environment ID "+state.ID.ValueString()+": "+err.Error(),
-			)
-		}
+	if errors.Is(err, hny.ErrConflict) {
+		resp.Diagnostics.AddError(
+			"Unable to Delete Environment",
+			"Delete Protection is enabled. "+
+				"You must disable delete protection before it can be deleted.",
+		)
+	} else {
+		helper.AddDiagnosticOnError(&resp.Diagnostics, "Deleting Honeycomb Environment", err)
 	}
 }
@@ -76,19 +76,6 @@ func (d *environmentsDataSource) Read(ctx context.Context, req datasource.ReadRe
 		return
 	}
 
-	pager, err := d.client.Environments.List(ctx)
-	if helper.AddDiagnosticOnError(&resp.Diagnostics, "Listing Environments", err) {
-		return
-	}
-	envs := []*v2client.Environment{}
-	for pager.HasNext() {
-		items, err := pager.Next(ctx)
-		if helper.AddDiagnosticOnError(&resp.Diagnostics, "Listing Environments", err) {
-			return
-		}
-		envs = append(envs, items...)
-	}
-
 	// Create a filter group with all filters (implicit AND logic)
 	filterGroup, err := filter.NewFilterGroup(data.DetailFilter)
 	if err != nil {
@@ -96,6 +83,15 @@ func (d *environmentsDataSource) Read(ctx context.Context, req datasource.ReadRe
 		return
 	}
 
+	pager, err := d.client.Environments.List(ctx, environmentListOptions(filterGroup)...)
+	if helper.AddDiagnosticOnError(&resp.Diagnostics, "Listing Environments", err) {
+		return
+	}
+	envs, err := pager.Collect(ctx, 0)
+	if helper.AddDiagnosticOnError(&resp.Diagnostics, "Listing Environments", err) {
+		return
+	}
+
 	for _, e := range envs {
 		envResource := environmentToResourceModel(e)
 
@@ -31,17 +31,56 @@ var (
 	_ resource.Resource                 = &flexibleBoardResource{}
 	_ resource.ResourceWithConfigure    = &flexibleBoardResource{}
 	_ resource.ResourceWithImportState  = &flexibleBoardResource{}
+	_ resource.ResourceWithModifyPlan   = &flexibleBoardResource{}
 	_ resource.ResourceWithUpgradeState = &flexibleBoardResource{}
 )
 
 type flexibleBoardResource struct {
-	client *client.Client
+	client      *client.Client
+	permissions permissionCheck
 }
 
 func NewFlexibleBoardResource() resource.Resource {
 	return &flexibleBoardResource{}
 }
 
+// flexibleBoardFieldPaths maps the fields named in the API's validation errors
+// to the resource's attributes.
+var flexibleBoardFieldPaths = helper.FieldPaths{
+	"name":                                        "name",
+	"description":                                 "description",
+	"tags":                                        "tags",
+	"panels":                                      "panel",
+	"panels[].type":                               "panel[].type",
+	"panels[].position":                           "panel[].position",
+	"panels[].position.x_coordinate":              "panel[].position.x_coordinate",
+	"panels[].position.y_coordinate":              "panel[].position.y_coordinate",
+	"panels[].position.height":                    "panel[].position.height",
+	"panels[].position.width":                     "panel[].position.width",
+	"panels[].slo_panel":                          "panel[].slo_panel[0]",
+	"panels[].slo_panel.slo_id":                   "panel[].slo_panel[0].slo_id",
+	"panels[].text_panel":                         "panel[].text_panel[0]",
+	"panels[].text_panel.content":                 "panel[].text_panel[0].content",
+	"panels[].query_panel":                        "panel[].query_panel[0]",
+	"panels[].query_panel.query_id":               "panel[].query_panel[0].query_id",
+	"panels[].query_panel.query_annotation_id":    "panel[].query_panel[0].query_annotation_id",
+	"panels[].query_panel.query_style":            "panel[].query_panel[0].query_style",
+	"panels[].query_panel.visualization_settings": "panel[].query_panel[0].visualization_settings[0]",
+	"panels[].query_panel.visualization_settings.utc_xaxis":                    "panel[].query_panel[0].visualization_settings[0].use_utc_xaxis",
+	"panels[].query_panel.visualization_settings.hide_markers":                 "panel[].query_panel[0].visualization_settings[0].hide_markers",
+	"panels[].query_panel.visualization_settings.hide_hovers":                  "panel[].query_panel[0].visualization_settings[0].hide_hovers",
+	"panels[].query_panel.visualization_settings.overlaid_charts":              "panel[].query_panel[0].visualization_settings[0].prefer_overlaid_charts",
+	"panels[].query_panel.visualization_settings.hide_compare":                 "panel[].query_panel[0].visualization_settings[0].hide_compare",
+	"panels[].query_panel.visualization_settings.charts":                       "panel[].query_panel[0].visualization_settings[0].chart",
+	"panels[].query_panel.visualization_settings.charts[].chart_type":          "panel[].query_panel[0].visualization_settings[0].chart[].chart_type",
+	"panels[].query_panel.visualization_settings.charts[].chart_index":         "panel[].query_panel[0].visualization_settings[0].chart[].chart_index",
+	"panels[].query_panel.visualization_settings.charts[].omit_missing_values": "panel[].query_panel[0].visualization_settings[0].chart[].omit_missing_values",
+	"panels[].query_panel.visualization_settings.charts[].log_scale":           "panel[].query_panel[0].visualization_settings[0].chart[].use_log_scale",
+	"preset_filters":          "preset_filter",
+	"preset_filters[].column": "preset_filter[].column",
+	"preset_filters[].alias":  "preset_filter[].alias",
+}
+
 func (*flexibleBoardResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_flexible_board"
 }
@@ -58,6 +97,7 @@ func (r *flexibleBoardResource) Configure(_ context.Context, req resource.Config
 		return
 	}
 	r.client = c
+	r.permissions = w.RequireAccess(helper.AccessBoards)
 }
 
 func (*flexibleBoardResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
@@ -627,6 +667,10 @@ func (*flexibleBoardResource) UpgradeState(ctx context.Context) map[int64]resour
 	}
 }
 
+func (r *flexibleBoardResource) ModifyPlan(_ context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	checkPermissions(req, resp, r.permissions)
+}
+
 func (r *flexibleBoardResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var plan, config models.FlexibleBoardResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
@@ -658,7 +702,7 @@ func (r *flexibleBoardResource) Create(ctx context.Context, req resource.CreateR
 	}
 
 	board, err := r.client.Boards.Create(ctx, createRequest)
-	if helper.AddDiagnosticOnError(&resp.Diagnostics, "Creating Board", err) {
+	if flexibleBoardFieldPaths.AddDiagnosticOnError(&resp.Diagnostics, "Creating Board", err) {
 		return
 	}
 
@@ -714,26 +758,12 @@ func (r *flexibleBoardResource) Read(ctx context.Context, req resource.ReadReque
 		return
 	}
 
-	var detailedErr client.DetailedError
 	board, err := r.client.Boards.Get(ctx, state.ID.ValueString())
-	if errors.As(err, &detailedErr) {
-		if detailedErr.IsNotFound() {
-			// if not found consider it deleted -- remove it from state
-			resp.State.RemoveResource(ctx)
-			return
-		} else {
-			resp.Diagnostics.Append(helper.NewDetailedErrorDiagnostic(
-				"Error Reading Honeycomb Board",
-				&detailedErr,
-			))
-		}
-	} else if err != nil {
-		resp.Diagnostics.AddError(
-			"Error Reading Honeycomb Board",
-			"Unexpected error reading Board ID "+state.ID.ValueString()+": "+err.Error(),
-		)
-	}
-	if resp.Diagnostics.HasError() {
+	if errors.Is(err, client.ErrNotFound) {
+		// if not found consider it deleted -- remove it from state
+		resp.State.RemoveResource(ctx)
+		return
+	} else if helper.AddDiagnosticOnError(&resp.Diagnostics, "Reading Honeycomb Board", err) {
 		return
 	}
 
@@ -826,7 +856,7 @@ func (r *flexibleBoardResource) Update(ctx context.Context, req resource.UpdateR
 	}
 
 	board, err := r.client.Boards.Update(ctx, updateRequest)
-	if helper.AddDiagnosticOnError(&resp.Diagnostics, "Updating Honeycomb Board", err) {
+	if flexibleBoardFieldPaths.AddDiagnosticOnError(&resp.Diagnostics, "Updating Honeycomb Board", err) {
 		return
 	}
 
@@ -889,23 +919,10 @@ func (r *flexibleBoardResource) Delete(ctx context.Context, req resource.DeleteR
 		return
 	}
 
-	var detailedErr client.DetailedError
 	err := r.client.Boards.Delete(ctx, state.ID.ValueString())
-	if err != nil {
-		if errors.As(err, &detailedErr) {
-			// if not found consider it deleted -- so don't error
-			if !detailedErr.IsNotFound() {
-				resp.Diagnostics.Append(helper.NewDetailedErrorDiagnostic(
-					"Error Deleting Honeycomb Board",
-					&detailedErr,
-				))
-			}
-		} else {
-			resp.Diagnostics.AddError(
-				"Error Deleting Honeycomb Board",
-				"Could not delete Board ID "+state.ID.ValueString()+": "+err.Error(),
-			)
-		}
+	// if not found consider it deleted -- so don't error
+	if !errors.Is(err, client.ErrNotFound) {
+		helper.AddDiagnosticOnError(&resp.Diagnostics, "Deleting Honeycomb Board", err)
 	}
 }
 
@@ -82,13 +82,9 @@ func getEnvironmentSweeper(name string) *resource.Sweeper {
 				return fmt.Errorf("could not list environments: %w", err)
 			}
 
-			envs := make([]*v2client.Environment, 0)
-			for pager.HasNext() {
-				items, err := pager.Next(ctx)
-				if err != nil {
-					return fmt.Errorf("error listing environments: %w", err)
-				}
-				envs = append(envs, items...)
+			envs, err := pager.Collect(ctx, 0)
+			if err != nil {
+				return fmt.Errorf("error listing environments: %w", err)
 			}
 
 			for _, e := range envs {
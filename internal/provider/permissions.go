@@ -0,0 +1,82 @@
+package provider
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+
+	v2client "github.com/honeycombio/terraform-provider-honeycombio/client/v2"
+	"github.com/honeycombio/terraform-provider-honeycombio/internal/helper"
+)
+
+// v2 API key scopes needed to manage resources. Write scopes imply the
+// corresponding read scope.
+const (
+	scopeAPIKeysWrite      = "api-keys:write"
+	scopeEnvironmentsWrite = "environments:write"
+)
+
+// permissionCheck adds an error to the diagnostics if the configured API
+// key is known to lack the permission needed to manage a resource.
+type permissionCheck func(diags *diag.Diagnostics)
+
+// RequireAccess returns a check that the v1 API key has been granted the
+// access.
+//
+// If the auth metadata could not be fetched when the provider was
+// configured the check always passes, leaving it to the API to refuse.
+func (c *ConfiguredClient) RequireAccess(access helper.APIKeyAccess) permissionCheck {
+	return func(diags *diag.Diagnostics) {
+		if c.v1auth == nil || access.Granted(*c.v1auth) {
+			return
+		}
+		diags.AddError("Insufficient API Key Permissions", access.Missing())
+	}
+}
+
+// RequireScope returns a check that the v2 API key has been granted the
+// scope.
+//
+// If the auth metadata could not be fetched when the provider was
+// configured the check always passes, leaving it to the API to refuse.
+func (c *ConfiguredClient) RequireScope(scope string) permissionCheck {
+	return func(diags *diag.Diagnostics) {
+		if c.v2auth == nil || hasScope(c.v2auth, scope) {
+			return
+		}
+		diags.AddError(
+			"Insufficient API Key Permissions",
+			"The configured Management API key does not have the \""+scope+"\" scope needed to manage this resource. "+
+				"Grant it to the key in the Team's API key settings, or configure a key which has it.",
+		)
+	}
+}
+
+// hasScope reports whether the scope has been granted, directly or by the
+// corresponding write scope.
+func hasScope(auth *v2client.AuthMetadata, scope string) bool {
+	if slices.Contains(auth.Scopes, scope) {
+		return true
+	}
+	if resource, ok := strings.CutSuffix(scope, ":read"); ok {
+		return slices.Contains(auth.Scopes, resource+":write")
+	}
+	return false
+}
+
+// checkPermissions runs the permission check when the plan changes the
+// resource, so that a key lacking permission is reported at plan time
+// rather than partway through an apply.
+//
+// The check is nil if the resource has not been configured.
+func checkPermissions(req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse, check permissionCheck) {
+	if check == nil {
+		return
+	}
+	if !req.Plan.Raw.IsNull() && !req.State.Raw.IsNull() && req.Plan.Raw.Equal(req.State.Raw) {
+		return
+	}
+	check(&resp.Diagnostics)
+}
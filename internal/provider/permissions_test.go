@@ -0,0 +1,105 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	tfresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/honeycombio/terraform-provider-honeycombio/client"
+	v2client "github.com/honeycombio/terraform-provider-honeycombio/client/v2"
+	"github.com/honeycombio/terraform-provider-honeycombio/internal/helper"
+)
+
+func TestConfiguredClient_RequireAccess(t *testing.T) {
+	t.Parallel()
+
+	auth := &client.AuthMetadata{}
+	auth.APIKeyAccess.Boards = true
+
+	tests := []struct {
+		name    string
+		cc      *ConfiguredClient
+		access  helper.APIKeyAccess
+		wantErr bool
+	}{
+		{name: "granted", cc: &ConfiguredClient{v1auth: auth}, access: helper.AccessBoards},
+		{name: "not granted", cc: &ConfiguredClient{v1auth: auth}, access: helper.AccessTriggers, wantErr: true},
+		{name: "unknown", cc: &ConfiguredClient{}, access: helper.AccessTriggers},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var diags diag.Diagnostics
+			tt.cc.RequireAccess(tt.access)(&diags)
+			assert.Equal(t, tt.wantErr, diags.HasError())
+			if tt.wantErr {
+				assert.Contains(t, diags[0].Detail(), `"Manage Triggers" permission`)
+			}
+		})
+	}
+}
+
+func TestConfiguredClient_RequireScope(t *testing.T) {
+	t.Parallel()
+
+	auth := &v2client.AuthMetadata{Scopes: []string{"api-keys:write", "environments:read"}}
+
+	tests := []struct {
+		name    string
+		cc      *ConfiguredClient
+		scope   string
+		wantErr bool
+	}{
+		{name: "granted", cc: &ConfiguredClient{v2auth: auth}, scope: "api-keys:write"},
+		{name: "implied by write", cc: &ConfiguredClient{v2auth: auth}, scope: "api-keys:read"},
+		{name: "not granted", cc: &ConfiguredClient{v2auth: auth}, scope: "environments:write", wantErr: true},
+		{name: "unknown", cc: &ConfiguredClient{}, scope: "environments:write"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var diags diag.Diagnostics
+			tt.cc.RequireScope(tt.scope)(&diags)
+			assert.Equal(t, tt.wantErr, diags.HasError())
+			if tt.wantErr {
+				assert.Contains(t, diags[0].Detail(), `"environments:write" scope`)
+			}
+		})
+	}
+}
+
+func TestCheckPermissions(t *testing.T) {
+	t.Parallel()
+
+	deny := (&ConfiguredClient{v1auth: &client.AuthMetadata{}}).RequireAccess(helper.AccessBoards)
+	value := func(s string) tftypes.Value {
+		return tftypes.NewValue(tftypes.String, s)
+	}
+	null := tftypes.NewValue(tftypes.String, nil)
+
+	tests := []struct {
+		name        string
+		state, plan tftypes.Value
+		check       permissionCheck
+		wantErr     bool
+	}{
+		{name: "create", state: null, plan: value("a"), check: deny, wantErr: true},
+		{name: "update", state: value("a"), plan: value("b"), check: deny, wantErr: true},
+		{name: "destroy", state: value("a"), plan: null, check: deny, wantErr: true},
+		{name: "no changes", state: value("a"), plan: value("a"), check: deny},
+		{name: "not configured", state: null, plan: value("a")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := tfresource.ModifyPlanRequest{
+				State: tfsdk.State{Raw: tt.state},
+				Plan:  tfsdk.Plan{Raw: tt.plan},
+			}
+			var resp tfresource.ModifyPlanResponse
+			checkPermissions(req, &resp, tt.check)
+			assert.Equal(t, tt.wantErr, resp.Diagnostics.HasError())
+		})
+	}
+}
@@ -4,20 +4,28 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/http"
 	"os"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/float64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 
 	"github.com/honeycombio/terraform-provider-honeycombio/client"
 	v2client "github.com/honeycombio/terraform-provider-honeycombio/client/v2"
 	"github.com/honeycombio/terraform-provider-honeycombio/internal/features"
 	"github.com/honeycombio/terraform-provider-honeycombio/internal/helper"
 	"github.com/honeycombio/terraform-provider-honeycombio/internal/helper/log"
+	"github.com/honeycombio/terraform-provider-honeycombio/internal/helper/validation"
 )
 
 // Ensure HoneycombioProvider satisfies various provider interfaces.
@@ -38,6 +46,18 @@ type HoneycombioProviderModel struct {
 	APIUrl    types.String     `tfsdk:"api_url"`
 	Debug     types.Bool       `tfsdk:"debug"`
 	Features  []features.Model `tfsdk:"features"`
+
+	MaxRequestsPerSecond types.Float64 `tfsdk:"max_requests_per_second"`
+	RetryMax             types.Int64   `tfsdk:"retry_max"`
+	RetryWaitMin         types.String  `tfsdk:"retry_wait_min"`
+	RetryWaitMax         types.String  `tfsdk:"retry_wait_max"`
+	PageSize             types.Int64   `tfsdk:"page_size"`
+
+	CACertFile     types.String `tfsdk:"ca_cert_file"`
+	ClientCertFile types.String `tfsdk:"client_cert_file"`
+	ClientKeyFile  types.String `tfsdk:"client_key_file"`
+	ProxyURL       types.String `tfsdk:"proxy_url"`
+	TLSMinVersion  types.String `tfsdk:"tls_min_version"`
 }
 
 func New(version string) provider.Provider {
@@ -72,6 +92,64 @@ func (p *HoneycombioProvider) Schema(_ context.Context, _ provider.SchemaRequest
 				MarkdownDescription: "Enable the API client's debug logs. By default, a `TF_LOG` setting of debug or higher will enable this.",
 				Optional:            true,
 			},
+			"ca_cert_file": schema.StringAttribute{
+				MarkdownDescription: "Path to a PEM-encoded bundle of CA certificates to trust, in addition to the system's, when connecting to the Honeycomb API. Useful when traffic passes through a proxy intercepting TLS. It can also be set via the `HONEYCOMB_CA_CERT_FILE` environment variable.",
+				Optional:            true,
+			},
+			"client_cert_file": schema.StringAttribute{
+				MarkdownDescription: "Path to a PEM-encoded client certificate to present when connecting to the Honeycomb API, for mutual TLS. Must be set with `client_key_file`. It can also be set via the `HONEYCOMB_CLIENT_CERT_FILE` environment variable.",
+				Optional:            true,
+			},
+			"client_key_file": schema.StringAttribute{
+				MarkdownDescription: "Path to the PEM-encoded private key of the client certificate. Must be set with `client_cert_file`. It can also be set via the `HONEYCOMB_CLIENT_KEY_FILE` environment variable.",
+				Optional:            true,
+			},
+			"proxy_url": schema.StringAttribute{
+				MarkdownDescription: "URL of a proxy to send requests to the Honeycomb API through. By default, the proxy is taken from the `HTTPS_PROXY` and `NO_PROXY` environment variables. It can also be set via the `HONEYCOMB_PROXY_URL` environment variable.",
+				Optional:            true,
+			},
+			"tls_min_version": schema.StringAttribute{
+				MarkdownDescription: "The minimum TLS version to accept when connecting to the Honeycomb API, one of `1.2` or `1.3`. It can also be set via the `HONEYCOMB_TLS_MIN_VERSION` environment variable.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(client.TLSVersions()...),
+				},
+			},
+			"max_requests_per_second": schema.Float64Attribute{
+				MarkdownDescription: "The maximum number of requests per second the provider will make to the Honeycomb API. Regardless of this setting, the provider slows down as the rate limit reported by the API is approached.",
+				Optional:            true,
+				Validators: []validator.Float64{
+					float64validator.AtLeast(0),
+				},
+			},
+			"retry_max": schema.Int64Attribute{
+				MarkdownDescription: "The maximum number of times a failed request to the Honeycomb API is retried. Defaults to `30`, and `0` disables retries. Creating a trigger, marker, column, derived column or API key is only retried after a server error once the provider has checked the failed attempt did not create anything.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"retry_wait_min": schema.StringAttribute{
+				MarkdownDescription: "The minimum time to wait before retrying a failed request, when the API does not say how long to wait. After a server error, the wait is between `500ms` and `950ms` times the number of attempts, kept within `retry_wait_min` and `retry_wait_max`. Defaults to `200ms`.",
+				Optional:            true,
+				Validators: []validator.String{
+					validation.IsValidDuration(),
+				},
+			},
+			"retry_wait_max": schema.StringAttribute{
+				MarkdownDescription: "The maximum time to wait before retrying a failed request, when the API does not say how long to wait. Defaults to `1m`.",
+				Optional:            true,
+				Validators: []validator.String{
+					validation.IsValidDuration(),
+				},
+			},
+			"page_size": schema.Int64Attribute{
+				MarkdownDescription: "The number of results to fetch per request when listing resources from the Management API, such as environments. Defaults to `20`, and may be at most `100`.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.Between(1, v2client.MaxPageSize),
+				},
+			},
 		},
 		Blocks: map[string]schema.Block{
 			"features": features.GetFeaturesBlock(),
@@ -86,6 +164,7 @@ func (p *HoneycombioProvider) Resources(ctx context.Context) []func() resource.R
 		NewBurnAlertResource,
 		NewColumnResource,
 		NewDatasetResource,
+		NewDerivedColumnResource,
 		NewTriggerResource,
 		NewQueryResource,
 		NewQueryAnnotationResource,
@@ -102,6 +181,7 @@ func (p *HoneycombioProvider) DataSources(ctx context.Context) []func() datasour
 		NewDatasetDataSource,
 		NewDatasetsDataSource,
 		NewDerivedColumnDataSource,
+		NewDerivedColumnEvaluationDataSource,
 		NewDerivedColumnsDataSource,
 		NewEnvironmentDataSource,
 		NewEnvironmentsDataSource,
@@ -218,12 +298,60 @@ func (p *HoneycombioProvider) Configure(ctx context.Context, req provider.Config
 		p.version,
 	)
 
+	transport := client.TransportConfigFromEnv()
+	if !config.CACertFile.IsNull() {
+		transport.CACertFile = config.CACertFile.ValueString()
+	}
+	if !config.ClientCertFile.IsNull() {
+		transport.ClientCertFile = config.ClientCertFile.ValueString()
+	}
+	if !config.ClientKeyFile.IsNull() {
+		transport.ClientKeyFile = config.ClientKeyFile.ValueString()
+	}
+	if !config.ProxyURL.IsNull() {
+		transport.ProxyURL = config.ProxyURL.ValueString()
+	}
+	if !config.TLSMinVersion.IsNull() {
+		transport.TLSMinVersion = config.TLSMinVersion.ValueString()
+	}
+	var httpClient *http.Client
+	if !transport.IsZero() {
+		var err error
+		httpClient, err = transport.NewHTTPClient()
+		if err != nil {
+			resp.Diagnostics.AddError("Unable to configure connections to the Honeycomb API", err.Error())
+			return
+		}
+	}
+
+	var retryMax *int
+	if !config.RetryMax.IsNull() {
+		retryMax = client.ToPtr(int(config.RetryMax.ValueInt64()))
+	}
+	var retryWaitMin, retryWaitMax time.Duration
+	if !config.RetryWaitMin.IsNull() {
+		retryWaitMin, _ = time.ParseDuration(config.RetryWaitMin.ValueString())
+	}
+	if !config.RetryWaitMax.IsNull() {
+		retryWaitMax, _ = time.ParseDuration(config.RetryWaitMax.ValueString())
+	}
+
+	// the v1 and v2 clients share a limiter as they draw on the same
+	// rate limit budget
+	limiter := client.NewRateLimiter(config.MaxRequestsPerSecond.ValueFloat64())
+
 	if initv1Client {
 		client, err := client.NewClientWithConfig(&client.Config{
-			APIKey:    apiKey,
-			APIUrl:    config.APIUrl.ValueString(),
-			Debug:     debug,
-			UserAgent: userAgent,
+			APIKey:     apiKey,
+			APIUrl:     config.APIUrl.ValueString(),
+			Debug:      debug,
+			HTTPClient: httpClient,
+			UserAgent:  userAgent,
+
+			RateLimiter:  limiter,
+			RetryMax:     retryMax,
+			RetryWaitMin: retryWaitMin,
+			RetryWaitMax: retryWaitMax,
 		})
 		if helper.AddDiagnosticOnError(&resp.Diagnostics, "Unable to create Honeycomb API V1 Client", err) {
 			return
@@ -237,7 +365,15 @@ func (p *HoneycombioProvider) Configure(ctx context.Context, req provider.Config
 			APIKeySecret: keySecret,
 			BaseURL:      config.APIUrl.ValueString(),
 			Debug:        debug,
+			HTTPClient:   httpClient,
 			UserAgent:    userAgent,
+
+			RateLimiter:  limiter,
+			RetryMax:     retryMax,
+			RetryWaitMin: retryWaitMin,
+			RetryWaitMax: retryWaitMax,
+
+			PageSize: int(config.PageSize.ValueInt64()),
 		})
 		if helper.AddDiagnosticOnError(&resp.Diagnostics, "Unable to create Honeycomb API V2 Client", err) {
 			return
@@ -245,6 +381,8 @@ func (p *HoneycombioProvider) Configure(ctx context.Context, req provider.Config
 		cc.v2client = v2client
 	}
 
+	cc.fetchAuthMetadata(ctx)
+
 	resp.DataSourceData = cc
 	resp.ResourceData = cc
 }
@@ -254,6 +392,43 @@ type ConfiguredClient struct {
 	v1client *client.Client
 	v2client *v2client.Client
 	features *features.Features
+
+	// the auth metadata of the configured API keys, used to check their
+	// permissions at plan time. Nil if the key is not configured or the
+	// metadata could not be fetched.
+	v1auth *client.AuthMetadata
+	v2auth *v2client.AuthMetadata
+}
+
+// authMetadataTimeout bounds how long fetching the auth metadata may hold up
+// configuring the provider.
+const authMetadataTimeout = 10 * time.Second
+
+// fetchAuthMetadata fetches and caches the auth metadata of the configured
+// API keys.
+//
+// This is best effort: should it fail, the permission checks are skipped
+// and any problem with the key is reported by the API as it's used.
+func (c *ConfiguredClient) fetchAuthMetadata(ctx context.Context) {
+	ctx, cancel := context.WithTimeout(ctx, authMetadataTimeout)
+	defer cancel()
+
+	if c.v1client != nil {
+		auth, err := c.v1client.Auth.List(ctx)
+		if err != nil {
+			tflog.Debug(ctx, "Unable to fetch v1 API key auth metadata", map[string]any{"error": err.Error()})
+		} else {
+			c.v1auth = &auth
+		}
+	}
+	if c.v2client != nil {
+		auth, err := c.v2client.AuthInfo(ctx)
+		if err != nil {
+			tflog.Debug(ctx, "Unable to fetch v2 API key auth metadata", map[string]any{"error": err.Error()})
+		} else {
+			c.v2auth = auth
+		}
+	}
 }
 
 func (c *ConfiguredClient) V1Client() (*client.Client, error) {
@@ -24,11 +24,13 @@ import (
 var (
 	_ resource.Resource                = &queryAnnotationResource{}
 	_ resource.ResourceWithConfigure   = &queryAnnotationResource{}
+	_ resource.ResourceWithModifyPlan  = &queryAnnotationResource{}
 	_ resource.ResourceWithImportState = &queryAnnotationResource{}
 )
 
 type queryAnnotationResource struct {
-	client *client.Client
+	client      *client.Client
+	permissions permissionCheck
 }
 
 func NewQueryAnnotationResource() resource.Resource {
@@ -51,6 +53,11 @@ func (r *queryAnnotationResource) Configure(_ context.Context, req resource.Conf
 		return
 	}
 	r.client = c
+	r.permissions = w.RequireAccess(helper.AccessColumns)
+}
+
+func (r *queryAnnotationResource) ModifyPlan(_ context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	checkPermissions(req, resp, r.permissions)
 }
 
 func (*queryAnnotationResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
@@ -158,14 +165,9 @@ func (r *queryAnnotationResource) Read(ctx context.Context, req resource.ReadReq
 
 	dataset := helper.GetDatasetOrAll(state.Dataset)
 
-	var detailedErr client.DetailedError
 	queryAnnotation, err := r.client.QueryAnnotations.Get(ctx, dataset.ValueString(), state.ID.ValueString())
-	if errors.As(err, &detailedErr) {
-		if detailedErr.IsNotFound() {
-			resp.State.RemoveResource(ctx)
-			return
-		}
-		resp.Diagnostics.Append(helper.NewDetailedErrorDiagnostic("Reading Honeycomb Query Annotation", &detailedErr))
+	if errors.Is(err, client.ErrNotFound) {
+		resp.State.RemoveResource(ctx)
 		return
 	}
 	if helper.AddDiagnosticOnError(&resp.Diagnostics, "Reading Honeycomb Query Annotation", err) {
@@ -25,11 +25,13 @@ import (
 var (
 	_ resource.Resource                = &queryResource{}
 	_ resource.ResourceWithConfigure   = &queryResource{}
+	_ resource.ResourceWithModifyPlan  = &queryResource{}
 	_ resource.ResourceWithImportState = &queryResource{}
 )
 
 type queryResource struct {
-	client *client.Client
+	client      *client.Client
+	permissions permissionCheck
 }
 
 func NewQueryResource() resource.Resource {
@@ -52,6 +54,11 @@ func (r *queryResource) Configure(_ context.Context, req resource.ConfigureReque
 		return
 	}
 	r.client = c
+	r.permissions = w.RequireAccess(helper.AccessColumns)
+}
+
+func (r *queryResource) ModifyPlan(_ context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	checkPermissions(req, resp, r.permissions)
 }
 
 func (*queryResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
@@ -81,6 +88,7 @@ func (*queryResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *
 				Required: true,
 				PlanModifiers: []planmodifier.String{
 					modifiers.EquivalentQuerySpec(),
+					modifiers.QuerySpecChanges(),
 					stringplanmodifier.RequiresReplace(),
 				},
 				Validators: []validator.String{
@@ -147,26 +155,12 @@ func (r *queryResource) Read(ctx context.Context, req resource.ReadRequest, resp
 
 	dataset := helper.GetDatasetOrAll(state.Dataset)
 
-	var detailedErr client.DetailedError
 	query, err := r.client.Queries.Get(ctx, dataset.ValueString(), state.ID.ValueString())
-	if errors.As(err, &detailedErr) {
-		if detailedErr.IsNotFound() {
-			// if not found consider it deleted -- so just remove it from state
-			resp.State.RemoveResource(ctx)
-			return
-		} else {
-			resp.Diagnostics.Append(helper.NewDetailedErrorDiagnostic(
-				"Error Reading Honeycomb Query",
-				&detailedErr,
-			))
-		}
-	} else if err != nil {
-		resp.Diagnostics.AddError(
-			"Error Reading Honeycomb Query",
-			"Could not read Query ID "+state.ID.ValueString()+": "+err.Error(),
-		)
-	}
-	if resp.Diagnostics.HasError() {
+	if errors.Is(err, client.ErrNotFound) {
+		// if not found consider it deleted -- so just remove it from state
+		resp.State.RemoveResource(ctx)
+		return
+	} else if helper.AddDiagnosticOnError(&resp.Diagnostics, "Reading Honeycomb Query", err) {
 		return
 	}
 
@@ -185,6 +185,56 @@ EOT
 	})
 }
 
+// TestAcc_QueryResourceEquivalentCalculatedFieldSupressed tests that
+// reformatting the expression of a calculated field is not a change to the
+// query.
+func TestAcc_QueryResourceEquivalentCalculatedFieldSupressed(t *testing.T) {
+	dataset := testAccDataset()
+
+	config := func(expression string) string {
+		return fmt.Sprintf(`
+data "honeycombio_query_specification" "test" {
+  calculated_field {
+    name       = "fast"
+    expression = <<EOT
+%s
+EOT
+  }
+
+  calculation {
+    op = "COUNT"
+  }
+
+  breakdowns = ["fast"]
+}
+
+resource "honeycombio_query" "test" {
+  dataset    = "%s"
+  query_json = data.honeycombio_query_specification.test.json
+}`, expression, dataset)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 testAccPreCheck(t),
+		ProtoV6ProviderFactories: testAccProtoV6MuxServerFactory,
+		Steps: []resource.TestStep{
+			{
+				Config: config(`LTE($duration_ms,300)`),
+			},
+			{
+				Config:             config("$duration_ms <= 300"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: false,
+			},
+			{
+				Config:             config("LTE($duration_ms, 500)"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
 func TestAcc_QueryAllToUnset(t *testing.T) {
 	ctx := context.Background()
 	c := testAccClient(t)
@@ -2,7 +2,7 @@ package provider
 
 import (
 	"context"
-	"reflect"
+	"maps"
 	"slices"
 	"strconv"
 	"strings"
@@ -26,6 +26,20 @@ import (
 // Ensure the implementation satisfies the expected interfaces.
 var _ datasource.DataSource = &querySpecDataSource{}
 
+var querySpecFieldPaths = helper.FieldPaths{
+	"limit":                       "limit",
+	"time_range":                  "time_range",
+	"start_time":                  "start_time",
+	"end_time":                    "end_time",
+	"granularity":                 "granularity",
+	"havings[]":                   "having[]",
+	"havings[].calculate_op":      "having[].calculate_op",
+	"havings[].column":            "having[].column",
+	"orders[]":                    "order[]",
+	"orders[].op":                 "order[].op",
+	"compare_time_offset_seconds": "compare_time_offset",
+}
+
 // relationalFieldPrefixes are the prefixes that indicate a field references a related span.
 var relationalFieldPrefixes = []string{"root.", "child.", "parent.", "any.", "any2.", "any3.", "none."}
 
@@ -106,6 +120,16 @@ func (d *querySpecDataSource) Schema(_ context.Context, _ datasource.SchemaReque
 					"Used to compare current time range data with data from a previous time period.",
 				Optional: true,
 			},
+			"query_text": schema.StringAttribute{
+				Description: "The query written in the query text language, as an alternative to the other attributes and blocks, " +
+					"e.g. \"VISUALIZE COUNT, P99(duration_ms) WHERE status_code >= 500 GROUP BY route ORDER BY COUNT DESC LIMIT 50 TIME 2h\". " +
+					"Can not be combined with any of the other arguments describing the query.",
+				MarkdownDescription: "The query written in the query text language, as an alternative to the other attributes and blocks, " +
+					"e.g. `VISUALIZE COUNT, P99(duration_ms) WHERE status_code >= 500 GROUP BY route ORDER BY COUNT DESC LIMIT 50 TIME 2h`. " +
+					"Can not be combined with any of the other arguments describing the query.",
+				Optional:   true,
+				Validators: []validator.String{stringvalidator.LengthAtLeast(1)},
+			},
 			"json": schema.StringAttribute{
 				Description:         "The generated query specification in JSON format.",
 				MarkdownDescription: "JSON representation of the query according to the [Query Specification](https://docs.honeycomb.io/api/query-specification/#fields-on-a-query-specification), can be used as input for other resources.",
@@ -303,6 +327,11 @@ func (d *querySpecDataSource) Read(ctx context.Context, req datasource.ReadReque
 		return
 	}
 
+	if !data.QueryText.IsNull() {
+		d.readQueryText(ctx, &data, resp)
+		return
+	}
+
 	// Track all names used by calculations and formulas (must be unique across both)
 	type nameSource struct {
 		sourceType string // "calculation" or "formula"
@@ -553,24 +582,6 @@ func (d *querySpecDataSource) Read(ctx context.Context, req datasource.ReadReque
 
 		havings = append(havings, having)
 	}
-	// Ensure all havings have a matching calculate_op/column pair
-	for i, having := range havings {
-		found := false
-		for _, calc := range calculations {
-			if reflect.DeepEqual(having.Column, calc.Column) &&
-				*having.CalculateOp == calc.Op {
-				found = true
-				break
-			}
-		}
-		if !found {
-			resp.Diagnostics.AddAttributeError(
-				path.Root("having").AtListIndex(i).AtName("calculate_op"),
-				string(*having.CalculateOp)+" missing matching calculation",
-				"each having must have a matching calculation",
-			)
-		}
-	}
 
 	breakdowns := make([]string, len(data.Breakdowns))
 	for i, b := range data.Breakdowns {
@@ -607,60 +618,6 @@ func (d *querySpecDataSource) Read(ctx context.Context, req datasource.ReadReque
 
 		orders[i] = order
 	}
-	// ensure all orders have a matching calculation, calculated_field, formula, or breakdown
-	for i, order := range orders {
-		if order.Op != nil && *order.Op == client.CalculationOpHeatmap {
-			resp.Diagnostics.AddAttributeError(
-				path.Root("order").AtListIndex(i).AtName("op"),
-				"cannot order by HEATMAP",
-				"",
-			)
-			continue
-		}
-		found := false
-		for _, calc := range calculations {
-			if reflect.DeepEqual(order.Column, calc.Column) {
-				found = true
-				break
-			}
-			// Also check if order references a named calculation
-			if order.Column != nil && calc.Name != nil && *order.Column == *calc.Name {
-				found = true
-				break
-			}
-		}
-		if !found {
-			for _, calc := range calculatedFields {
-				if order.Column != nil && *order.Column == calc.Name {
-					found = true
-					break
-				}
-			}
-		}
-		if !found {
-			for _, formula := range formulas {
-				if order.Column != nil && *order.Column == formula.Name {
-					found = true
-					break
-				}
-			}
-		}
-		if !found {
-			for _, breakdown := range breakdowns {
-				if order.Column != nil && *order.Column == breakdown {
-					found = true
-					break
-				}
-			}
-		}
-		if !found {
-			resp.Diagnostics.AddAttributeError(
-				path.Root("order").AtListIndex(i),
-				"missing matching calculation, formula, or breakdown",
-				"each order must have a matching calculation, formula, or breakdown",
-			)
-		}
-	}
 
 	querySpec := &client.QuerySpec{
 		Calculations:      calculations,
@@ -686,71 +643,85 @@ func (d *querySpecDataSource) Read(ctx context.Context, req datasource.ReadReque
 		querySpec.Granularity = client.ToPtr(int(data.Granularity.ValueInt64()))
 	}
 	if !data.CompareTimeOffset.IsNull() {
-		timeOffset := data.CompareTimeOffset.ValueInt64()
-
-		// get the query time range to check if the compare time offset is greater
-		var queryTimeRange int64
-		if !data.TimeRange.IsUnknown() && !data.TimeRange.IsNull() {
-			queryTimeRange = data.TimeRange.ValueInt64()
-		} else if !data.StartTime.IsUnknown() && !data.StartTime.IsNull() && !data.EndTime.IsUnknown() && !data.EndTime.IsNull() {
-			queryTimeRange = data.EndTime.ValueInt64() - data.StartTime.ValueInt64()
-		} else {
-			queryTimeRange = client.DefaultQueryTimeRange
-		}
+		querySpec.CompareTimeOffsetSeconds = client.ToPtr(int(data.CompareTimeOffset.ValueInt64()))
+	}
 
-		if timeOffset < queryTimeRange {
-			resp.Diagnostics.AddAttributeError(
-				path.Root("compare_time_offset"),
-				"compare_time_offset must be greater than the queries time range.",
-				"",
-			)
-		}
-		if !slices.Contains(client.ValidTimeCompareOffsets, data.CompareTimeOffset.ValueInt64()) {
-			validTimeOffsets := append([]int64{queryTimeRange}, client.ValidTimeCompareOffsets...)
-			// Convert int to string for the error message
-			timeOverTimeStrings := make([]string, len(validTimeOffsets))
-			for i, val := range validTimeOffsets {
-				timeOverTimeStrings[i] = strconv.FormatInt(val, 10)
-			}
+	// check the query as a whole against the rules the API will apply to it
+	querySpecFieldPaths.AddDiagnosticOnError(&resp.Diagnostics, "validating query specification", querySpec.Validate())
+
+	// if we encountered any errors during parsing, we'll stop here
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
+	d.setQuerySpec(ctx, &data, querySpec, resp)
+}
+
+// readQueryText builds the query specification from the query_text attribute,
+// which can not be combined with any of the other attributes describing the query.
+func (d *querySpecDataSource) readQueryText(ctx context.Context, data *models.QuerySpecificationModel, resp *datasource.ReadResponse) {
+	conflicting := map[string]bool{
+		"filter_combination":  !data.FilterCombination.IsNull(),
+		"breakdowns":          len(data.Breakdowns) > 0,
+		"limit":               !data.Limit.IsNull(),
+		"time_range":          !data.TimeRange.IsNull(),
+		"start_time":          !data.StartTime.IsNull(),
+		"end_time":            !data.EndTime.IsNull(),
+		"granularity":         !data.Granularity.IsNull(),
+		"compare_time_offset": !data.CompareTimeOffset.IsNull(),
+		"calculation":         len(data.Calculations) > 0,
+		"calculated_field":    len(data.CalculatedFields) > 0,
+		"formula":             len(data.Formulas) > 0,
+		"filter":              len(data.Filters) > 0,
+		"having":              len(data.Havings) > 0,
+		"order":               len(data.Orders) > 0,
+	}
+	for _, name := range slices.Sorted(maps.Keys(conflicting)) {
+		if conflicting[name] {
 			resp.Diagnostics.AddAttributeError(
-				path.Root("compare_time_offset"),
-				"compare_time_offset is an invalid value.",
-				"Valid values are: "+strings.Join(timeOverTimeStrings, ", "),
+				path.Root(name),
+				"Invalid Attribute Combination",
+				"\""+name+"\" can not be specified when \"query_text\" is specified",
 			)
 		}
-
-		querySpec.CompareTimeOffsetSeconds = client.ToPtr(int(data.CompareTimeOffset.ValueInt64()))
+	}
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	if querySpec.TimeRange != nil && querySpec.StartTime != nil && querySpec.EndTime != nil {
-		resp.Diagnostics.AddError(
-			"invalid time configuration",
-			"specify at most two of time_range, start_time and end_time",
+	querySpec, err := client.ParseQueryText(data.QueryText.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("query_text"),
+			"Error parsing query text",
+			err.Error(),
 		)
+		return
 	}
-	if querySpec.TimeRange != nil && querySpec.Granularity != nil {
-		if *querySpec.Granularity > *querySpec.TimeRange {
-			resp.Diagnostics.AddAttributeError(
-				path.Root("granularity"),
-				"invalid granularity",
-				"granularity can not be greater than time_range",
-			)
-		}
-		if *querySpec.Granularity != 0 && *querySpec.Granularity < (*querySpec.TimeRange/1000) {
-			resp.Diagnostics.AddAttributeError(
-				path.Root("granularity"),
-				"invalid granularity",
-				"granularity can not be less than time_range/1000",
-			)
-		}
+
+	// apply the same defaults as when building the query from its attributes,
+	// so that either way of writing a query results in the same JSON
+	if len(querySpec.Calculations) == 0 {
+		querySpec.Calculations = []client.CalculationSpec{{Op: client.CalculationOpCount}}
+	}
+	if querySpec.TimeRange == nil {
+		querySpec.TimeRange = client.ToPtr(client.DefaultQueryTimeRange)
 	}
 
-	// if we encountered any errors during parsing, we'll stop here
-	if resp.Diagnostics.HasError() {
+	if err := querySpec.Validate(); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("query_text"),
+			"Error validating query specification",
+			err.Error(),
+		)
 		return
 	}
 
+	d.setQuerySpec(ctx, data, querySpec, resp)
+}
+
+// setQuerySpec sets the JSON and ID of the query specification in the state.
+func (d *querySpecDataSource) setQuerySpec(ctx context.Context, data *models.QuerySpecificationModel, querySpec *client.QuerySpec, resp *datasource.ReadResponse) {
 	json, err := querySpec.Encode()
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -762,6 +733,6 @@ func (d *querySpecDataSource) Read(ctx context.Context, req datasource.ReadReque
 	data.Json = types.StringValue(json)
 	data.ID = types.StringValue(strconv.Itoa(hashcode.String(json)))
 
-	diags := resp.State.Set(ctx, &data)
+	diags := resp.State.Set(ctx, data)
 	resp.Diagnostics.Append(diags...)
 }
@@ -1415,3 +1415,49 @@ output "query_json" {
 		},
 	})
 }
+
+func TestAcc_QuerySpecificationDataSource_queryText(t *testing.T) {
+	// Note: By default go encodes `<` and `>` for html, hence the `\u003e`
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 testAccPreCheck(t),
+		ProtoV6ProviderFactories: testAccProtoV6MuxServerFactory,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+data "honeycombio_query_specification" "test" {
+  query_text = "VISUALIZE COUNT, P99(duration_ms) WHERE service.name = api AND status_code >= 500 GROUP BY route ORDER BY COUNT DESC LIMIT 50 TIME 2h"
+}
+
+output "query_json" {
+  value = data.honeycombio_query_specification.test.json
+}`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckOutput("query_json", `{"calculations":[{"op":"COUNT"},{"op":"P99","column":"duration_ms"}],"filters":[{"column":"service.name","op":"=","value":"api"},{"column":"status_code","op":"\u003e=","value":500}],"breakdowns":["route"],"orders":[{"op":"COUNT","order":"descending"}],"limit":50,"time_range":7200}`),
+				),
+			},
+			{
+				Config: `
+data "honeycombio_query_specification" "test" {
+  query_text = "VISUALIZE COUNT WHERE"
+}`,
+				ExpectError: regexp.MustCompile(`expected a column`),
+			},
+			{
+				Config: `
+data "honeycombio_query_specification" "test" {
+  query_text = "VISUALIZE COUNT"
+
+  breakdowns = ["route"]
+}`,
+				ExpectError: regexp.MustCompile(`"breakdowns" can not be specified when "query_text" is specified`),
+			},
+			{
+				Config: `
+data "honeycombio_query_specification" "test" {
+  query_text = "VISUALIZE COUNT ORDER BY MAX(duration_ms)"
+}`,
+				ExpectError: regexp.MustCompile(`missing matching calculation`),
+			},
+		},
+	})
+}
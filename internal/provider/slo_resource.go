@@ -28,17 +28,31 @@ import (
 var (
 	_ resource.Resource                = &sloResource{}
 	_ resource.ResourceWithConfigure   = &sloResource{}
+	_ resource.ResourceWithModifyPlan  = &sloResource{}
 	_ resource.ResourceWithImportState = &sloResource{}
 )
 
 type sloResource struct {
-	client *client.Client
+	client      *client.Client
+	permissions permissionCheck
 }
 
 func NewSLOResource() resource.Resource {
 	return &sloResource{}
 }
 
+// sloFieldPaths maps the fields named in the API's validation errors
+// to the resource's attributes.
+var sloFieldPaths = helper.FieldPaths{
+	"name":               "name",
+	"description":        "description",
+	"sli":                "sli",
+	"target_per_million": "target_percentage",
+	"time_period_days":   "time_period",
+	"dataset_slugs":      "datasets",
+	"tags":               "tags",
+}
+
 func (*sloResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_slo"
 }
@@ -55,6 +69,11 @@ func (r *sloResource) Configure(_ context.Context, req resource.ConfigureRequest
 		return
 	}
 	r.client = c
+	r.permissions = w.RequireAccess(helper.AccessSLOs)
+}
+
+func (r *sloResource) ModifyPlan(_ context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	checkPermissions(req, resp, r.permissions)
 }
 
 func (*sloResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
@@ -171,7 +190,7 @@ func (r *sloResource) Create(ctx context.Context, req resource.CreateRequest, re
 	}
 
 	slo, err := r.client.SLOs.Create(ctx, dataset.ValueString(), expandedSLO)
-	if helper.AddDiagnosticOnError(&resp.Diagnostics, "Creating Honeycomb SLO", err) {
+	if sloFieldPaths.AddDiagnosticOnError(&resp.Diagnostics, "Creating Honeycomb SLO", err) {
 		return
 	}
 
@@ -216,28 +235,12 @@ func (r *sloResource) Read(ctx context.Context, req resource.ReadRequest, resp *
 
 	dataset := helper.GetDatasetOrAll(state.Dataset)
 
-	var detailedErr client.DetailedError
 	slo, err := r.client.SLOs.Get(ctx, dataset.ValueString(), state.ID.ValueString())
-	if errors.As(err, &detailedErr) {
-		if detailedErr.IsNotFound() {
-			// if not found consider it deleted -- so just remove it from state
-			resp.State.RemoveResource(ctx)
-			return
-		}
-
-		resp.Diagnostics.Append(helper.NewDetailedErrorDiagnostic(
-			"Error Reading Honeycomb SLO",
-			&detailedErr,
-		))
+	if errors.Is(err, client.ErrNotFound) {
+		// if not found consider it deleted -- so just remove it from state
+		resp.State.RemoveResource(ctx)
 		return
-	} else if err != nil {
-		resp.Diagnostics.AddError(
-			"Error Reading Honeycomb SLO",
-			fmt.Sprintf("Could not read SLO %s: %s", state.ID.ValueString(), err),
-		)
-		return
-	}
-	if resp.Diagnostics.HasError() {
+	} else if helper.AddDiagnosticOnError(&resp.Diagnostics, "Reading Honeycomb SLO", err) {
 		return
 	}
 
@@ -286,7 +289,7 @@ func (r *sloResource) Update(ctx context.Context, req resource.UpdateRequest, re
 	}
 
 	slo, err := r.client.SLOs.Update(ctx, dataset.ValueString(), expandedSLO)
-	if helper.AddDiagnosticOnError(&resp.Diagnostics, "Updating Honeycomb SLO", err) {
+	if sloFieldPaths.AddDiagnosticOnError(&resp.Diagnostics, "Updating Honeycomb SLO", err) {
 		return
 	}
 
@@ -331,26 +334,10 @@ func (r *sloResource) Delete(ctx context.Context, req resource.DeleteRequest, re
 
 	dataset := helper.GetDatasetOrAll(state.Dataset)
 
-	var detailedErr client.DetailedError
 	err := r.client.SLOs.Delete(ctx, dataset.ValueString(), state.ID.ValueString())
-	if err != nil {
-		if errors.As(err, &detailedErr) {
-			if detailedErr.IsNotFound() {
-				return // if not found, consider it deleted
-			}
-
-			resp.Diagnostics.Append(helper.NewDetailedErrorDiagnostic(
-				"Error deleting SLO",
-				&detailedErr,
-			))
-			return
-		}
-
-		resp.Diagnostics.AddError(
-			"Error deleting SLO",
-			fmt.Sprintf("Could delete SLO %s: %s", state.ID.ValueString(), err),
-		)
-		return
+	// if not found consider it deleted -- so don't error
+	if !errors.Is(err, client.ErrNotFound) {
+		helper.AddDiagnosticOnError(&resp.Diagnostics, "deleting SLO", err)
 	}
 }
 
@@ -37,6 +37,7 @@ import (
 var (
 	_ resource.Resource                   = &triggerResource{}
 	_ resource.ResourceWithConfigure      = &triggerResource{}
+	_ resource.ResourceWithModifyPlan     = &triggerResource{}
 	_ resource.ResourceWithImportState    = &triggerResource{}
 	_ resource.ResourceWithValidateConfig = &triggerResource{}
 )
@@ -46,8 +47,9 @@ func NewTriggerResource() resource.Resource {
 }
 
 type triggerResource struct {
-	client  *client.Client
-	feature features.FeaturesIntelligence
+	client      *client.Client
+	feature     features.FeaturesIntelligence
+	permissions permissionCheck
 }
 
 // matches HH:mm timestamps with optional leading 0
@@ -55,6 +57,32 @@ type triggerResource struct {
 //	e.g. 9:00, 09:01
 var hhMMRegex = regexp.MustCompile(`^([0-1]?[0-9]|2[0-3]):[0-5][0-9]$`)
 
+// triggerFieldPaths maps the fields named in the API's validation errors
+// to the resource's attributes.
+var triggerFieldPaths = helper.FieldPaths{
+	"name":                     "name",
+	"description":              "description",
+	"disabled":                 "disabled",
+	"auto_investigate":         "auto_investigate",
+	"query_id":                 "query_id",
+	"query":                    "query_json",
+	"alert_type":               "alert_type",
+	"frequency":                "frequency",
+	"tags":                     "tags",
+	"threshold":                "threshold[0]",
+	"threshold.op":             "threshold[0].op",
+	"threshold.value":          "threshold[0].value",
+	"threshold.exceeded_limit": "threshold[0].exceeded_limit",
+	"evaluation_schedule":      "evaluation_schedule[0]",
+	"evaluation_schedule.window.days_of_week": "evaluation_schedule[0].days_of_week",
+	"evaluation_schedule.window.start_time":   "evaluation_schedule[0].start_time",
+	"evaluation_schedule.window.end_time":     "evaluation_schedule[0].end_time",
+	"recipients":                              "recipient",
+	"baseline_details":                        "baseline_details[0]",
+	"baseline_details.type":                   "baseline_details[0].type",
+	"baseline_details.offset_minutes":         "baseline_details[0].offset_minutes",
+}
+
 func (r *triggerResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_trigger"
 }
@@ -120,10 +148,10 @@ func (r *triggerResource) Schema(_ context.Context, _ resource.SchemaRequest, re
 					" While the JSON can be constructed manually, it is easiest to use the `honeycombio_query_specification` data source.",
 				PlanModifiers: []planmodifier.String{
 					modifiers.EquivalentQuerySpec(),
+					modifiers.QuerySpecChanges(),
 				},
 				Validators: []validator.String{
 					stringvalidator.ConflictsWith(path.MatchRelative().AtParent().AtName("query_id")),
-					validation.ValidQuerySpec(),
 					validation.ValidTriggerQuerySpec(),
 				},
 			},
@@ -265,6 +293,7 @@ func (r *triggerResource) Configure(_ context.Context, req resource.ConfigureReq
 		return
 	}
 	r.client = c
+	r.permissions = w.RequireAccess(helper.AccessTriggers)
 
 	f, err := w.Features()
 	if err != nil {
@@ -274,6 +303,10 @@ func (r *triggerResource) Configure(_ context.Context, req resource.ConfigureReq
 	r.feature = f.Intelligence
 }
 
+func (r *triggerResource) ModifyPlan(_ context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	checkPermissions(req, resp, r.permissions)
+}
+
 func (r *triggerResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var plan, config models.TriggerResourceModel
 	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
@@ -341,7 +374,7 @@ func (r *triggerResource) Create(ctx context.Context, req resource.CreateRequest
 	dataset := helper.GetDatasetOrAll(plan.Dataset)
 
 	trigger, err := r.client.Triggers.Create(ctx, dataset.ValueString(), newTrigger)
-	if helper.AddDiagnosticOnError(&resp.Diagnostics, "Creating Honeycomb Trigger", err) {
+	if triggerFieldPaths.AddDiagnosticOnError(&resp.Diagnostics, "Creating Honeycomb Trigger", err) {
 		return
 	}
 
@@ -393,26 +426,12 @@ func (r *triggerResource) Read(ctx context.Context, req resource.ReadRequest, re
 
 	dataset := helper.GetDatasetOrAll(state.Dataset)
 
-	var detailedErr client.DetailedError
 	trigger, err := r.client.Triggers.Get(ctx, dataset.ValueString(), state.ID.ValueString())
-	if errors.As(err, &detailedErr) {
-		if detailedErr.IsNotFound() {
-			// if not found consider it deleted -- so just remove it from state
-			resp.State.RemoveResource(ctx)
-			return
-		} else {
-			resp.Diagnostics.Append(helper.NewDetailedErrorDiagnostic(
-				"Error Reading Honeycomb Trigger",
-				&detailedErr,
-			))
-		}
-	} else if err != nil {
-		resp.Diagnostics.AddError(
-			"Error Reading Honeycomb Trigger",
-			"Unexpected error reading Trigger ID "+state.ID.ValueString()+": "+err.Error(),
-		)
-	}
-	if resp.Diagnostics.HasError() {
+	if errors.Is(err, client.ErrNotFound) {
+		// if not found consider it deleted -- so just remove it from state
+		resp.State.RemoveResource(ctx)
+		return
+	} else if helper.AddDiagnosticOnError(&resp.Diagnostics, "Reading Honeycomb Trigger", err) {
 		return
 	}
 
@@ -531,7 +550,7 @@ func (r *triggerResource) Update(ctx context.Context, req resource.UpdateRequest
 	dataset := helper.GetDatasetOrAll(plan.Dataset)
 
 	_, err := r.client.Triggers.Update(ctx, dataset.ValueString(), updatedTrigger)
-	if helper.AddDiagnosticOnError(&resp.Diagnostics, "Updating Honeycomb Trigger", err) {
+	if triggerFieldPaths.AddDiagnosticOnError(&resp.Diagnostics, "Updating Honeycomb Trigger", err) {
 		return
 	}
 
@@ -588,23 +607,10 @@ func (r *triggerResource) Delete(ctx context.Context, req resource.DeleteRequest
 
 	dataset := helper.GetDatasetOrAll(state.Dataset)
 
-	var detailedErr client.DetailedError
 	err := r.client.Triggers.Delete(ctx, dataset.ValueString(), state.ID.ValueString())
-	if err != nil {
-		if errors.As(err, &detailedErr) {
-			// if not found consider it deleted -- so don't error
-			if !detailedErr.IsNotFound() {
-				resp.Diagnostics.Append(helper.NewDetailedErrorDiagnostic(
-					"Error Deleting Honeycomb Trigger",
-					&detailedErr,
-				))
-			}
-		} else {
-			resp.Diagnostics.AddError(
-				"Error Deleting Honeycomb Trigger",
-				"Could not delete Trigger ID "+state.ID.ValueString()+": "+err.Error(),
-			)
-		}
+	// if not found consider it deleted -- so don't error
+	if !errors.Is(err, client.ErrNotFound) {
+		helper.AddDiagnosticOnError(&resp.Diagnostics, "Deleting Honeycomb Trigger", err)
 	}
 }
 
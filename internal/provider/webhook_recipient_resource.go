@@ -30,6 +30,7 @@ import (
 var (
 	_ resource.Resource                   = &webhookRecipientResource{}
 	_ resource.ResourceWithConfigure      = &webhookRecipientResource{}
+	_ resource.ResourceWithModifyPlan     = &webhookRecipientResource{}
 	_ resource.ResourceWithImportState    = &webhookRecipientResource{}
 	_ resource.ResourceWithValidateConfig = &webhookRecipientResource{}
 
@@ -39,13 +40,25 @@ var (
 )
 
 type webhookRecipientResource struct {
-	client *client.Client
+	client      *client.Client
+	permissions permissionCheck
 }
 
 func NewWebhookRecipientResource() resource.Resource {
 	return &webhookRecipientResource{}
 }
 
+// webhookRecipientFieldPaths maps the fields named in the API's validation errors
+// to the resource's attributes.
+var webhookRecipientFieldPaths = helper.FieldPaths{
+	"details.webhook_name":                        "name",
+	"details.webhook_url":                         "url",
+	"details.webhook_secret":                      "secret",
+	"details.webhook_headers":                     "header",
+	"details.webhook_payloads.payload_templates":  "template",
+	"details.webhook_payloads.template_variables": "variable",
+}
+
 func (*webhookRecipientResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_webhook_recipient"
 }
@@ -62,6 +75,11 @@ func (r *webhookRecipientResource) Configure(_ context.Context, req resource.Con
 		return
 	}
 	r.client = c
+	r.permissions = w.RequireAccess(helper.AccessRecipients)
+}
+
+func (r *webhookRecipientResource) ModifyPlan(_ context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	checkPermissions(req, resp, r.permissions)
 }
 
 func (*webhookRecipientResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
@@ -305,7 +323,7 @@ func (r *webhookRecipientResource) Create(ctx context.Context, req resource.Crea
 			WebhookHeaders:  expandWebhookHeaders(ctx, plan.Headers, &resp.Diagnostics),
 		},
 	})
-	if helper.AddDiagnosticOnError(&resp.Diagnostics, "Creating Honeycomb Webhook Recipient", err) {
+	if webhookRecipientFieldPaths.AddDiagnosticOnError(&resp.Diagnostics, "Creating Honeycomb Webhook Recipient", err) {
 		return
 	}
 
@@ -345,26 +363,12 @@ func (r *webhookRecipientResource) Read(ctx context.Context, req resource.ReadRe
 		return
 	}
 
-	var detailedErr client.DetailedError
 	rcpt, err := r.client.Recipients.Get(ctx, state.ID.ValueString())
-	if errors.As(err, &detailedErr) {
-		if detailedErr.IsNotFound() {
-			// if not found consider it deleted -- so just remove it from state
-			resp.State.RemoveResource(ctx)
-			return
-		} else {
-			resp.Diagnostics.Append(helper.NewDetailedErrorDiagnostic(
-				"Error Reading Honeycomb Webhook Recipient",
-				&detailedErr,
-			))
-		}
-	} else if err != nil {
-		resp.Diagnostics.AddError(
-			"Error Reading Honeycomb Webhook Recipient",
-			"Unexpected error reading Webhook Recipient "+state.ID.ValueString()+": "+err.Error(),
-		)
-	}
-	if resp.Diagnostics.HasError() {
+	if errors.Is(err, client.ErrNotFound) {
+		// if not found consider it deleted -- so just remove it from state
+		resp.State.RemoveResource(ctx)
+		return
+	} else if helper.AddDiagnosticOnError(&resp.Diagnostics, "Reading Honeycomb Webhook Recipient", err) {
 		return
 	}
 	if rcpt.Type != client.RecipientTypeWebhook {
@@ -418,7 +422,7 @@ func (r *webhookRecipientResource) Update(ctx context.Context, req resource.Upda
 			WebhookPayloads: webhookTemplatesToClientPayloads(ctx, plan.Templates, plan.Variables, &resp.Diagnostics),
 		},
 	})
-	if helper.AddDiagnosticOnError(&resp.Diagnostics, "Updating Honeycomb Webhook Recipient", err) {
+	if webhookRecipientFieldPaths.AddDiagnosticOnError(&resp.Diagnostics, "Updating Honeycomb Webhook Recipient", err) {
 		return
 	}
 
@@ -463,23 +467,10 @@ func (r *webhookRecipientResource) Delete(ctx context.Context, req resource.Dele
 		return
 	}
 
-	var detailedErr client.DetailedError
 	err := r.client.Recipients.Delete(ctx, state.ID.ValueString())
-	if err != nil {
-		if errors.As(err, &detailedErr) {
-			// if not found consider it deleted -- so don't error
-			if !detailedErr.IsNotFound() {
-				resp.Diagnostics.Append(helper.NewDetailedErrorDiagnostic(
-					"Error Deleting Honeycomb Webhook Recipient",
-					&detailedErr,
-				))
-			}
-		} else {
-			resp.Diagnostics.AddError(
-				"Error Deleting Honeycomb Webhook Recipient",
-				"Could not delete Webhook Recipient ID "+state.ID.ValueString()+": "+err.Error(),
-			)
-		}
+	// if not found consider it deleted -- so don't error
+	if !errors.Is(err, client.ErrNotFound) {
+		helper.AddDiagnosticOnError(&resp.Diagnostics, "Deleting Honeycomb Webhook Recipient", err)
 	}
 }
 
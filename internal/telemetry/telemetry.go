@@ -0,0 +1,68 @@
+// Package telemetry configures the export of the provider's OpenTelemetry
+// traces, recording the calls it makes to the Honeycomb API.
+package telemetry
+
+import (
+	"context"
+	"os"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.38.0"
+)
+
+const (
+	// EndpointEnv and TracesEndpointEnv are the standard OpenTelemetry
+	// environment variables for the OTLP endpoint. Traces are only exported
+	// if either is set.
+	EndpointEnv       = "OTEL_EXPORTER_OTLP_ENDPOINT"
+	TracesEndpointEnv = "OTEL_EXPORTER_OTLP_TRACES_ENDPOINT"
+
+	serviceName = "terraform-provider-honeycombio"
+
+	// batchTimeout is kept short, as Terraform gives the provider little
+	// time to exit once it is done with it
+	batchTimeout = time.Second
+)
+
+// Start installs a global TracerProvider exporting spans via OTLP over HTTP,
+// if an OTLP endpoint has been configured in the environment. The exporter
+// is otherwise configured by the standard OTEL_EXPORTER_OTLP_* environment
+// variables, such as OTEL_EXPORTER_OTLP_HEADERS.
+//
+// The returned function flushes any spans not yet exported and shuts the
+// TracerProvider down. It must be called before the provider exits.
+func Start(ctx context.Context, version string) (func(context.Context) error, error) {
+	noop := func(context.Context) error { return nil }
+	if os.Getenv(EndpointEnv) == "" && os.Getenv(TracesEndpointEnv) == "" {
+		return noop, nil
+	}
+
+	exporter, err := otlptracehttp.New(ctx)
+	if err != nil {
+		return noop, err
+	}
+	res, err := resource.New(ctx,
+		resource.WithTelemetrySDK(),
+		resource.WithAttributes(
+			semconv.ServiceName(serviceName),
+			semconv.ServiceVersion(version),
+		),
+		// OTEL_SERVICE_NAME and OTEL_RESOURCE_ATTRIBUTES take precedence
+		resource.WithFromEnv(),
+	)
+	if err != nil {
+		return noop, err
+	}
+
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter, sdktrace.WithBatchTimeout(batchTimeout)),
+		sdktrace.WithResource(res),
+	)
+	otel.SetTracerProvider(tp)
+
+	return tp.Shutdown, nil
+}
@@ -0,0 +1,97 @@
+package telemetry
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	collectortrace "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/honeycombio/terraform-provider-honeycombio/client"
+	"github.com/honeycombio/terraform-provider-honeycombio/client/fakeserver"
+)
+
+func TestStart(t *testing.T) {
+	t.Run("does nothing without an endpoint", func(t *testing.T) {
+		t.Setenv(EndpointEnv, "")
+		t.Setenv(TracesEndpointEnv, "")
+
+		before := otel.GetTracerProvider()
+		shutdown, err := Start(context.Background(), "test")
+		require.NoError(t, err)
+		require.NoError(t, shutdown(context.Background()))
+		assert.Equal(t, before, otel.GetTracerProvider())
+	})
+
+	t.Run("exports API calls to the collector", func(t *testing.T) {
+		// a local collector, receiving spans via OTLP over HTTP
+		var mu sync.Mutex
+		var spans []*tracepb.Span
+		collector := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/v1/traces", r.URL.Path)
+			body, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			var req collectortrace.ExportTraceServiceRequest
+			require.NoError(t, proto.Unmarshal(body, &req))
+
+			mu.Lock()
+			defer mu.Unlock()
+			for _, rs := range req.GetResourceSpans() {
+				for _, ss := range rs.GetScopeSpans() {
+					spans = append(spans, ss.GetSpans()...)
+				}
+			}
+			w.Header().Set("Content-Type", "application/x-protobuf")
+			w.WriteHeader(http.StatusOK)
+		}))
+		t.Cleanup(collector.Close)
+		t.Setenv(EndpointEnv, collector.URL)
+
+		before := otel.GetTracerProvider()
+		t.Cleanup(func() { otel.SetTracerProvider(before) })
+
+		ctx := context.Background()
+		shutdown, err := Start(ctx, "test")
+		require.NoError(t, err)
+
+		s := fakeserver.New()
+		t.Cleanup(s.Close)
+		c, err := client.NewClientWithConfig(&client.Config{APIKey: s.APIKey(), APIUrl: s.URL})
+		require.NoError(t, err)
+		_, err = c.Boards.Get(ctx, "missing")
+		require.ErrorIs(t, err, client.ErrNotFound)
+
+		require.NoError(t, shutdown(ctx))
+
+		mu.Lock()
+		defer mu.Unlock()
+		require.Len(t, spans, 1)
+		span := spans[0]
+		assert.Equal(t, "GET /1/boards/{id}", span.GetName())
+		assert.Equal(t, tracepb.Span_SPAN_KIND_CLIENT, span.GetKind())
+
+		attrs := make(map[string]any)
+		for _, kv := range span.GetAttributes() {
+			switch v := kv.GetValue().GetValue().(type) {
+			case *commonpb.AnyValue_StringValue:
+				attrs[kv.GetKey()] = v.StringValue
+			case *commonpb.AnyValue_IntValue:
+				attrs[kv.GetKey()] = v.IntValue
+			}
+		}
+		assert.Equal(t, "GET", attrs["http.request.method"])
+		assert.Equal(t, "/1/boards/{id}", attrs["url.template"])
+		assert.Equal(t, "boards", attrs["honeycomb.resource_type"])
+		assert.EqualValues(t, 1, attrs["honeycomb.attempt"])
+		assert.EqualValues(t, http.StatusNotFound, attrs["http.response.status_code"])
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"flag"
 	"log"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
@@ -13,12 +14,17 @@ import (
 
 	"github.com/honeycombio/terraform-provider-honeycombio/honeycombio"
 	"github.com/honeycombio/terraform-provider-honeycombio/internal/provider"
+	"github.com/honeycombio/terraform-provider-honeycombio/internal/telemetry"
 )
 
 // providerVersion represents the current version of the provider. It should be
 // overwritten during the release process.
 var providerVersion = "dev"
 
+// telemetryShutdownTimeout bounds how long exporting the remaining traces
+// may hold up the provider exiting.
+const telemetryShutdownTimeout = 2 * time.Second
+
 func main() {
 	ctx := context.Background()
 
@@ -26,6 +32,12 @@ func main() {
 	flag.BoolVar(&debugMode, "debug", false, "set to true to run the provider with support for debuggers like delve")
 	flag.Parse()
 
+	// export traces of the provider's API calls if an OTLP endpoint is configured
+	shutdownTelemetry, err := telemetry.Start(ctx, providerVersion)
+	if err != nil {
+		log.Printf("[WARN] unable to export traces: %s", err)
+	}
+
 	// build a pair of V6 Provider Servers to bridge the Plugin SDK based provider
 	// and the new Plugin Framework provider as things are migrated. We use the upgrade server
 	// as we've not fully upgraded to the framework server yet when we were using v5, so there might
@@ -60,6 +72,13 @@ func main() {
 		muxServer.ProviderServer,
 		serveOpts...,
 	)
+
+	shutdownCtx, cancel := context.WithTimeout(ctx, telemetryShutdownTimeout)
+	defer cancel()
+	if err := shutdownTelemetry(shutdownCtx); err != nil {
+		log.Printf("[WARN] unable to export traces: %s", err)
+	}
+
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -0,0 +1,782 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/honeycombio/terraform-provider-honeycombio/client"
+	"github.com/honeycombio/terraform-provider-honeycombio/internal/helper"
+)
+
+// The files the configuration is written to.
+const (
+	fileRecipients = "recipients.tf"
+	fileDatasets   = "datasets.tf"
+	fileQueries    = "queries.tf"
+	fileSLOs       = "slos.tf"
+	fileTriggers   = "triggers.tf"
+	fileBoards     = "boards.tf"
+	fileImports    = "imports.tf"
+	fileVariables  = "variables.tf"
+)
+
+// exporter walks an environment, writing its configuration as resources
+// of this provider.
+type exporter struct {
+	c *client.Client
+
+	files map[string]*hclwrite.File
+	names names
+	// skipped describes the configuration which could not be exported
+	skipped []string
+
+	// the following map the IDs of exported configuration to the address
+	// of the resource it was exported as, so it can be referenced
+	recipients  map[string]address
+	queries     map[string]address
+	annotations map[string]address
+	slos        map[string]address
+}
+
+// export writes the configuration of the environment as Terraform
+// configuration, returning the contents of each file by its name.
+func export(ctx context.Context, c *client.Client) (map[string][]byte, []string, error) {
+	e := &exporter{
+		c:           c,
+		files:       make(map[string]*hclwrite.File),
+		names:       make(names),
+		recipients:  make(map[string]address),
+		queries:     make(map[string]address),
+		annotations: make(map[string]address),
+		slos:        make(map[string]address),
+	}
+	if err := e.export(ctx); err != nil {
+		return nil, nil, err
+	}
+
+	files := make(map[string][]byte, len(e.files))
+	for name, f := range e.files {
+		files[name] = hclwrite.Format(f.Bytes())
+	}
+	return files, e.skipped, nil
+}
+
+func (e *exporter) export(ctx context.Context) error {
+	auth, err := e.c.Auth.List(ctx)
+	if err != nil {
+		return fmt.Errorf("getting auth metadata: %w", err)
+	}
+	// the environment and its API keys are managed with a v2 management
+	// key, and the secrets of API keys can't be read back after creation
+	if auth.Environment.Slug != "" {
+		e.skip("environment %s: the honeycombio_environment resource needs a management key, which this tool does not use", auth.Environment.Slug)
+	}
+	e.skip("API keys: the honeycombio_api_key resource can't import existing keys, as their secrets are only returned on creation")
+
+	if err := e.exportRecipients(ctx); err != nil {
+		return err
+	}
+
+	datasets, err := e.c.Datasets.List(ctx)
+	if err != nil {
+		return fmt.Errorf("listing datasets: %w", err)
+	}
+	slugs := make([]string, 0, len(datasets)+1)
+	for _, d := range datasets {
+		if err := e.exportDataset(ctx, d); err != nil {
+			return err
+		}
+		slugs = append(slugs, d.Slug)
+	}
+	slugs = append(slugs, client.EnvironmentWideSlug)
+
+	for _, slug := range slugs {
+		if err := e.exportDerivedColumns(ctx, slug); err != nil {
+			return err
+		}
+	}
+	for _, slug := range slugs {
+		if err := e.skipMarkerSettings(ctx, slug); err != nil {
+			return err
+		}
+		if err := e.exportQueryAnnotations(ctx, slug); err != nil {
+			return err
+		}
+		if err := e.exportSLOs(ctx, slug); err != nil {
+			return err
+		}
+		if err := e.exportTriggers(ctx, slug); err != nil {
+			return err
+		}
+	}
+
+	return e.exportBoards(ctx)
+}
+
+func (e *exporter) file(name string) *hclwrite.Body {
+	f, ok := e.files[name]
+	if !ok {
+		f = hclwrite.NewEmptyFile()
+		e.files[name] = f
+	}
+	return f.Body()
+}
+
+// block appends a resource or data source block to the file, named after
+// the hint.
+func (e *exporter) block(file string, mode, typ, hint string) (address, *hclwrite.Body) {
+	kind := "resource"
+	if mode != "" {
+		kind = mode
+	}
+
+	addr := address{mode: mode, typ: typ, name: e.names.next(kind+"."+typ, hint)}
+	body := e.file(file)
+	if len(body.Blocks()) > 0 {
+		body.AppendNewline()
+	}
+	return addr, body.AppendNewBlock(kind, []string{typ, addr.name}).Body()
+}
+
+func (e *exporter) resource(file, typ, hint string) (address, *hclwrite.Body) {
+	return e.block(file, "", typ, hint)
+}
+
+// importBlock appends an import block, bringing the existing configuration
+// with the ID under the management of the resource.
+func (e *exporter) importBlock(to address, id string) {
+	body := e.file(fileImports)
+	if len(body.Blocks()) > 0 {
+		body.AppendNewline()
+	}
+	b := body.AppendNewBlock("import", nil).Body()
+	b.SetAttributeTraversal("to", to.traversal())
+	setString(b, "id", id)
+}
+
+// sensitiveVariable declares a sensitive variable for a secret, so that it
+// is not written to the configuration, returning a reference to it.
+func (e *exporter) sensitiveVariable(hint, description string) hcl.Traversal {
+	name := e.names.next("variable", hint)
+	body := e.file(fileVariables)
+	if len(body.Blocks()) > 0 {
+		body.AppendNewline()
+	}
+	b := body.AppendNewBlock("variable", []string{name}).Body()
+	setString(b, "description", description)
+	b.SetAttributeTraversal("type", hcl.Traversal{hcl.TraverseRoot{Name: "string"}})
+	b.SetAttributeValue("sensitive", cty.True)
+	return hcl.Traversal{hcl.TraverseRoot{Name: "var"}, hcl.TraverseAttr{Name: name}}
+}
+
+func (e *exporter) skip(format string, args ...any) {
+	e.skipped = append(e.skipped, fmt.Sprintf(format, args...))
+}
+
+// importID returns the ID of configuration belonging to the dataset as
+// the provider's resources import it: prefixed by the dataset, unless it
+// is environment-wide.
+func importID(dataset, id string) string {
+	if dataset == client.EnvironmentWideSlug {
+		return id
+	}
+	return dataset + "/" + id
+}
+
+// setDataset sets the dataset attribute, unless the configuration is
+// environment-wide.
+func setDataset(b *hclwrite.Body, dataset string) {
+	if dataset != client.EnvironmentWideSlug {
+		setString(b, "dataset", dataset)
+	}
+}
+
+func setTags(b *hclwrite.Body, tags []client.Tag) {
+	m := make(map[string]string, len(tags))
+	for _, t := range tags {
+		m[t.Key] = t.Value
+	}
+	setStringMap(b, "tags", m)
+}
+
+func (e *exporter) exportRecipients(ctx context.Context) error {
+	recipients, err := e.c.Recipients.List(ctx)
+	if err != nil {
+		return fmt.Errorf("listing recipients: %w", err)
+	}
+
+	for _, r := range recipients {
+		var addr address
+		switch r.Type {
+		case client.RecipientTypeEmail:
+			var b *hclwrite.Body
+			addr, b = e.resource(fileRecipients, "honeycombio_email_recipient", r.Details.EmailAddress)
+			setString(b, "address", r.Details.EmailAddress)
+		case client.RecipientTypeSlack:
+			var b *hclwrite.Body
+			addr, b = e.resource(fileRecipients, "honeycombio_slack_recipient", r.Details.SlackChannel)
+			setString(b, "channel", r.Details.SlackChannel)
+		case client.RecipientTypePagerDuty:
+			var b *hclwrite.Body
+			addr, b = e.resource(fileRecipients, "honeycombio_pagerduty_recipient", r.Details.PDIntegrationName)
+			b.SetAttributeTraversal("integration_key", e.sensitiveVariable(
+				addr.name+"_integration_key",
+				"The integration key of the "+r.Details.PDIntegrationName+" PagerDuty integration",
+			))
+			setString(b, "integration_name", r.Details.PDIntegrationName)
+		case client.RecipientTypeMSTeams:
+			var b *hclwrite.Body
+			addr, b = e.resource(fileRecipients, "honeycombio_msteams_recipient", r.Details.WebhookName)
+			setString(b, "name", r.Details.WebhookName)
+			setString(b, "url", r.Details.WebhookURL)
+		case client.RecipientTypeMSTeamsWorkflow:
+			var b *hclwrite.Body
+			addr, b = e.resource(fileRecipients, "honeycombio_msteams_workflow_recipient", r.Details.WebhookName)
+			setString(b, "name", r.Details.WebhookName)
+			setString(b, "url", r.Details.WebhookURL)
+		case client.RecipientTypeWebhook:
+			addr = e.exportWebhookRecipient(r)
+		default:
+			e.skip("%s recipient %s: no resource manages this type of recipient", r.Type, r.ID)
+			continue
+		}
+
+		e.importBlock(addr, r.ID)
+		e.recipients[r.ID] = addr
+	}
+	return nil
+}
+
+func (e *exporter) exportWebhookRecipient(r client.Recipient) address {
+	addr, b := e.resource(fileRecipients, "honeycombio_webhook_recipient", r.Details.WebhookName)
+	setString(b, "name", r.Details.WebhookName)
+	setString(b, "url", r.Details.WebhookURL)
+	if r.Details.WebhookSecret != "" {
+		b.SetAttributeTraversal("secret", e.sensitiveVariable(
+			addr.name+"_secret",
+			"The secret of the "+r.Details.WebhookName+" webhook",
+		))
+	}
+
+	for _, h := range r.Details.WebhookHeaders {
+		hb := b.AppendNewBlock("header", nil).Body()
+		setString(hb, "name", h.Key)
+		setOptionalString(hb, "value", h.Value)
+	}
+	if p := r.Details.WebhookPayloads; p != nil {
+		for _, t := range []struct {
+			typ      string
+			template *client.PayloadTemplate
+		}{
+			{"trigger", p.PayloadTemplates.Trigger},
+			{"exhaustion_time", p.PayloadTemplates.ExhaustionTime},
+			{"budget_rate", p.PayloadTemplates.BudgetRate},
+		} {
+			if t.template == nil {
+				continue
+			}
+			tb := b.AppendNewBlock("template", nil).Body()
+			setString(tb, "type", t.typ)
+			setString(tb, "body", t.template.Body)
+		}
+		for _, v := range p.TemplateVariables {
+			vb := b.AppendNewBlock("variable", nil).Body()
+			setString(vb, "name", v.Name)
+			setOptionalString(vb, "default_value", v.Default)
+		}
+	}
+	return addr
+}
+
+func (e *exporter) exportDataset(ctx context.Context, d client.Dataset) error {
+	addr, b := e.resource(fileDatasets, "honeycombio_dataset", d.Slug)
+	setString(b, "name", d.Name)
+	setOptionalString(b, "description", d.Description)
+	if d.ExpandJSONDepth > 0 {
+		setInt(b, "expand_json_depth", d.ExpandJSONDepth)
+	}
+	e.importBlock(addr, d.Slug)
+
+	columns, err := e.c.Columns.List(ctx, d.Slug)
+	if err != nil {
+		return fmt.Errorf("listing columns of dataset %q: %w", d.Slug, err)
+	}
+	for _, col := range columns {
+		// columns are created by sending events, so only those which have
+		// been configured are worth managing
+		hidden := client.PtrValueOrDefault(col.Hidden, false)
+		if col.Description == "" && !hidden {
+			continue
+		}
+
+		addr, b := e.resource(fileDatasets, "honeycombio_column", d.Slug+"_"+col.KeyName)
+		setString(b, "dataset", d.Slug)
+		setString(b, "name", col.KeyName)
+		setOptionalString(b, "description", col.Description)
+		setOptionalBool(b, "hidden", hidden)
+		if col.Type != nil {
+			setString(b, "type", string(*col.Type))
+		}
+		e.importBlock(addr, d.Slug+"/"+col.KeyName)
+	}
+
+	def, err := e.c.DatasetDefinitions.Get(ctx, d.Slug)
+	if err != nil {
+		return fmt.Errorf("getting definitions of dataset %q: %w", d.Slug, err)
+	}
+	// dataset definitions can't be imported, but creating them sets the
+	// definition to the same column
+	v := reflect.ValueOf(*def)
+	for i := range v.NumField() {
+		col, ok := v.Field(i).Interface().(*client.DefinitionColumn)
+		if !ok || col == nil || col.Name == "" {
+			continue
+		}
+		name, _, _ := strings.Cut(v.Type().Field(i).Tag.Get("json"), ",")
+
+		_, b := e.resource(fileDatasets, "honeycombio_dataset_definition", d.Slug+"_"+name)
+		setString(b, "dataset", d.Slug)
+		setString(b, "name", name)
+		setString(b, "column", col.Name)
+	}
+	return nil
+}
+
+func (e *exporter) exportDerivedColumns(ctx context.Context, slug string) error {
+	columns, err := e.c.DerivedColumns.List(ctx, slug)
+	if err != nil {
+		return fmt.Errorf("listing derived columns of dataset %q: %w", slug, err)
+	}
+
+	for _, dc := range columns {
+		hint := dc.Alias
+		if slug != client.EnvironmentWideSlug {
+			hint = slug + "_" + dc.Alias
+		}
+		addr, b := e.resource(fileDatasets, "honeycombio_derived_column", hint)
+		setString(b, "alias", dc.Alias)
+		setString(b, "expression", dc.Expression)
+		setOptionalString(b, "description", dc.Description)
+		setDataset(b, slug)
+		e.importBlock(addr, importID(slug, dc.Alias))
+	}
+	return nil
+}
+
+// skipMarkerSettings records the marker settings of the dataset as skipped.
+// Creating them would duplicate the existing settings, which can't be
+// imported.
+func (e *exporter) skipMarkerSettings(ctx context.Context, slug string) error {
+	settings, err := e.c.MarkerSettings.List(ctx, slug)
+	if err != nil {
+		return fmt.Errorf("listing marker settings of dataset %q: %w", slug, err)
+	}
+	for _, ms := range settings {
+		e.skip("%s marker setting %s of dataset %s: the honeycombio_marker_setting resource can't import existing marker settings", ms.Type, ms.ID, slug)
+	}
+	return nil
+}
+
+// exportQuerySpecification writes the query as a
+// honeycombio_query_specification data source, returning its address.
+func (e *exporter) exportQuerySpecification(file, hint string, q *client.QuerySpec) address {
+	addr, b := e.block(file, "data", "honeycombio_query_specification", hint)
+	n := q.Normalize()
+
+	for _, c := range n.Calculations {
+		cb := b.AppendNewBlock("calculation", nil).Body()
+		setString(cb, "op", string(c.Op))
+		setOptionalString(cb, "column", client.PtrValueOrDefault(c.Column, ""))
+		setOptionalString(cb, "name", client.PtrValueOrDefault(c.Name, ""))
+		writeFilters(cb, c.Filters)
+		setOptionalString(cb, "filter_combination", string(c.FilterCombination))
+	}
+	for _, f := range n.CalculatedFields {
+		fb := b.AppendNewBlock("calculated_field", nil).Body()
+		setString(fb, "name", f.Name)
+		setString(fb, "expression", f.Expression)
+	}
+	for _, f := range n.Formulas {
+		fb := b.AppendNewBlock("formula", nil).Body()
+		setString(fb, "name", f.Name)
+		setString(fb, "expression", f.Expression)
+	}
+	writeFilters(b, n.Filters)
+	setOptionalString(b, "filter_combination", string(n.FilterCombination))
+	setStrings(b, "breakdowns", n.Breakdowns)
+	for _, o := range n.Orders {
+		ob := b.AppendNewBlock("order", nil).Body()
+		if o.Op != nil {
+			setString(ob, "op", string(*o.Op))
+		}
+		setOptionalString(ob, "column", client.PtrValueOrDefault(o.Column, ""))
+		if o.Order != nil {
+			setString(ob, "order", string(*o.Order))
+		}
+	}
+	for _, h := range n.Havings {
+		hb := b.AppendNewBlock("having", nil).Body()
+		if h.CalculateOp != nil {
+			setString(hb, "calculate_op", string(*h.CalculateOp))
+		}
+		setOptionalString(hb, "column", client.PtrValueOrDefault(h.Column, ""))
+		if h.Op != nil {
+			setString(hb, "op", string(*h.Op))
+		}
+		if v, ok := h.Value.(float64); ok {
+			setFloat(hb, "value", v)
+		}
+	}
+	setOptionalInt(b, "limit", n.Limit)
+	setOptionalInt(b, "time_range", n.TimeRange)
+	setOptionalInt(b, "start_time", n.StartTime)
+	setOptionalInt(b, "end_time", n.EndTime)
+	setOptionalInt(b, "granularity", n.Granularity)
+	setOptionalInt(b, "compare_time_offset", n.CompareTimeOffsetSeconds)
+
+	return addr
+}
+
+func writeFilters(b *hclwrite.Body, filters []client.FilterSpec) {
+	for _, f := range filters {
+		fb := b.AppendNewBlock("filter", nil).Body()
+		setString(fb, "column", f.Column)
+		setString(fb, "op", string(f.Op))
+		if v, ok := formatValue(f.Value); ok {
+			setString(fb, "value", v)
+		}
+	}
+}
+
+// exportQuery writes the query as a honeycombio_query resource, returning
+// its address. Each query is only exported once, however many times it is
+// referenced.
+func (e *exporter) exportQuery(ctx context.Context, slug, id, hint string) (address, error) {
+	if addr, ok := e.queries[id]; ok {
+		return addr, nil
+	}
+
+	q, err := e.c.Queries.Get(ctx, slug, id)
+	if err != nil {
+		return address{}, fmt.Errorf("getting query %q of dataset %q: %w", id, slug, err)
+	}
+	spec := e.exportQuerySpecification(fileQueries, hint, q)
+
+	addr, b := e.resource(fileQueries, "honeycombio_query", hint)
+	setDataset(b, slug)
+	b.SetAttributeTraversal("query_json", spec.traversal("json"))
+	e.importBlock(addr, importID(slug, id))
+
+	e.queries[id] = addr
+	return addr, nil
+}
+
+func (e *exporter) exportQueryAnnotations(ctx context.Context, slug string) error {
+	annotations, err := e.c.QueryAnnotations.List(ctx, slug)
+	if err != nil {
+		return fmt.Errorf("listing query annotations of dataset %q: %w", slug, err)
+	}
+
+	for _, qa := range annotations {
+		query, err := e.exportQuery(ctx, slug, qa.QueryID, qa.Name)
+		if err != nil {
+			return err
+		}
+
+		addr, b := e.resource(fileQueries, "honeycombio_query_annotation", qa.Name)
+		setString(b, "name", qa.Name)
+		setOptionalString(b, "description", qa.Description)
+		b.SetAttributeTraversal("query_id", query.traversal("id"))
+		setDataset(b, slug)
+		e.importBlock(addr, importID(slug, qa.ID))
+
+		e.annotations[qa.ID] = addr
+	}
+	return nil
+}
+
+func (e *exporter) exportSLOs(ctx context.Context, slug string) error {
+	slos, err := e.c.SLOs.List(ctx, slug)
+	if err != nil {
+		return fmt.Errorf("listing SLOs of dataset %q: %w", slug, err)
+	}
+
+	for _, slo := range slos {
+		addr, b := e.resource(fileSLOs, "honeycombio_slo", slo.Name)
+		setString(b, "name", slo.Name)
+		setOptionalString(b, "description", slo.Description)
+		setString(b, "sli", slo.SLI.Alias)
+		setFloat(b, "target_percentage", helper.PPMToFloat(slo.TargetPerMillion))
+		setInt(b, "time_period", slo.TimePeriodDays)
+		if slug == client.EnvironmentWideSlug {
+			setStrings(b, "datasets", slo.DatasetSlugs)
+		} else {
+			setString(b, "dataset", slug)
+		}
+		setTags(b, slo.Tags)
+		e.importBlock(addr, importID(slug, slo.ID))
+		e.slos[slo.ID] = addr
+
+		alerts, err := e.c.BurnAlerts.ListForSLO(ctx, slug, slo.ID)
+		if err != nil {
+			return fmt.Errorf("listing burn alerts of SLO %q: %w", slo.Name, err)
+		}
+		for _, ba := range alerts {
+			e.exportBurnAlert(slug, addr, ba)
+		}
+	}
+	return nil
+}
+
+func (e *exporter) exportBurnAlert(slug string, slo address, ba client.BurnAlert) {
+	addr, b := e.resource(fileSLOs, "honeycombio_burn_alert", slo.name+"_"+string(ba.AlertType))
+	b.SetAttributeTraversal("slo_id", slo.traversal("id"))
+	setDataset(b, slug)
+	setString(b, "alert_type", string(ba.AlertType))
+	setOptionalString(b, "description", ba.Description)
+	switch ba.AlertType {
+	case client.BurnAlertAlertTypeBudgetRate:
+		setOptionalInt(b, "budget_rate_window_minutes", ba.BudgetRateWindowMinutes)
+		if ba.BudgetRateDecreaseThresholdPerMillion != nil {
+			setFloat(b, "budget_rate_decrease_percent", helper.PPMToFloat(*ba.BudgetRateDecreaseThresholdPerMillion))
+		}
+	default:
+		setOptionalInt(b, "exhaustion_minutes", ba.ExhaustionMinutes)
+	}
+	setOptionalBool(b, "auto_investigate", client.PtrValueOrDefault(ba.AutoInvestigate, false))
+	e.writeRecipients(b, ba.Recipients)
+	e.importBlock(addr, importID(slug, ba.ID))
+}
+
+func (e *exporter) exportTriggers(ctx context.Context, slug string) error {
+	triggers, err := e.c.Triggers.List(ctx, slug)
+	if err != nil {
+		return fmt.Errorf("listing triggers of dataset %q: %w", slug, err)
+	}
+
+	for _, t := range triggers {
+		q := t.Query
+		if t.QueryID != "" {
+			if q, err = e.c.Queries.Get(ctx, slug, t.QueryID); err != nil {
+				return fmt.Errorf("getting query of trigger %q: %w", t.Name, err)
+			}
+		}
+		if q == nil {
+			e.skip("trigger %s: it has no query", t.ID)
+			continue
+		}
+		// the trigger is imported with its query as JSON, rather than the
+		// ID of a query
+		spec := e.exportQuerySpecification(fileTriggers, t.Name, q)
+
+		addr, b := e.resource(fileTriggers, "honeycombio_trigger", t.Name)
+		setString(b, "name", t.Name)
+		setOptionalString(b, "description", t.Description)
+		setDataset(b, slug)
+		setOptionalBool(b, "disabled", t.Disabled)
+		b.SetAttributeTraversal("query_json", spec.traversal("json"))
+		setOptionalString(b, "alert_type", string(t.AlertType))
+		if t.Frequency > 0 {
+			setInt(b, "frequency", t.Frequency)
+		}
+		setOptionalBool(b, "auto_investigate", client.PtrValueOrDefault(t.AutoInvestigate, false))
+		setTags(b, t.Tags)
+
+		if t.Threshold != nil {
+			tb := b.AppendNewBlock("threshold", nil).Body()
+			setString(tb, "op", string(t.Threshold.Op))
+			setFloat(tb, "value", t.Threshold.Value)
+			if t.Threshold.ExceededLimit > 0 {
+				setInt(tb, "exceeded_limit", t.Threshold.ExceededLimit)
+			}
+		}
+		if t.EvaluationScheduleType == client.TriggerEvaluationScheduleWindow && t.EvaluationSchedule != nil {
+			sb := b.AppendNewBlock("evaluation_schedule", nil).Body()
+			setString(sb, "start_time", t.EvaluationSchedule.Window.StartTime)
+			setString(sb, "end_time", t.EvaluationSchedule.Window.EndTime)
+			setStrings(sb, "days_of_week", t.EvaluationSchedule.Window.DaysOfWeek)
+		}
+		if t.BaselineDetails != nil {
+			bb := b.AppendNewBlock("baseline_details", nil).Body()
+			setString(bb, "type", t.BaselineDetails.Type)
+			setInt(bb, "offset_minutes", t.BaselineDetails.OffsetMinutes)
+		}
+		e.writeRecipients(b, t.Recipients)
+
+		e.importBlock(addr, importID(slug, t.ID))
+	}
+	return nil
+}
+
+// writeRecipients writes recipient blocks for the notification recipients,
+// referencing the exported recipients by their address.
+func (e *exporter) writeRecipients(b *hclwrite.Body, recipients []client.NotificationRecipient) {
+	for _, r := range recipients {
+		rb := b.AppendNewBlock("recipient", nil).Body()
+		if addr, ok := e.recipients[r.ID]; ok {
+			rb.SetAttributeTraversal("id", addr.traversal("id"))
+		} else if r.ID != "" {
+			setString(rb, "id", r.ID)
+		} else {
+			setString(rb, "type", string(r.Type))
+			setOptionalString(rb, "target", r.Target)
+		}
+
+		if r.Details == nil || (r.Details.PDSeverity == "" && len(r.Details.Variables) == 0) {
+			continue
+		}
+		db := rb.AppendNewBlock("notification_details", nil).Body()
+		setOptionalString(db, "pagerduty_severity", string(r.Details.PDSeverity))
+		for _, v := range r.Details.Variables {
+			vb := db.AppendNewBlock("variable", nil).Body()
+			setString(vb, "name", v.Name)
+			setOptionalString(vb, "value", v.Value)
+		}
+	}
+}
+
+func (e *exporter) exportBoards(ctx context.Context) error {
+	boards, err := e.c.Boards.List(ctx)
+	if err != nil {
+		return fmt.Errorf("listing boards: %w", err)
+	}
+
+	for _, board := range boards {
+		if board.BoardType != client.BoardTypeFlexible {
+			e.skip("board %s: only flexible boards are managed by the provider", board.ID)
+			continue
+		}
+
+		// the queries of the panels are written first, so that they are
+		// declared before the board
+		panelQueries := make([]address, len(board.Panels))
+		for i, p := range board.Panels {
+			if p.QueryPanel == nil {
+				continue
+			}
+			slug := p.QueryPanel.Dataset
+			if slug == "" {
+				slug = client.EnvironmentWideSlug
+			}
+			if panelQueries[i], err = e.exportQuery(ctx, slug, p.QueryPanel.QueryID, fmt.Sprintf("%s panel %d", board.Name, i+1)); err != nil {
+				return err
+			}
+		}
+
+		addr, b := e.resource(fileBoards, "honeycombio_flexible_board", board.Name)
+		setString(b, "name", board.Name)
+		setOptionalString(b, "description", board.Description)
+		setTags(b, board.Tags)
+		if board.PresetFilters != nil {
+			for _, f := range *board.PresetFilters {
+				fb := b.AppendNewBlock("preset_filter", nil).Body()
+				setString(fb, "column", f.Column)
+				setString(fb, "alias", f.Alias)
+			}
+		}
+		for i, p := range board.Panels {
+			e.writePanel(b, p, panelQueries[i])
+		}
+		e.importBlock(addr, board.ID)
+
+		if err := e.exportBoardViews(ctx, board, addr); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (e *exporter) writePanel(b *hclwrite.Body, p client.BoardPanel, query address) {
+	pb := b.AppendNewBlock("panel", nil).Body()
+	setString(pb, "type", string(p.PanelType))
+	if !p.IsBlank() {
+		pos := pb.AppendNewBlock("position", nil).Body()
+		setInt(pos, "x_coordinate", p.PanelPosition.X)
+		setInt(pos, "y_coordinate", p.PanelPosition.Y)
+		setInt(pos, "height", p.PanelPosition.Height)
+		setInt(pos, "width", p.PanelPosition.Width)
+	}
+
+	switch {
+	case p.QueryPanel != nil:
+		qb := pb.AppendNewBlock("query_panel", nil).Body()
+		qb.SetAttributeTraversal("query_id", query.traversal("id"))
+		if addr, ok := e.annotations[p.QueryPanel.QueryAnnotationID]; ok {
+			qb.SetAttributeTraversal("query_annotation_id", addr.traversal("id"))
+		} else {
+			setOptionalString(qb, "query_annotation_id", p.QueryPanel.QueryAnnotationID)
+		}
+		setOptionalString(qb, "query_style", string(p.QueryPanel.Style))
+
+		if vs := p.QueryPanel.VisualizationSettings; vs != nil {
+			vb := qb.AppendNewBlock("visualization_settings", nil).Body()
+			setOptionalBool(vb, "use_utc_xaxis", vs.UseUTCXAxis)
+			setOptionalBool(vb, "hide_markers", vs.HideMarkers)
+			setOptionalBool(vb, "hide_hovers", vs.HideHovers)
+			setOptionalBool(vb, "prefer_overlaid_charts", vs.PreferOverlaidCharts)
+			setOptionalBool(vb, "hide_compare", vs.HideCompare)
+			for _, c := range vs.Charts {
+				cb := vb.AppendNewBlock("chart", nil).Body()
+				setInt(cb, "chart_index", c.ChartIndex)
+				setOptionalString(cb, "chart_type", c.ChartType)
+				setOptionalBool(cb, "omit_missing_values", c.OmitMissingValues)
+				setOptionalBool(cb, "use_log_scale", c.UseLogScale)
+			}
+		}
+	case p.SLOPanel != nil:
+		sb := pb.AppendNewBlock("slo_panel", nil).Body()
+		if addr, ok := e.slos[p.SLOPanel.SLOID]; ok {
+			sb.SetAttributeTraversal("slo_id", addr.traversal("id"))
+		} else {
+			setString(sb, "slo_id", p.SLOPanel.SLOID)
+		}
+	case p.TextPanel != nil:
+		tb := pb.AppendNewBlock("text_panel", nil).Body()
+		setString(tb, "content", p.TextPanel.Content)
+	}
+}
+
+func (e *exporter) exportBoardViews(ctx context.Context, board client.Board, boardAddr address) error {
+	views, err := e.c.BoardViews.List(ctx, board.ID)
+	if err != nil {
+		return fmt.Errorf("listing views of board %q: %w", board.Name, err)
+	}
+
+	for _, v := range views {
+		if len(v.Filters) == 0 {
+			// the default view of every board, which can't be managed
+			continue
+		}
+
+		addr, b := e.resource(fileBoards, "honeycombio_board_view", boardAddr.name+"_"+v.Name)
+		b.SetAttributeTraversal("board_id", boardAddr.traversal("id"))
+		setString(b, "name", v.Name)
+		for _, f := range v.Filters {
+			fb := b.AppendNewBlock("filter", nil).Body()
+			setString(fb, "column", f.Column)
+			setString(fb, "operation", f.Operation)
+			if value, ok := formatValue(f.Value); ok {
+				setString(fb, "value", value)
+			}
+		}
+		e.importBlock(addr, board.ID+"/"+v.ID)
+	}
+	return nil
+}
+
+// fileNames returns the names of the files in the order they are best
+// read in.
+func fileNames(files map[string][]byte) []string {
+	order := []string{fileVariables, fileRecipients, fileDatasets, fileQueries, fileSLOs, fileTriggers, fileBoards, fileImports}
+	return slices.SortedFunc(maps.Keys(files), func(a, b string) int {
+		return slices.Index(order, a) - slices.Index(order, b)
+	})
+}
@@ -0,0 +1,192 @@
+package main
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/hcl/v2/hclparse"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/honeycombio/terraform-provider-honeycombio/client"
+	"github.com/honeycombio/terraform-provider-honeycombio/client/fakeserver"
+)
+
+func TestExport(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	s := fakeserver.New()
+	t.Cleanup(s.Close)
+	c, err := client.NewClientWithConfig(&client.Config{
+		APIKey: s.APIKey(),
+		APIUrl: s.URL,
+	})
+	require.NoError(t, err)
+
+	ds, err := c.Datasets.Create(ctx, &client.Dataset{Name: "api"})
+	require.NoError(t, err)
+	_, err = c.Columns.Create(ctx, ds.Slug, &client.Column{KeyName: "status_code", Description: "The HTTP status code"})
+	require.NoError(t, err)
+	_, err = c.Columns.Create(ctx, ds.Slug, &client.Column{KeyName: "http.route"})
+	require.NoError(t, err)
+	_, err = c.DerivedColumns.Create(ctx, ds.Slug, &client.DerivedColumn{
+		Alias:      "is_error",
+		Expression: "EQUALS($status_code, 500)",
+	})
+	require.NoError(t, err)
+	_, err = c.DatasetDefinitions.Update(ctx, ds.Slug, &client.DatasetDefinition{
+		Route: &client.DefinitionColumn{Name: "http.route"},
+	})
+	require.NoError(t, err)
+
+	email, err := c.Recipients.Create(ctx, &client.Recipient{
+		Type:    client.RecipientTypeEmail,
+		Details: client.RecipientDetails{EmailAddress: "oncall@example.com"},
+	})
+	require.NoError(t, err)
+	pd, err := c.Recipients.Create(ctx, &client.Recipient{
+		Type: client.RecipientTypePagerDuty,
+		Details: client.RecipientDetails{
+			PDIntegrationKey:  "abcdef0123456789abcdef0123456789",
+			PDIntegrationName: "Platform",
+		},
+	})
+	require.NoError(t, err)
+
+	query, err := c.Queries.Create(ctx, ds.Slug, &client.QuerySpec{
+		Calculations: []client.CalculationSpec{{Op: client.CalculationOpCount}},
+		Filters: []client.FilterSpec{
+			{Column: "status_code", Op: client.FilterOpGreaterThanOrEqual, Value: 500},
+		},
+	})
+	require.NoError(t, err)
+	annotation, err := c.QueryAnnotations.Create(ctx, ds.Slug, &client.QueryAnnotation{
+		Name:    "Errors",
+		QueryID: *query.ID,
+	})
+	require.NoError(t, err)
+
+	slo, err := c.SLOs.Create(ctx, ds.Slug, &client.SLO{
+		Name:             "Availability",
+		TimePeriodDays:   30,
+		TargetPerMillion: 999000,
+		SLI:              client.SLIRef{Alias: "is_error"},
+	})
+	require.NoError(t, err)
+	_, err = c.BurnAlerts.Create(ctx, ds.Slug, &client.BurnAlert{
+		AlertType:         client.BurnAlertAlertTypeExhaustionTime,
+		ExhaustionMinutes: client.ToPtr(60),
+		SLO:               client.SLORef{ID: slo.ID},
+		Recipients:        []client.NotificationRecipient{{ID: email.ID}},
+	})
+	require.NoError(t, err)
+
+	trigger, err := c.Triggers.Create(ctx, ds.Slug, &client.Trigger{
+		Name:      "High error rate",
+		QueryID:   *query.ID,
+		Frequency: 300,
+		Threshold: &client.TriggerThreshold{Op: client.TriggerThresholdOpGreaterThan, Value: 10},
+		Recipients: []client.NotificationRecipient{
+			{
+				ID:      pd.ID,
+				Details: &client.NotificationRecipientDetails{PDSeverity: client.PDSeverityCRITICAL},
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	board, err := c.Boards.Create(ctx, &client.Board{
+		Name:      "Service",
+		BoardType: client.BoardTypeFlexible,
+		Panels: []client.BoardPanel{
+			{
+				PanelType: client.BoardPanelTypeQuery,
+				QueryPanel: &client.BoardQueryPanel{
+					Dataset:           ds.Slug,
+					QueryID:           *query.ID,
+					QueryAnnotationID: annotation.ID,
+				},
+			},
+			{
+				PanelType: client.BoardPanelTypeSLO,
+				SLOPanel:  &client.BoardSLOPanel{SLOID: slo.ID},
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	setting, err := c.MarkerSettings.Create(ctx, ds.Slug, &client.MarkerSetting{Type: "deploy", Color: "#00ff00"})
+	require.NoError(t, err)
+
+	files, skipped, err := export(ctx, c)
+	require.NoError(t, err)
+	if assert.Len(t, skipped, 3) {
+		assert.Contains(t, skipped[0], "honeycombio_environment")
+		assert.Contains(t, skipped[1], "honeycombio_api_key")
+		assert.Contains(t, skipped[2], "deploy marker setting "+setting.ID)
+	}
+
+	parser := hclparse.NewParser()
+	for name, content := range files {
+		_, diags := parser.ParseHCL(content, name)
+		require.False(t, diags.HasErrors(), "%s does not parse: %s\n%s", name, diags.Error(), content)
+	}
+
+	assert.ElementsMatch(t,
+		[]string{"variables.tf", "recipients.tf", "datasets.tf", "queries.tf", "slos.tf", "triggers.tf", "boards.tf", "imports.tf"},
+		fileNames(files),
+	)
+
+	recipients := string(files["recipients.tf"])
+	assert.Contains(t, recipients, `resource "honeycombio_email_recipient" "oncall_example_com"`)
+	assert.Contains(t, recipients, "integration_key  = var.platform_integration_key")
+	assert.NotContains(t, recipients, "abcdef0123456789abcdef0123456789", "secrets must not be exported")
+	assert.Contains(t, string(files["variables.tf"]), `variable "platform_integration_key"`)
+
+	datasets := string(files["datasets.tf"])
+	assert.Contains(t, datasets, `resource "honeycombio_column" "api_status_code"`)
+	assert.NotContains(t, datasets, `"api_http_route"`, "unconfigured columns are not exported")
+	assert.Contains(t, datasets, `resource "honeycombio_derived_column" "api_is_error"`)
+	assert.Contains(t, datasets, `resource "honeycombio_dataset_definition" "api_route"`)
+
+	// the query is referenced by the annotation and the board, but only
+	// exported once
+	queries := string(files["queries.tf"])
+	assert.Equal(t, 1, strings.Count(queries, `resource "honeycombio_query" `))
+	assert.Contains(t, queries, `data "honeycombio_query_specification" "errors"`)
+	assert.Contains(t, queries, "query_json = data.honeycombio_query_specification.errors.json")
+	assert.Contains(t, queries, "query_id = honeycombio_query.errors.id")
+
+	slos := string(files["slos.tf"])
+	assert.Contains(t, slos, "target_percentage = 99.9")
+	assert.Contains(t, slos, "slo_id             = honeycombio_slo.availability.id")
+	assert.Contains(t, slos, "id = honeycombio_email_recipient.oncall_example_com.id")
+
+	triggers := string(files["triggers.tf"])
+	assert.Contains(t, triggers, `data "honeycombio_query_specification" "high_error_rate"`)
+	assert.Contains(t, triggers, "query_json = data.honeycombio_query_specification.high_error_rate.json")
+	assert.Contains(t, triggers, "id = honeycombio_pagerduty_recipient.platform.id")
+	assert.Contains(t, triggers, `pagerduty_severity = "critical"`)
+
+	boards := string(files["boards.tf"])
+	assert.Contains(t, boards, "query_id            = honeycombio_query.errors.id")
+	assert.Contains(t, boards, "query_annotation_id = honeycombio_query_annotation.errors.id")
+	assert.Contains(t, boards, "slo_id = honeycombio_slo.availability.id")
+
+	imports := string(files["imports.tf"])
+	for _, want := range []string{
+		`id = "` + ds.Slug + `"`,
+		`id = "` + ds.Slug + `/status_code"`,
+		`id = "` + ds.Slug + `/is_error"`,
+		`id = "` + ds.Slug + "/" + *query.ID + `"`,
+		`id = "` + ds.Slug + "/" + slo.ID + `"`,
+		`id = "` + ds.Slug + "/" + trigger.ID + `"`,
+		`id = "` + email.ID + `"`,
+		`id = "` + board.ID + `"`,
+	} {
+		assert.Contains(t, imports, want)
+	}
+	assert.NotContains(t, imports, "honeycombio_dataset_definition", "dataset definitions can't be imported")
+}
@@ -0,0 +1,169 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// address is the address of a resource or data source in the configuration,
+// such as honeycombio_trigger.high_latency.
+type address struct {
+	// mode is "data" for data sources, and empty for resources
+	mode string
+	typ  string
+	name string
+}
+
+func (a address) String() string {
+	if a.mode != "" {
+		return a.mode + "." + a.typ + "." + a.name
+	}
+	return a.typ + "." + a.name
+}
+
+// traversal returns the traversal of the address, followed by the
+// attributes, such as honeycombio_slo.availability.id.
+func (a address) traversal(attrs ...string) hcl.Traversal {
+	var t hcl.Traversal
+	if a.mode != "" {
+		t = hcl.Traversal{hcl.TraverseRoot{Name: a.mode}, hcl.TraverseAttr{Name: a.typ}}
+	} else {
+		t = hcl.Traversal{hcl.TraverseRoot{Name: a.typ}}
+	}
+	t = append(t, hcl.TraverseAttr{Name: a.name})
+	for _, attr := range attrs {
+		t = append(t, hcl.TraverseAttr{Name: attr})
+	}
+	return t
+}
+
+// names hands out unique names for the resources of each type, derived
+// from a hint such as the name of the trigger.
+type names map[string]map[string]bool
+
+func (n names) next(typ, hint string) string {
+	if n[typ] == nil {
+		n[typ] = make(map[string]bool)
+	}
+
+	base := sanitizeName(hint)
+	if base == "" {
+		base = strings.TrimPrefix(typ, "honeycombio_")
+	}
+	name := base
+	for i := 2; n[typ][name]; i++ {
+		name = base + "_" + strconv.Itoa(i)
+	}
+	n[typ][name] = true
+	return name
+}
+
+// sanitizeName turns the hint into a valid Terraform identifier made of
+// lowercase letters, digits and underscores.
+func sanitizeName(hint string) string {
+	var b strings.Builder
+	underscore := false
+	for _, r := range strings.ToLower(hint) {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+			b.WriteRune(r)
+			underscore = false
+		case !underscore && b.Len() > 0:
+			b.WriteByte('_')
+			underscore = true
+		}
+	}
+
+	name := strings.TrimSuffix(b.String(), "_")
+	if name != "" && name[0] >= '0' && name[0] <= '9' {
+		name = "_" + name
+	}
+	return name
+}
+
+func setString(b *hclwrite.Body, name, value string) {
+	b.SetAttributeValue(name, cty.StringVal(value))
+}
+
+// setOptionalString sets the attribute unless the value is empty.
+func setOptionalString(b *hclwrite.Body, name, value string) {
+	if value != "" {
+		setString(b, name, value)
+	}
+}
+
+func setInt(b *hclwrite.Body, name string, value int) {
+	b.SetAttributeValue(name, cty.NumberIntVal(int64(value)))
+}
+
+// setOptionalInt sets the attribute unless the value is nil.
+func setOptionalInt[T int | int64](b *hclwrite.Body, name string, value *T) {
+	if value != nil {
+		b.SetAttributeValue(name, cty.NumberIntVal(int64(*value)))
+	}
+}
+
+func setFloat(b *hclwrite.Body, name string, value float64) {
+	b.SetAttributeValue(name, cty.NumberFloatVal(value))
+}
+
+// setOptionalBool sets the attribute if the value is true.
+func setOptionalBool(b *hclwrite.Body, name string, value bool) {
+	if value {
+		b.SetAttributeValue(name, cty.True)
+	}
+}
+
+// setStrings sets the attribute to a list of the values, unless there are
+// none.
+func setStrings(b *hclwrite.Body, name string, values []string) {
+	if len(values) == 0 {
+		return
+	}
+	vals := make([]cty.Value, len(values))
+	for i, v := range values {
+		vals[i] = cty.StringVal(v)
+	}
+	b.SetAttributeValue(name, cty.ListVal(vals))
+}
+
+// setStringMap sets the attribute to a map of the values, unless there are
+// none.
+func setStringMap(b *hclwrite.Body, name string, values map[string]string) {
+	if len(values) == 0 {
+		return
+	}
+	vals := make(map[string]cty.Value, len(values))
+	for k, v := range values {
+		vals[k] = cty.StringVal(v)
+	}
+	b.SetAttributeValue(name, cty.MapVal(vals))
+}
+
+// formatValue formats a filter value as the provider expects it: lists are
+// written as comma-separated values.
+func formatValue(v any) (string, bool) {
+	switch v := v.(type) {
+	case nil:
+		return "", false
+	case string:
+		return v, true
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), true
+	case []string:
+		return strings.Join(v, ","), true
+	case []any:
+		parts := make([]string, len(v))
+		for i, p := range v {
+			parts[i], _ = formatValue(p)
+		}
+		return strings.Join(parts, ","), true
+	default:
+		return fmt.Sprint(v), true
+	}
+}
@@ -0,0 +1,60 @@
+// Command export writes the configuration of an existing Honeycomb
+// environment as Terraform configuration for this provider, along with the
+// import blocks which bring it under Terraform's management.
+//
+// The client is configured from the environment like the provider is, with
+// HONEYCOMB_API_KEY and HONEYCOMB_API_ENDPOINT.
+//
+//	go run ./tools/export -out ./exported
+//	cd ./exported && terraform plan
+//
+// Secrets, such as the integration keys of PagerDuty recipients, are not
+// written to the configuration but are declared as sensitive variables.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/honeycombio/terraform-provider-honeycombio/client"
+)
+
+func main() {
+	var out string
+	flag.StringVar(&out, "out", ".", "the directory to write the configuration to")
+	flag.Parse()
+
+	if err := run(context.Background(), out); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func run(ctx context.Context, out string) error {
+	c, err := client.NewClient()
+	if err != nil {
+		return fmt.Errorf("configuring client: %w", err)
+	}
+
+	files, skipped, err := export(ctx, c)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(out, 0o755); err != nil {
+		return err
+	}
+	for _, name := range fileNames(files) {
+		if err := os.WriteFile(filepath.Join(out, name), files[name], 0o644); err != nil {
+			return err
+		}
+		fmt.Println(filepath.Join(out, name))
+	}
+	for _, s := range skipped {
+		fmt.Fprintf(os.Stderr, "skipped %s\n", s)
+	}
+	return nil
+}